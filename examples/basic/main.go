@@ -129,23 +129,24 @@ func main() {
 
 	for _, v := range cases {
 		inputs := map[string]float64{"Temperature": v}
+		outputs, trace, _ := fis.InferWithTrace(inputs)
+		fmt.Printf("\nInput: %v\n", inputs)
+		if trace == nil {
+			continue
+		}
 
 		// Debug: show fuzzified memberships for the input
-		mem := temp.Fuzzify(v)
-		fmt.Printf("\nInput: %v\n", inputs)
 		fmt.Println("  Temperature memberships:")
-		for name, deg := range mem {
+		for name, deg := range trace.Fuzzification["Temperature"] {
 			fmt.Printf("    %s: %.4f\n", name, deg)
 		}
 
 		// Debug: show each rule's firing strength
 		fmt.Println("  Rule firing strengths:")
 		for idx, r := range fis.Rules {
-			strength, _ := r.Evaluate(map[string]map[string]float64{"Temperature": mem})
-			fmt.Printf("    rule %d -> output %s:%s = %.4f\n", idx+1, r.Output.Variable, r.Output.Set, strength)
+			fmt.Printf("    rule %d -> output %s:%s = %.4f\n", idx+1, r.Output.Variable, r.Output.Set, trace.RuleStrengths[idx])
 		}
 
-		outputs, _ := fis.Infer(inputs)
 		fmt.Printf("  Defuzzified Output: %v\n", outputs)
 	}
 }
@@ -13,7 +13,12 @@ import (
 
 // LoadFIS parses a .fis file and returns a configured MamdaniInferenceSystem
 func LoadFIS(filename string) (*inference.MamdaniInferenceSystem, error) {
-	model, err := ParseFIS(filename)
+	return LoadFISWithOptions(filename, DefaultFISOptions())
+}
+
+// LoadFISWithOptions parses a .fis file using the given options and returns a configured MamdaniInferenceSystem
+func LoadFISWithOptions(filename string, opts FISOptions) (*inference.MamdaniInferenceSystem, error) {
+	model, err := ParseFISWithOptions(filename, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -36,6 +41,34 @@ func ConvertToInferenceSystem(model *FISModel) (*inference.MamdaniInferenceSyste
 		return nil, fmt.Errorf("error setting defuzzification method: %w", err)
 	}
 
+	// Record the configured methods; rule operators are resolved per rule in convertRule
+	if model.System.ImpMethod != "" {
+		if err := fis.SetImplicationMethod(model.System.ImpMethod); err != nil {
+			return nil, fmt.Errorf("error setting implication method: %w", err)
+		}
+	}
+	if model.System.AggMethod != "" {
+		if err := fis.SetAggregationMethod(model.System.AggMethod); err != nil {
+			return nil, fmt.Errorf("error setting aggregation method: %w", err)
+		}
+	}
+	// Rules are built with plain AND/OR connectives; the system-wide operators
+	// replace them at evaluation time
+	andOp, err := resolveOperator(model.System.AndMethod, "min")
+	if err != nil {
+		return nil, err
+	}
+	if err := fis.SetAndOperator(andOp); err != nil {
+		return nil, fmt.Errorf("error setting AND method: %w", err)
+	}
+	orOp, err := resolveOperator(model.System.OrMethod, "max")
+	if err != nil {
+		return nil, err
+	}
+	if err := fis.SetOrOperator(orOp); err != nil {
+		return nil, fmt.Errorf("error setting OR method: %w", err)
+	}
+
 	// Convert input variables
 	for i, inputSpec := range model.Inputs {
 		inputVar, err := convertVariable(inputSpec)
@@ -56,6 +89,11 @@ func ConvertToInferenceSystem(model *FISModel) (*inference.MamdaniInferenceSyste
 		if err := fis.AddOutputVariable(outputVar); err != nil {
 			return nil, fmt.Errorf("error adding output variable #%d ('%s'): %w", i+1, outputSpec.Name, err)
 		}
+		if outputSpec.DefuzzMethod != "" {
+			if err := fis.SetOutputDefuzzificationMethod(outputSpec.Name, mapDefuzzMethod(outputSpec.DefuzzMethod)); err != nil {
+				return nil, fmt.Errorf("error setting defuzzification method for output variable #%d ('%s'): %w", i+1, outputSpec.Name, err)
+			}
+		}
 	}
 
 	// Convert rules
@@ -92,43 +130,32 @@ func convertVariable(spec VariableSection) (*variable.FuzzyVariable, error) {
 	return v, nil
 }
 
+// fisKinds maps .fis membership function types to membership.New kinds
+var fisKinds = map[string]string{
+	"trimf":   membership.KindTriangular,
+	"trapmf":  membership.KindTrapezoidal,
+	"gaussmf": membership.KindGaussian,
+}
+
 // convertMembershipFunction converts a MembershipFunctionSpec to a membership.MembershipFunction
 func convertMembershipFunction(spec MembershipFunctionSpec) (membership.MembershipFunction, error) {
-	switch spec.Type {
-	case "trimf":
-		if len(spec.Params) != 3 {
-			return nil, fmt.Errorf("trimf requires 3 parameters, got %d: %v", len(spec.Params), spec.Params)
-		}
-		mf, err := membership.NewTriangular(spec.Params[0], spec.Params[1], spec.Params[2])
-		if err != nil {
-			return nil, fmt.Errorf("invalid trimf parameters: %w", err)
-		}
-		return mf, nil
-
-	case "trapmf":
-		if len(spec.Params) != 4 {
-			return nil, fmt.Errorf("trapmf requires 4 parameters, got %d: %v", len(spec.Params), spec.Params)
-		}
-		mf, err := membership.NewTrapezoidal(spec.Params[0], spec.Params[1], spec.Params[2], spec.Params[3])
-		if err != nil {
-			return nil, fmt.Errorf("invalid trapmf parameters: %w", err)
-		}
-		return mf, nil
-
-	case "gaussmf":
-		if len(spec.Params) != 2 {
-			return nil, fmt.Errorf("gaussmf requires 2 parameters (sigma, center), got %d: %v", len(spec.Params), spec.Params)
+	kind, ok := fisKinds[spec.Type]
+	if !ok {
+		return nil, fmt.Errorf("unsupported membership function type '%s' (supported: trimf, trapmf, gaussmf)", spec.Type)
+	}
+	params := spec.Params
+	if spec.Type == "gaussmf" {
+		if len(params) != 2 {
+			return nil, fmt.Errorf("gaussmf requires 2 parameters (sigma, center), got %d: %v", len(params), params)
 		}
 		// gaussmf params are [sigma, center]
-		mf, err := membership.NewGaussian(spec.Params[1], spec.Params[0])
-		if err != nil {
-			return nil, fmt.Errorf("invalid gaussmf parameters: %w", err)
-		}
-		return mf, nil
-
-	default:
-		return nil, fmt.Errorf("unsupported membership function type '%s' (supported: trimf, trapmf, gaussmf)", spec.Type)
+		params = []float64{params[1], params[0]}
+	}
+	mf, err := membership.New(kind, params)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s parameters: %w", spec.Type, err)
 	}
+	return mf, nil
 }
 
 // convertRule converts a RuleSpec to a Rule
@@ -138,37 +165,47 @@ func convertRule(spec RuleSpec, inputs, outputs []VariableSection) (*rule.Rule,
 		return nil, fmt.Errorf("rule must have at least one consequent")
 	}
 
-	// Get first non-zero consequent
-	var outputVarIdx, outputSetIdx int
+	// Collect every non-zero consequent (0 means the output is not affected)
+	var consequents []rule.RuleCondition
 	for i, idx := range spec.Consequents {
-		if idx != 0 {
-			outputVarIdx = i
-			outputSetIdx = idx - 1 // Convert from 1-based to 0-based
-			break
+		if idx == 0 {
+			continue
+		}
+		if idx < 0 {
+			return nil, fmt.Errorf("consequent %d cannot be negated (index %d)", i+1, idx)
+		}
+		if i >= len(outputs) {
+			return nil, fmt.Errorf("consequent index %d exceeds number of outputs %d", i, len(outputs))
+		}
+		setIdx := idx - 1 // Convert from 1-based to 0-based
+		if setIdx >= len(outputs[i].MFs) {
+			return nil, fmt.Errorf("invalid MF index %d for output %s", idx, outputs[i].Name)
 		}
+		consequents = append(consequents, rule.RuleCondition{
+			Variable: outputs[i].Name,
+			Set:      outputs[i].MFs[setIdx].Name,
+		})
 	}
-
-	if outputVarIdx >= len(outputs) || outputSetIdx >= len(outputs[outputVarIdx].MFs) {
-		return nil, fmt.Errorf("invalid output index in rule")
+	if len(consequents) == 0 {
+		return nil, fmt.Errorf("rule must have at least one non-zero consequent")
 	}
 
-	outputVar := outputs[outputVarIdx].Name
-	outputSet := outputs[outputVarIdx].MFs[outputSetIdx].Name
-
-	// Determine operator
+	// Determine connective
 	var op operators.Operator = operators.AND
 	if spec.Connection == 2 {
 		op = operators.OR
 	}
 
 	// Create rule
-	r, err := rule.NewRule(rule.RuleCondition{
-		Variable: outputVar,
-		Set:      outputSet,
-	}, op)
+	r, err := rule.NewRule(consequents[0], op)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create rule: %w", err)
 	}
+	for _, out := range consequents[1:] {
+		if err := r.AddOutput(out.Variable, out.Set); err != nil {
+			return nil, fmt.Errorf("failed to add consequent: %w", err)
+		}
+	}
 
 	// Add conditions
 	for i, idx := range spec.Antecedents {
@@ -200,6 +237,12 @@ func convertRule(spec RuleSpec, inputs, outputs []VariableSection) (*rule.Rule,
 		r.Conditions = append(r.Conditions, condition)
 	}
 
+	if len(spec.Connections) > 0 {
+		if err := applyConnectives(r, spec.Connections); err != nil {
+			return nil, err
+		}
+	}
+
 	// Set weight (validate it's in valid range)
 	if err := r.SetWeight(spec.Weight); err != nil {
 		return nil, fmt.Errorf("invalid rule weight %.2f: %w", spec.Weight, err)
@@ -208,15 +251,72 @@ func convertRule(spec RuleSpec, inputs, outputs []VariableSection) (*rule.Rule,
 	return r, nil
 }
 
+// methodName returns name, or fallback when name is empty
+func methodName(name, fallback string) string {
+	if name == "" {
+		return fallback
+	}
+	return name
+}
+
+// resolveOperator resolves a FIS AndMethod/OrMethod name, using fallback when name is empty
+func resolveOperator(name, fallback string) (operators.Operator, error) {
+	op, err := operators.ByName(methodName(name, fallback))
+	if err != nil {
+		return nil, fmt.Errorf("unsupported rule operator: %w", err)
+	}
+	return op, nil
+}
+
+// applyConnectives regroups the conditions of r according to per-condition connectives.
+// AND binds tighter than OR, so "A AND B OR C" becomes the groups (A AND B) and (C),
+// combined by OR. Uniform connectives keep the flat condition list.
+func applyConnectives(r *rule.Rule, connections []int) error {
+	if len(connections) != len(r.Conditions)-1 {
+		return fmt.Errorf("expected %d connectives for %d conditions, got %d", len(r.Conditions)-1, len(r.Conditions), len(connections))
+	}
+	var groups [][]rule.RuleCondition
+	current := []rule.RuleCondition{r.Conditions[0]}
+	for i, c := range connections {
+		if c == 2 {
+			groups = append(groups, current)
+			current = nil
+		}
+		current = append(current, r.Conditions[i+1])
+	}
+	groups = append(groups, current)
+
+	switch {
+	case len(groups) == 1:
+		r.Operator = operators.AND
+	case len(groups) == len(r.Conditions):
+		r.Operator = operators.OR
+	default:
+		r.Operator = operators.OR
+		r.GroupOperator = operators.AND
+		r.Conditions = nil
+		for _, g := range groups {
+			if err := r.AddGroup(g...); err != nil {
+				return fmt.Errorf("failed to add condition group: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
 // mapDefuzzMethod maps FIS defuzzification method names to internal constants
 func mapDefuzzMethod(fisMethod string) string {
 	switch fisMethod {
-	case "centroid", "bisector":
+	case "centroid":
 		return inference.DefuzzCOG
+	case "bisector":
+		return inference.DefuzzBIS
 	case "mom":
 		return inference.DefuzzMOM
-	case "som", "lom":
-		return inference.DefuzzFOM
+	case "som":
+		return inference.DefuzzSOM
+	case "lom":
+		return inference.DefuzzLOM
 	default:
 		// Default to MOM
 		return inference.DefuzzMOM
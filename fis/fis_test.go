@@ -1,6 +1,11 @@
 package fis
 
 import (
+	"errors"
+	"github.com/loian/fuzzylib/inference"
+	"github.com/loian/fuzzylib/operators"
+	"math"
+	"strings"
 	"testing"
 )
 
@@ -180,3 +185,498 @@ func TestLoadFIS_Negation(t *testing.T) {
 		t.Errorf("Expected medium-high fan speed (>50) for hot+wet conditions, got %f", fanSpeed2)
 	}
 }
+
+const multiOutputFIS = `[System]
+Name='MultiOutput'
+Type='mamdani'
+NumInputs=1
+NumOutputs=2
+NumRules=2
+DefuzzMethod='centroid'
+
+[Input1]
+Name='Temperature'
+Range=[0 50]
+NumMFs=2
+MF1='Cold':'trimf',[0 0 30]
+MF2='Hot':'trimf',[20 50 50]
+
+[Output1]
+Name='FanSpeed'
+Range=[0 100]
+NumMFs=2
+MF1='Low':'trimf',[0 0 60]
+MF2='High':'trimf',[40 100 100]
+
+[Output2]
+Name='Vent'
+Range=[0 1]
+NumMFs=2
+MF1='Closed':'trimf',[0 0 0.6]
+MF2='Open':'trimf',[0.4 1 1]
+
+[Rules]
+1, 1 1 (1.0) : 1
+2, 2 2 (1.0) : 1
+`
+
+func TestLoadFIS_MultipleConsequents(t *testing.T) {
+	model, err := ParseFISString(multiOutputFIS)
+	if err != nil {
+		t.Fatalf("Failed to parse FIS: %v", err)
+	}
+	fis, err := ConvertToInferenceSystem(model)
+	if err != nil {
+		t.Fatalf("Failed to convert FIS: %v", err)
+	}
+
+	if len(fis.Rules) != 2 {
+		t.Fatalf("Expected 2 rules, got %d", len(fis.Rules))
+	}
+	outputs := fis.Rules[1].Outputs()
+	if len(outputs) != 2 {
+		t.Fatalf("Expected 2 consequents on rule 2, got %d", len(outputs))
+	}
+	if outputs[0].Variable != "FanSpeed" || outputs[0].Set != "High" {
+		t.Errorf("Expected first consequent FanSpeed.High, got %s.%s", outputs[0].Variable, outputs[0].Set)
+	}
+	if outputs[1].Variable != "Vent" || outputs[1].Set != "Open" {
+		t.Errorf("Expected second consequent Vent.Open, got %s.%s", outputs[1].Variable, outputs[1].Set)
+	}
+
+	results, err := fis.Infer(map[string]float64{"Temperature": 45})
+	if err != nil {
+		t.Fatalf("Inference failed: %v", err)
+	}
+	if results["FanSpeed"] < 50 || results["Vent"] < 0.5 {
+		t.Errorf("Expected both outputs driven high, got FanSpeed=%f Vent=%f", results["FanSpeed"], results["Vent"])
+	}
+}
+
+func TestConvertRule_SkipsZeroConsequents(t *testing.T) {
+	model, _ := ParseFISString(multiOutputFIS)
+	model.Rules[0].Consequents = []int{0, 1}
+	fis, err := ConvertToInferenceSystem(model)
+	if err != nil {
+		t.Fatalf("Failed to convert FIS: %v", err)
+	}
+	outputs := fis.Rules[0].Outputs()
+	if len(outputs) != 1 || outputs[0].Variable != "Vent" {
+		t.Errorf("Expected single Vent consequent, got %v", outputs)
+	}
+
+	model.Rules[0].Consequents = []int{0, 0}
+	if _, err := ConvertToInferenceSystem(model); err == nil {
+		t.Error("Expected error for rule without non-zero consequents, got nil")
+	}
+}
+
+func TestParseFIS_DefaultRuleWeight(t *testing.T) {
+	content := `[System]
+Name='Weights'
+NumInputs=1
+NumOutputs=1
+
+[Rules]
+1, 1 : 1
+1, 1 (0.3) : 1
+`
+	model, err := ParseFISStringWithOptions(content, FISOptions{DefaultRuleWeight: 0.7})
+	if err != nil {
+		t.Fatalf("Failed to parse FIS: %v", err)
+	}
+	if len(model.Rules) != 2 {
+		t.Fatalf("Expected 2 rules, got %d", len(model.Rules))
+	}
+	if model.Rules[0].Weight != 0.7 {
+		t.Errorf("Expected weight-less rule to use default 0.7, got %f", model.Rules[0].Weight)
+	}
+	if model.Rules[1].Weight != 0.3 {
+		t.Errorf("Expected explicit weight 0.3 to be kept, got %f", model.Rules[1].Weight)
+	}
+
+	// Standard parsing keeps the 1.0 default
+	model, _ = ParseFISString(content)
+	if model.Rules[0].Weight != 1.0 {
+		t.Errorf("Expected default weight 1.0, got %f", model.Rules[0].Weight)
+	}
+
+	if _, err := ParseFISStringWithOptions(content, FISOptions{DefaultRuleWeight: 1.5}); err == nil {
+		t.Error("Expected error for default weight outside [0, 1], got nil")
+	}
+
+	// Options built as a literal leave the weight unset, which means 1.0
+	for _, opts := range []FISOptions{{}, {ExtendedConnectives: true}} {
+		model, err := ParseFISStringWithOptions(content, opts)
+		if err != nil {
+			t.Fatalf("Failed to parse FIS with %+v: %v", opts, err)
+		}
+		if model.Rules[0].Weight != 1.0 || model.Rules[1].Weight != 0.3 {
+			t.Errorf("Expected weights 1.0 and 0.3 with %+v, got %f and %f", opts, model.Rules[0].Weight, model.Rules[1].Weight)
+		}
+	}
+}
+
+func TestLoadFIS_PerOutputDefuzzMethod(t *testing.T) {
+	content := strings.Replace(multiOutputFIS, "Name='FanSpeed'\n", "Name='FanSpeed'\nDefuzzMethod='centroid'\n", 1)
+	content = strings.Replace(content, "Name='Vent'\n", "Name='Vent'\nDefuzzMethod='mom'\n", 1)
+
+	model, err := ParseFISString(content)
+	if err != nil {
+		t.Fatalf("Failed to parse FIS: %v", err)
+	}
+	if model.Outputs[0].DefuzzMethod != "centroid" || model.Outputs[1].DefuzzMethod != "mom" {
+		t.Fatalf("Expected per-output methods centroid and mom, got '%s' and '%s'",
+			model.Outputs[0].DefuzzMethod, model.Outputs[1].DefuzzMethod)
+	}
+
+	fis, err := ConvertToInferenceSystem(model)
+	if err != nil {
+		t.Fatalf("Failed to convert FIS: %v", err)
+	}
+	if fis.OutputDefuzzMethods["FanSpeed"] != inference.DefuzzCOG || fis.OutputDefuzzMethods["Vent"] != inference.DefuzzMOM {
+		t.Errorf("Expected overrides centroid/mom, got %v", fis.OutputDefuzzMethods)
+	}
+	results, err := fis.Infer(map[string]float64{"Temperature": 45})
+	if err != nil {
+		t.Fatalf("Inference failed: %v", err)
+	}
+
+	// Each output must match a system loaded with that method for every output
+	for method, output := range map[string]string{"centroid": "FanSpeed", "mom": "Vent"} {
+		single, _ := ParseFISString(strings.Replace(multiOutputFIS, "DefuzzMethod='centroid'", "DefuzzMethod='"+method+"'", 1))
+		ref, err := ConvertToInferenceSystem(single)
+		if err != nil {
+			t.Fatalf("Failed to convert reference FIS: %v", err)
+		}
+		want, err := ref.Infer(map[string]float64{"Temperature": 45})
+		if err != nil {
+			t.Fatalf("Reference inference failed: %v", err)
+		}
+		if results[output] != want[output] {
+			t.Errorf("%s: expected %s result %f, got %f", output, method, want[output], results[output])
+		}
+	}
+
+	// Files without per-output methods keep using the system method
+	model, _ = ParseFISString(multiOutputFIS)
+	fis, _ = ConvertToInferenceSystem(model)
+	if len(fis.OutputDefuzzMethods) != 0 {
+		t.Errorf("Expected no per-output overrides, got %v", fis.OutputDefuzzMethods)
+	}
+}
+
+func TestConvertRule_OperatorMethods(t *testing.T) {
+	model, _ := ParseFISString(multiOutputFIS)
+	model.System.AndMethod = "prod"
+	model.System.OrMethod = "probor"
+	model.Rules[1].Connection = 2
+	fis, err := ConvertToInferenceSystem(model)
+	if err != nil {
+		t.Fatalf("Failed to convert FIS: %v", err)
+	}
+	// Rules keep plain connectives; the system replaces them during evaluation
+	if fis.Rules[0].Operator != operators.AND || fis.Rules[1].Operator != operators.OR {
+		t.Errorf("Expected AND and OR connectives, got %T and %T", fis.Rules[0].Operator, fis.Rules[1].Operator)
+	}
+	if fis.AndOperator != operators.PROD {
+		t.Errorf("Expected AND operator PROD, got %T", fis.AndOperator)
+	}
+	if fis.OrOperator != operators.PROBOR {
+		t.Errorf("Expected OR operator PROBOR, got %T", fis.OrOperator)
+	}
+
+	model.System.AndMethod = "custom"
+	if _, err := ConvertToInferenceSystem(model); !errors.Is(err, operators.ErrUnknownOperator) {
+		t.Errorf("Expected ErrUnknownOperator for unsupported AndMethod, got %v", err)
+	}
+}
+
+func TestParseFIS_ExtendedConnectives(t *testing.T) {
+	content := `[System]
+Name='Mixed'
+NumInputs=3
+NumOutputs=1
+
+[Input1]
+Name='A'
+Range=[0 1]
+NumMFs=2
+MF1='Low':'trimf',[0 0 1]
+MF2='High':'trimf',[0 1 1]
+
+[Input2]
+Name='B'
+Range=[0 1]
+NumMFs=2
+MF1='Low':'trimf',[0 0 1]
+MF2='High':'trimf',[0 1 1]
+
+[Input3]
+Name='C'
+Range=[0 1]
+NumMFs=2
+MF1='Low':'trimf',[0 0 1]
+MF2='High':'trimf',[0 1 1]
+
+[Output1]
+Name='Y'
+Range=[0 1]
+NumMFs=2
+MF1='Low':'trimf',[0 0 1]
+MF2='High':'trimf',[0 1 1]
+
+[Rules]
+2 2 2, 2 (1) : 1 2
+`
+	opts := DefaultFISOptions()
+	opts.ExtendedConnectives = true
+	model, err := ParseFISStringWithOptions(content, opts)
+	if err != nil {
+		t.Fatalf("Failed to parse FIS: %v", err)
+	}
+	if got := model.Rules[0].Connections; len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Fatalf("Expected connectives [1 2], got %v", got)
+	}
+
+	fis, err := ConvertToInferenceSystem(model)
+	if err != nil {
+		t.Fatalf("Failed to convert FIS: %v", err)
+	}
+	r := fis.Rules[0]
+	if len(r.Groups) != 2 || len(r.Groups[0].Conditions) != 2 || len(r.Groups[1].Conditions) != 1 {
+		t.Fatalf("Expected groups (A AND B) OR (C), got %+v", r.Groups)
+	}
+
+	// (A AND B) OR C
+	degrees := map[string]map[string]float64{
+		"A": {"High": 0.8},
+		"B": {"High": 0.3},
+		"C": {"High": 0.5},
+	}
+	strength, err := r.Evaluate(degrees)
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	if strength != 0.5 {
+		t.Errorf("Expected strength max(min(0.8, 0.3), 0.5) = 0.5, got %f", strength)
+	}
+	degrees["C"]["High"] = 0.1
+	if strength, _ = r.Evaluate(degrees); strength != 0.3 {
+		t.Errorf("Expected strength max(min(0.8, 0.3), 0.1) = 0.3, got %f", strength)
+	}
+
+	// Standard parsing ignores the connective list
+	model, err = ParseFISString(content)
+	if err != nil {
+		t.Fatalf("Failed to parse FIS: %v", err)
+	}
+	if model.Rules[0].Connections != nil {
+		t.Errorf("Expected no connectives in standard mode, got %v", model.Rules[0].Connections)
+	}
+
+	// Connective count must match the number of conditions
+	bad := strings.Replace(content, ": 1 2", ": 1 2 1", 1)
+	if _, err := ParseFISStringWithOptions(bad, opts); err == nil {
+		t.Error("Expected error for connective count mismatch, got nil")
+	}
+}
+
+func TestLoadFIS_ProbOrMethod(t *testing.T) {
+	content := `[System]
+Name='ProbOr'
+Type='mamdani'
+NumInputs=2
+NumOutputs=1
+AndMethod='min'
+OrMethod='probor'
+
+[Input1]
+Name='A'
+Range=[0 1]
+NumMFs=1
+MF1='High':'trimf',[0 1 1]
+
+[Input2]
+Name='B'
+Range=[0 1]
+NumMFs=1
+MF1='High':'trimf',[0 1 1]
+
+[Output1]
+Name='Y'
+Range=[0 1]
+NumMFs=1
+MF1='High':'trimf',[0 1 1]
+
+[Rules]
+1 1, 1 (1) : 2
+`
+	model, err := ParseFISString(content)
+	if err != nil {
+		t.Fatalf("Failed to parse FIS: %v", err)
+	}
+	fis, err := ConvertToInferenceSystem(model)
+	if err != nil {
+		t.Fatalf("Failed to convert FIS: %v", err)
+	}
+	fired, err := fis.InferFuzzy(map[string]float64{"A": 0.5, "B": 0.4})
+	if err != nil {
+		t.Fatalf("InferFuzzy failed: %v", err)
+	}
+	// probor: 0.5 + 0.4 - 0.5*0.4 = 0.7 (max would give 0.5)
+	if got := fired["Y"]["High"]; got < 0.7-1e-9 || got > 0.7+1e-9 {
+		t.Errorf("Expected probabilistic OR strength 0.7, got %f", got)
+	}
+}
+
+func TestLoadFIS_MethodNames(t *testing.T) {
+	content := `[System]
+Name='Methods'
+Type='mamdani'
+NumInputs=1
+NumOutputs=1
+AndMethod='prod'
+OrMethod='max'
+ImpMethod='min'
+AggMethod='max'
+
+[Input1]
+Name='A'
+Range=[0 1]
+NumMFs=1
+MF1='High':'trimf',[0 1 1]
+
+[Output1]
+Name='Y'
+Range=[0 1]
+NumMFs=1
+MF1='High':'trimf',[0 1 1]
+
+[Rules]
+1, 1 (1) : 1
+`
+	model, err := ParseFISString(content)
+	if err != nil {
+		t.Fatalf("Failed to parse FIS: %v", err)
+	}
+	fis, err := ConvertToInferenceSystem(model)
+	if err != nil {
+		t.Fatalf("Failed to convert FIS: %v", err)
+	}
+	names := map[string][2]string{
+		"AndMethodName": {fis.AndMethodName(), "prod"},
+		"OrMethodName":  {fis.OrMethodName(), "max"},
+		"ImpMethodName": {fis.ImpMethodName(), "min"},
+		"AggMethodName": {fis.AggMethodName(), "max"},
+	}
+	for accessor, got := range names {
+		if got[0] != got[1] {
+			t.Errorf("%s() = %q, expected %q", accessor, got[0], got[1])
+		}
+	}
+
+	model.System.ImpMethod = "max"
+	if _, err := ConvertToInferenceSystem(model); err == nil {
+		t.Error("Expected error for unsupported ImpMethod, got nil")
+	}
+	model.System.ImpMethod = "min"
+	model.System.AggMethod = "probor"
+	fis, err = ConvertToInferenceSystem(model)
+	if err != nil {
+		t.Fatalf("Failed to convert FIS with probor aggregation: %v", err)
+	}
+	if fis.AggregationMethod != inference.AggregationProbOr {
+		t.Errorf("Expected aggregation %q, got %q", inference.AggregationProbOr, fis.AggregationMethod)
+	}
+	model.System.AggMethod = "bounded"
+	if _, err := ConvertToInferenceSystem(model); err == nil {
+		t.Error("Expected error for unsupported AggMethod, got nil")
+	}
+}
+
+func TestLoadFIS_BisectorMethod(t *testing.T) {
+	load := func(method string) *inference.MamdaniInferenceSystem {
+		model, err := ParseFISString(strings.Replace(multiOutputFIS, "DefuzzMethod='centroid'", "DefuzzMethod='"+method+"'", 1))
+		if err != nil {
+			t.Fatalf("Failed to parse FIS: %v", err)
+		}
+		fis, err := ConvertToInferenceSystem(model)
+		if err != nil {
+			t.Fatalf("Failed to convert FIS: %v", err)
+		}
+		return fis
+	}
+	bisector := load("bisector")
+	if bisector.DefuzzMethod != inference.DefuzzBIS {
+		t.Fatalf("Expected bisector to map to %s, got %s", inference.DefuzzBIS, bisector.DefuzzMethod)
+	}
+
+	// Only Cold fires at 10, leaving the clipped, right-skewed Low triangle
+	// [0 0 60], whose bisector sits left of its centroid
+	inputs := map[string]float64{"Temperature": 10}
+	bis, err := bisector.Infer(inputs)
+	if err != nil {
+		t.Fatalf("Bisector inference failed: %v", err)
+	}
+	cog, err := load("centroid").Infer(inputs)
+	if err != nil {
+		t.Fatalf("Centroid inference failed: %v", err)
+	}
+	if bis["FanSpeed"] >= cog["FanSpeed"] {
+		t.Errorf("Expected bisector %f below centroid %f on a right-skewed set", bis["FanSpeed"], cog["FanSpeed"])
+	}
+	if math.Abs(bis["FanSpeed"]-cog["FanSpeed"]) < 1 {
+		t.Errorf("Expected bisector %f and centroid %f to differ", bis["FanSpeed"], cog["FanSpeed"])
+	}
+}
+
+func TestLoadFIS_AndMethodProduct(t *testing.T) {
+	content := `[System]
+Name='Product'
+Type='mamdani'
+NumInputs=2
+NumOutputs=1
+AndMethod='prod'
+
+[Input1]
+Name='A'
+Range=[0 1]
+NumMFs=1
+MF1='High':'trimf',[0 1 2]
+
+[Input2]
+Name='B'
+Range=[0 1]
+NumMFs=1
+MF1='High':'trimf',[0 1 2]
+
+[Output1]
+Name='Y'
+Range=[0 1]
+NumMFs=1
+MF1='High':'trimf',[0 1 2]
+
+[Rules]
+1 1, 1 (1) : 1
+`
+	model, err := ParseFISString(content)
+	if err != nil {
+		t.Fatalf("Failed to parse FIS: %v", err)
+	}
+	fis, err := ConvertToInferenceSystem(model)
+	if err != nil {
+		t.Fatalf("Failed to convert FIS: %v", err)
+	}
+
+	fired, err := fis.InferFuzzy(map[string]float64{"A": 0.5, "B": 0.6})
+	if err != nil {
+		t.Fatalf("InferFuzzy failed: %v", err)
+	}
+	if got := fired["Y"]["High"]; math.Abs(got-0.3) > 1e-9 {
+		t.Errorf("Expected product 0.5*0.6 = 0.3, got %f", got)
+	}
+	if got := fis.AndMethodName(); got != "prod" {
+		t.Errorf("AndMethodName() = %q, expected \"prod\"", got)
+	}
+}
@@ -25,10 +25,11 @@ type SystemSection struct {
 
 // VariableSection represents an [Input#] or [Output#] section
 type VariableSection struct {
-	Name   string
-	Range  [2]float64
-	NumMFs int
-	MFs    []MembershipFunctionSpec
+	Name         string
+	Range        [2]float64
+	NumMFs       int
+	MFs          []MembershipFunctionSpec
+	DefuzzMethod string // Per-output override of the system DefuzzMethod (extended format, outputs only)
 }
 
 // MembershipFunctionSpec represents a membership function definition
@@ -44,4 +45,5 @@ type RuleSpec struct {
 	Consequents []int   // MF indices for outputs (1-based)
 	Weight      float64 // Rule weight (default 1.0)
 	Connection  int     // 1=AND, 2=OR
+	Connections []int   // Per-condition connectives between non-zero antecedents (extended mode only, nil otherwise)
 }
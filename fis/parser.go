@@ -8,25 +8,81 @@ import (
 	"strings"
 )
 
+// FISOptions controls how .fis content is parsed
+type FISOptions struct {
+	// DefaultRuleWeight is the weight given to rules without an explicit "(w)".
+	// Must be in range [0, 1]; 0 means unset and gives the standard 1.0, so
+	// options built as a literal without it keep every rule firing.
+	DefaultRuleWeight float64
+
+	// ExtendedConnectives enables per-condition connectives in rule lines, as written
+	// by jFuzzyLogic exports: "1 2 3, 1 (1) : 1 2" reads "A AND B OR C". The list after
+	// ":" holds one connective (1=AND, 2=OR) between each pair of non-zero antecedents.
+	// A single connective keeps the standard meaning. Disabled by default.
+	ExtendedConnectives bool
+}
+
+// DefaultFISOptions returns the options used by ParseFIS, ParseFISString and ParseFISReader
+func DefaultFISOptions() FISOptions {
+	return FISOptions{DefaultRuleWeight: 1.0}
+}
+
+// validate checks that the options are usable
+func (o FISOptions) validate() error {
+	if o.DefaultRuleWeight < 0 || o.DefaultRuleWeight > 1 {
+		return fmt.Errorf("default rule weight must be in range [0, 1], got %.2f", o.DefaultRuleWeight)
+	}
+	return nil
+}
+
+// withDefaults returns o with unset fields replaced by those of DefaultFISOptions
+func (o FISOptions) withDefaults() FISOptions {
+	if o.DefaultRuleWeight == 0 {
+		o.DefaultRuleWeight = DefaultFISOptions().DefaultRuleWeight
+	}
+	return o
+}
+
 // ParseFIS parses a .fis file and returns a FISModel
 func ParseFIS(filename string) (*FISModel, error) {
+	return ParseFISWithOptions(filename, DefaultFISOptions())
+}
+
+// ParseFISWithOptions parses a .fis file using the given options
+func ParseFISWithOptions(filename string, opts FISOptions) (*FISModel, error) {
 	file, err := os.Open(filename)
 	if err != nil {
 		return nil, err
 	}
 	defer file.Close()
 
-	return ParseFISReader(bufio.NewScanner(file))
+	return ParseFISReaderWithOptions(bufio.NewScanner(file), opts)
 }
 
 // ParseFISString parses FIS content from a string
 func ParseFISString(content string) (*FISModel, error) {
+	return ParseFISStringWithOptions(content, DefaultFISOptions())
+}
+
+// ParseFISStringWithOptions parses FIS content from a string using the given options
+func ParseFISStringWithOptions(content string, opts FISOptions) (*FISModel, error) {
 	scanner := bufio.NewScanner(strings.NewReader(content))
-	return ParseFISReader(scanner)
+	return ParseFISReaderWithOptions(scanner, opts)
 }
 
 // ParseFISReader parses FIS content from a scanner
 func ParseFISReader(scanner *bufio.Scanner) (*FISModel, error) {
+	return ParseFISReaderWithOptions(scanner, DefaultFISOptions())
+}
+
+// ParseFISReaderWithOptions parses FIS content from a scanner using the given options.
+// Returns error if the options are invalid.
+func ParseFISReaderWithOptions(scanner *bufio.Scanner, opts FISOptions) (*FISModel, error) {
+	if err := opts.validate(); err != nil {
+		return nil, fmt.Errorf("invalid FIS options: %w", err)
+	}
+	opts = opts.withDefaults()
+
 	model := &FISModel{
 		Inputs:  make([]VariableSection, 0),
 		Outputs: make([]VariableSection, 0),
@@ -82,7 +138,7 @@ func ParseFISReader(scanner *bufio.Scanner) (*FISModel, error) {
 				}
 			}
 		case currentSection == "Rules":
-			rule, err := parseRuleLine(line, model.System.NumInputs, model.System.NumOutputs)
+			rule, err := parseRuleLine(line, model.System.NumInputs, model.System.NumOutputs, opts)
 			if err != nil {
 				return nil, fmt.Errorf("line %d: error parsing rule line '%s': %w", lineNum, line, err)
 			}
@@ -159,6 +215,8 @@ func parseVariableLine(v *VariableSection, line string) error {
 		v.Range = [2]float64{rangeVals[0], rangeVals[1]}
 	case "NumMFs":
 		v.NumMFs, _ = strconv.Atoi(value)
+	case "DefuzzMethod":
+		v.DefuzzMethod = value
 	default:
 		// Check if it's a membership function definition (MF1, MF2, etc.)
 		if strings.HasPrefix(key, "MF") {
@@ -174,7 +232,8 @@ func parseVariableLine(v *VariableSection, line string) error {
 }
 
 // parseRuleLine parses a rule line: "1 2 0, 3 (1.0) : 1"
-func parseRuleLine(line string, numInputs, numOutputs int) (*RuleSpec, error) {
+// opts.DefaultRuleWeight is used when the line has no "(w)" weight.
+func parseRuleLine(line string, numInputs, numOutputs int, opts FISOptions) (*RuleSpec, error) {
 	// Split by comma
 	parts := strings.Split(line, ",")
 	if len(parts) < 2 {
@@ -191,7 +250,7 @@ func parseRuleLine(line string, numInputs, numOutputs int) (*RuleSpec, error) {
 	rest := strings.TrimSpace(parts[1])
 
 	// Extract weight if present: (1.0)
-	weight := 1.0
+	weight := opts.DefaultRuleWeight
 	if idx := strings.Index(rest, "("); idx >= 0 {
 		endIdx := strings.Index(rest, ")")
 		if endIdx > idx {
@@ -203,11 +262,20 @@ func parseRuleLine(line string, numInputs, numOutputs int) (*RuleSpec, error) {
 
 	// Parse consequents and connection operator
 	connection := 1 // AND default
+	var connections []int
 	consequentPart := rest
 	if idx := strings.Index(rest, ":"); idx >= 0 {
 		consequentPart = strings.TrimSpace(rest[:idx])
 		connectionStr := strings.TrimSpace(rest[idx+1:])
-		connection, _ = strconv.Atoi(connectionStr)
+		if fields := strings.Fields(connectionStr); opts.ExtendedConnectives && len(fields) > 1 {
+			connections, err = parseConnectives(fields, antecedents)
+			if err != nil {
+				return nil, err
+			}
+			connection = connections[0]
+		} else {
+			connection, _ = strconv.Atoi(connectionStr)
+		}
 	}
 
 	consequents, err := parseIndices(consequentPart, numOutputs)
@@ -220,9 +288,33 @@ func parseRuleLine(line string, numInputs, numOutputs int) (*RuleSpec, error) {
 		Consequents: consequents,
 		Weight:      weight,
 		Connection:  connection,
+		Connections: connections,
 	}, nil
 }
 
+// parseConnectives parses an extended per-condition connective list. There must be
+// one connective (1=AND, 2=OR) between each pair of non-zero antecedents.
+func parseConnectives(fields []string, antecedents []int) ([]int, error) {
+	conditions := 0
+	for _, idx := range antecedents {
+		if idx != 0 {
+			conditions++
+		}
+	}
+	if len(fields) != conditions-1 {
+		return nil, fmt.Errorf("expected %d connectives for %d conditions, got %d", conditions-1, conditions, len(fields))
+	}
+	connections := make([]int, len(fields))
+	for i, f := range fields {
+		c, err := strconv.Atoi(f)
+		if err != nil || (c != 1 && c != 2) {
+			return nil, fmt.Errorf("invalid connective '%s' (expected 1=AND or 2=OR)", f)
+		}
+		connections[i] = c
+	}
+	return connections, nil
+}
+
 // parseKeyValue parses a "Key=Value" or "Key='Value'" line
 func parseKeyValue(line string) (key, value string, err error) {
 	parts := strings.SplitN(line, "=", 2)
@@ -0,0 +1,136 @@
+package inference
+
+import (
+	"github.com/loian/fuzzylib/internal/polyline"
+	"github.com/loian/fuzzylib/membership"
+	"github.com/loian/fuzzylib/variable"
+	"sort"
+)
+
+// clippedShape is a fired membership.AnalyticShape clipped at its firing strength
+type clippedShape struct {
+	shape    membership.AnalyticShape
+	xs, ys   []float64
+	strength float64
+}
+
+// analyticCOG computes the centroid and area of the aggregated output curve in
+// closed form when implication is min-clipping, aggregation is max or sum and
+// every fired set is a membership.AnalyticShape of non-zero width. Each clipped
+// set is then piecewise linear, so between consecutive vertices, clip points and
+// crossings the aggregate is linear, so its vertices form a polyline whose area
+// and moment polyline.CentroidArea computes exactly. A lone fired set
+// inside the domain uses its own ClippedCentroidArea.
+// ok is false if the curve must be sampled instead.
+func analyticCOG(outputVar *variable.FuzzyVariable, memberships map[string]float64, opts defuzzOptions) (centroid, area float64, ok bool) {
+	if opts.implication != ImplicationMin || opts.aggregation == AggregationProbOr {
+		return 0, 0, false
+	}
+	lo, hi := outputVar.MinValue, outputVar.MaxValue
+	shapes := make([]clippedShape, 0, len(memberships))
+	breaks := []float64{lo, hi}
+	for setName, strength := range memberships {
+		s, exists := outputVar.Sets[setName]
+		if !exists || strength == 0 {
+			continue
+		}
+		shape, analytic := s.MembershipFunc.(membership.AnalyticShape)
+		if !analytic || strength < 0 {
+			return 0, 0, false
+		}
+		xs, ys := shape.Vertices()
+		if xs[0] == xs[len(xs)-1] {
+			// A singleton has no area; only a sample landing on it gives it weight
+			return 0, 0, false
+		}
+		shapes = append(shapes, clippedShape{shape: shape, xs: xs, ys: ys, strength: strength})
+		breaks = append(breaks, xs...)
+		for k := 0; k+1 < len(xs); k++ {
+			if (ys[k]-strength)*(ys[k+1]-strength) < 0 {
+				breaks = append(breaks, xs[k]+(strength-ys[k])/(ys[k+1]-ys[k])*(xs[k+1]-xs[k]))
+			}
+		}
+	}
+	if len(shapes) == 1 && shapes[0].xs[0] >= lo && shapes[0].xs[len(shapes[0].xs)-1] <= hi {
+		centroid, area = shapes[0].shape.ClippedCentroidArea(shapes[0].strength)
+		return centroid, area, true
+	}
+	sort.Float64s(breaks)
+
+	var polyXs, polyYs []float64
+	va := make([]float64, len(shapes))
+	vb := make([]float64, len(shapes))
+	for i := 0; i+1 < len(breaks); i++ {
+		a, b := breaks[i], breaks[i+1]
+		if a < lo || b > hi || a >= b {
+			continue
+		}
+		for j, sh := range shapes {
+			va[j], vb[j] = sh.lineOn(a, b)
+		}
+		// at returns the aggregate at x, where every shape is linear on [a, b]
+		at := func(x float64) float64 {
+			t := (x - a) / (b - a)
+			acc := 0.0
+			for j := range shapes {
+				acc = opts.aggregate(acc, va[j]+(vb[j]-va[j])*t)
+			}
+			if opts.clamp && acc > 1 {
+				acc = 1
+			}
+			return acc
+		}
+		cuts := aggregateKinks(a, b, va, vb, opts)
+		for _, x := range cuts {
+			polyXs = append(polyXs, x)
+			polyYs = append(polyYs, at(x))
+		}
+	}
+	centroid, area = polyline.CentroidArea(polyXs, polyYs)
+	return centroid, area, true
+}
+
+// lineOn returns the clipped shape's degree at a and b, which must not straddle
+// a vertex or clip point, so that the shape is linear in between
+func (sh clippedShape) lineOn(a, b float64) (ya, yb float64) {
+	for k := 0; k+1 < len(sh.xs); k++ {
+		x0, x1 := sh.xs[k], sh.xs[k+1]
+		if x0 < x1 && x0 <= a && b <= x1 {
+			slope := (sh.ys[k+1] - sh.ys[k]) / (x1 - x0)
+			ya = min(sh.ys[k]+slope*(a-x0), sh.strength)
+			yb = min(sh.ys[k]+slope*(b-x0), sh.strength)
+			return ya, yb
+		}
+	}
+	return 0, 0
+}
+
+// aggregateKinks returns a, b and every point between them where the aggregate of
+// the lines running from va to vb changes slope, in increasing order: crossings
+// of two lines under MAX, and the point where the sum reaches 1 under clamping
+func aggregateKinks(a, b float64, va, vb []float64, opts defuzzOptions) []float64 {
+	cuts := []float64{a, b}
+	crossing := func(da, db float64) {
+		if da*db < 0 {
+			cuts = append(cuts, a+da/(da-db)*(b-a))
+		}
+	}
+	if opts.aggregation == AggregationSum {
+		if opts.clamp {
+			sa, sb := 0.0, 0.0
+			for j := range va {
+				sa += va[j]
+				sb += vb[j]
+			}
+			crossing(sa-1, sb-1)
+		}
+	} else {
+		for j := range va {
+			for k := j + 1; k < len(va); k++ {
+				crossing(va[j]-va[k], vb[j]-vb[k])
+			}
+		}
+	}
+	sort.Float64s(cuts)
+	return cuts
+}
@@ -0,0 +1,118 @@
+package inference
+
+import (
+	"github.com/loian/fuzzylib/membership"
+	"github.com/loian/fuzzylib/set"
+	"github.com/loian/fuzzylib/variable"
+	"math"
+	"testing"
+)
+
+// newAnalyticOutput builds an output variable [0,100] with overlapping
+// triangular and trapezoidal sets, including a shoulder and a set extending
+// past the domain
+func newAnalyticOutput() *variable.FuzzyVariable {
+	outVar, _ := variable.NewFuzzyVariable("Out", 0, 100)
+	outVar.AddSet(set.NewFuzzySet("Low", mustMF(membership.NewTriangular(0, 0, 40))))
+	outVar.AddSet(set.NewFuzzySet("Mid", mustMF(membership.NewTriangular(20, 50, 80))))
+	outVar.AddSet(set.NewFuzzySet("High", mustMF(membership.NewTrapezoidal(60, 80, 100, 120))))
+	outVar.AddSet(set.NewFuzzySet("Soft", mustMF(membership.NewGaussian(50, 10))))
+	outVar.AddSet(set.NewFuzzySet("Exact", mustMF(membership.NewTrapezoidal(30, 30, 30, 30))))
+	return outVar
+}
+
+func TestAnalyticCOG_Exact(t *testing.T) {
+	outVar := newAnalyticOutput()
+	opts := defaultDefuzzOptions()
+	opts.implication = ImplicationMin
+
+	// Mid clipped at 0.5 is a trapezoid over [20,80] with its top on [35,65]
+	centroid, area, ok := analyticCOG(outVar, map[string]float64{"Mid": 0.5}, opts)
+	if !ok {
+		t.Fatal("Expected analytic path for a clipped triangle")
+	}
+	if !floatEqual(centroid, 50) || !floatEqual(area, 0.5*(60+30)/2) {
+		t.Errorf("Expected centroid 50 and area 22.5, got %f and %f", centroid, area)
+	}
+}
+
+func TestAnalyticCOG_MatchesSampling(t *testing.T) {
+	outVar := newAnalyticOutput()
+	tests := []struct {
+		name        string
+		memberships map[string]float64
+		aggregation string
+		clamp       bool
+	}{
+		{"single set", map[string]float64{"Mid": 0.7}, AggregationMax, true},
+		{"shoulder", map[string]float64{"Low": 0.4}, AggregationMax, true},
+		{"overlap max", map[string]float64{"Low": 0.3, "Mid": 0.8, "High": 0.5}, AggregationMax, true},
+		{"past domain", map[string]float64{"High": 1}, AggregationMax, true},
+		{"overlap sum", map[string]float64{"Low": 0.6, "Mid": 0.9, "High": 0.4}, AggregationSum, true},
+		{"overlap sum unclamped", map[string]float64{"Low": 0.6, "Mid": 0.9}, AggregationSum, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := defaultDefuzzOptions()
+			opts.implication = ImplicationMin
+			opts.aggregation = tt.aggregation
+			opts.clamp = tt.clamp
+			analytic, analyticArea, ok := analyticCOG(outVar, tt.memberships, opts)
+			if !ok {
+				t.Fatal("Expected analytic path")
+			}
+			opts.resolution = 10000
+			sampled, sampledArea := sampledCOG(outVar, tt.memberships, opts)
+			if math.Abs(analytic-sampled) > 0.001*math.Abs(sampled) {
+				t.Errorf("Centroid: analytic %f differs from sampled %f by more than 0.1%%", analytic, sampled)
+			}
+			if math.Abs(analyticArea-sampledArea) > 0.001*sampledArea {
+				t.Errorf("Area: analytic %f differs from sampled %f by more than 0.1%%", analyticArea, sampledArea)
+			}
+		})
+	}
+}
+
+func TestAnalyticCOG_FallsBack(t *testing.T) {
+	outVar := newAnalyticOutput()
+	minOpts := defaultDefuzzOptions()
+	minOpts.implication = ImplicationMin
+	proborOpts := minOpts
+	proborOpts.aggregation = AggregationProbOr
+
+	tests := []struct {
+		name        string
+		memberships map[string]float64
+		opts        defuzzOptions
+	}{
+		{"product implication", map[string]float64{"Mid": 0.5}, defaultDefuzzOptions()},
+		{"probor aggregation", map[string]float64{"Mid": 0.5}, proborOpts},
+		{"gaussian set", map[string]float64{"Mid": 0.5, "Soft": 0.5}, minOpts},
+		{"singleton set", map[string]float64{"Exact": 0.5}, minOpts},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, ok := analyticCOG(outVar, tt.memberships, tt.opts); ok {
+				t.Error("Expected fallback to sampling")
+			}
+		})
+	}
+}
+
+func BenchmarkDefuzzifyCOG(b *testing.B) {
+	outVar := newAnalyticOutput()
+	memberships := map[string]float64{"Low": 0.3, "Mid": 0.8, "High": 0.5}
+	opts := defaultDefuzzOptions()
+	opts.implication = ImplicationMin
+
+	b.Run("analytic", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = defuzzifyCOGWithOptions(outVar, memberships, opts)
+		}
+	})
+	b.Run("sampled", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = sampledCOG(outVar, memberships, opts)
+		}
+	})
+}
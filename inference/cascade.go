@@ -0,0 +1,39 @@
+package inference
+
+// InferFuzzy performs fuzzification and rule evaluation like Infer but skips
+// defuzzification, returning the aggregated firing strength of every output set
+// as map[outputVariable][outputSet]strength. Output sets that no rule names as a
+// consequent are omitted. The result can be passed as fuzzy input to a downstream system with
+// InferMixed, avoiding premature defuzzification in cascaded systems.
+// Returns error if the system is not configured or the inputs are invalid.
+func (fis *MamdaniInferenceSystem) InferFuzzy(inputs map[string]float64) (map[string]map[string]float64, error) {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	ws := fis.newWorkspace()
+	if err := fis.fire(ws, inputs); err != nil {
+		return nil, err
+	}
+	result := make(map[string]map[string]float64, len(ws.outputMemberships))
+	for name, strengths := range ws.outputMemberships {
+		result[name] = copyValues(strengths)
+	}
+	return result, nil
+}
+
+// InferMixed performs Mamdani inference where some inputs are crisp and others
+// are already fuzzy. Input variables present in fuzzyInputs take their membership
+// degrees (map[setName]degree) from it instead of being fuzzified; missing sets
+// have degree 0. All other inputs are read from inputs as in Infer.
+// Upstream InferFuzzy results can be passed directly when the upstream output
+// variable and sets share names with the downstream input variable.
+// Returns error if a fuzzy input names an unknown input variable, or for any reason Infer would.
+func (fis *MamdaniInferenceSystem) InferMixed(inputs map[string]float64, fuzzyInputs map[string]map[string]float64) (map[string]float64, error) {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	ws := fis.newWorkspace()
+	ws.sink = fis.metrics
+	if err := fis.fireMixed(ws, inputs, fuzzyInputs); err != nil {
+		return nil, err
+	}
+	return fis.defuzzifyAll(ws)
+}
@@ -0,0 +1,80 @@
+package inference
+
+import (
+	"github.com/loian/fuzzylib/membership"
+	"github.com/loian/fuzzylib/operators"
+	"github.com/loian/fuzzylib/rule"
+	"github.com/loian/fuzzylib/set"
+	"github.com/loian/fuzzylib/variable"
+	"testing"
+)
+
+// newNoiseSystem builds a downstream system whose FanSpeed input shares set names
+// with the output of newTempFanSystem: IF FanSpeed IS x AND Load IS Heavy THEN Noise IS y
+func newNoiseSystem(t *testing.T) *MamdaniInferenceSystem {
+	t.Helper()
+	fis := NewMamdaniInferenceSystem()
+	fanVar, _ := variable.NewFuzzyVariable("FanSpeed", 0, 100)
+	fanVar.AddSet(set.NewFuzzySet("Low", mustMF(membership.NewTriangular(0, 0, 33))))
+	fanVar.AddSet(set.NewFuzzySet("Medium", mustMF(membership.NewTriangular(20, 50, 80))))
+	fanVar.AddSet(set.NewFuzzySet("High", mustMF(membership.NewTriangular(67, 100, 100))))
+	loadVar, _ := variable.NewFuzzyVariable("Load", 0, 10)
+	loadVar.AddSet(set.NewFuzzySet("Heavy", mustMF(membership.NewTriangular(0, 10, 20))))
+	noiseVar, _ := variable.NewFuzzyVariable("Noise", 0, 100)
+	noiseVar.AddSet(set.NewFuzzySet("Quiet", mustMF(membership.NewTriangular(-50, 0, 50))))
+	noiseVar.AddSet(set.NewFuzzySet("Moderate", mustMF(membership.NewTriangular(25, 50, 75))))
+	noiseVar.AddSet(set.NewFuzzySet("Loud", mustMF(membership.NewTriangular(50, 100, 150))))
+	_ = fis.AddInputVariable(fanVar)
+	_ = fis.AddInputVariable(loadVar)
+	_ = fis.AddOutputVariable(noiseVar)
+
+	for fan, noise := range map[string]string{"Low": "Quiet", "Medium": "Moderate", "High": "Loud"} {
+		r, _ := rule.NewRule(rule.RuleCondition{Variable: "Noise", Set: noise}, operators.AND)
+		r.AddCondition("FanSpeed", fan)
+		r.AddCondition("Load", "Heavy")
+		if err := fis.AddRule(r); err != nil {
+			t.Fatalf("AddRule failed: %v", err)
+		}
+	}
+	return fis
+}
+
+func TestInferFuzzy_Chained(t *testing.T) {
+	upstream := newTempFanSystem(t)
+	downstream := newNoiseSystem(t)
+	_ = downstream.SetDefuzzificationMethod(DefuzzCOG)
+
+	fuzzy, err := upstream.InferFuzzy(map[string]float64{"Temperature": 45})
+	if err != nil {
+		t.Fatalf("InferFuzzy failed: %v", err)
+	}
+	// Hot(45) = 0.75 fires FanSpeed.High; nothing is defuzzified
+	if !floatEqual(fuzzy["FanSpeed"]["High"], 0.75) {
+		t.Fatalf("Expected FanSpeed.High strength 0.75, got %v", fuzzy["FanSpeed"])
+	}
+
+	results, err := downstream.InferMixed(map[string]float64{"Load": 10}, fuzzy)
+	if err != nil {
+		t.Fatalf("InferMixed failed: %v", err)
+	}
+	if results["Noise"] <= 50 {
+		t.Errorf("Expected loud noise from a high fan speed, got %f", results["Noise"])
+	}
+
+	// Same result as supplying every membership by hand
+	want, _ := downstream.InferFromMemberships(map[string]map[string]float64{
+		"FanSpeed": fuzzy["FanSpeed"],
+		"Load":     {"Heavy": 1},
+	})
+	if !floatEqual(results["Noise"], want["Noise"]) {
+		t.Errorf("Expected %f, got %f", want["Noise"], results["Noise"])
+	}
+
+	// The crisp input is still required and validated
+	if _, err := downstream.InferMixed(map[string]float64{}, fuzzy); err == nil {
+		t.Error("Expected error for missing crisp input, got nil")
+	}
+	if _, err := downstream.InferMixed(map[string]float64{"Load": 10}, map[string]map[string]float64{"Unknown": {}}); err == nil {
+		t.Error("Expected error for fuzzy input naming an unknown variable, got nil")
+	}
+}
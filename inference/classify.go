@@ -0,0 +1,89 @@
+package inference
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Classify tie-break policies, selecting which output set Classify returns when
+// several share the highest strength
+const (
+	ClassifyTieFirst = "first" // the set concluded by the earliest-added rule firing at that strength (default)
+	ClassifyTieName  = "name"  // the alphabetically first set name
+	ClassifyTieError = "error" // fail with ErrClassifyTie
+)
+
+// SetClassifyTieBreak sets the policy Classify uses for ties at the highest strength.
+// Valid policies: "first", "name", "error".
+// Returns error if policy is not recognized.
+func (fis *MamdaniInferenceSystem) SetClassifyTieBreak(policy string) error {
+	fis.mu.Lock()
+	defer fis.mu.Unlock()
+	switch policy {
+	case ClassifyTieFirst, ClassifyTieName, ClassifyTieError:
+		fis.ClassifyTieBreak = policy
+		return nil
+	}
+	return fmt.Errorf("invalid classify tie-break policy '%s': must be 'first', 'name', or 'error'", policy)
+}
+
+// Classify runs inference and returns the name of the output set with the highest
+// aggregated firing strength for the named output variable, skipping
+// defuzzification. Ties are resolved according to ClassifyTieBreak.
+// Returns error if the output variable does not exist, inference fails, no output
+// set fired, or sets are tied under the ClassifyTieError policy.
+func (fis *MamdaniInferenceSystem) Classify(inputs map[string]float64, output string) (string, error) {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	if _, exists := fis.OutputVariables[output]; !exists {
+		return "", fmt.Errorf("output variable '%s' does not exist", output)
+	}
+	ws := fis.newWorkspace()
+	if err := fis.fire(ws, inputs); err != nil {
+		return "", err
+	}
+
+	strengths := ws.outputMemberships[output]
+	best := 0.0
+	for _, s := range strengths {
+		best = math.Max(best, s)
+	}
+	if best <= 0 {
+		return "", fmt.Errorf("%w for output '%s'", ErrNoRulesFired, output)
+	}
+	var tied []string
+	for name, s := range strengths {
+		if best-s < epsilon {
+			tied = append(tied, name)
+		}
+	}
+	sort.Strings(tied)
+	if len(tied) == 1 {
+		return tied[0], nil
+	}
+
+	switch fis.ClassifyTieBreak {
+	case ClassifyTieName:
+		return tied[0], nil
+	case ClassifyTieError:
+		return "", fmt.Errorf("%w: output '%s' sets %s", ErrClassifyTie, output, strings.Join(tied, ", "))
+	}
+	for i, r := range fis.Rules {
+		if r.Inhibitory || best-ws.ruleStrengths[i] >= epsilon {
+			continue
+		}
+		for _, out := range r.Outputs() {
+			if out.Variable != output {
+				continue
+			}
+			for _, name := range tied {
+				if out.Set == name {
+					return name, nil
+				}
+			}
+		}
+	}
+	return tied[0], nil
+}
@@ -0,0 +1,62 @@
+package inference
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	fis := newTempFanSystem(t)
+
+	got, err := fis.Classify(map[string]float64{"Temperature": 45}, "FanSpeed")
+	if err != nil {
+		t.Fatalf("Classify failed: %v", err)
+	}
+	if got != "High" {
+		t.Errorf("Expected High, got %s", got)
+	}
+
+	if _, err := fis.Classify(map[string]float64{"Temperature": 25}, "Unknown"); err == nil {
+		t.Error("Expected error for unknown output variable, got nil")
+	}
+	if err := fis.SetClassifyTieBreak("random"); err == nil {
+		t.Error("Expected error for unknown tie-break policy, got nil")
+	}
+}
+
+func TestClassify_TieBreak(t *testing.T) {
+	fis := newTempFanSystem(t)
+	// A second Warm rule ties Low with Medium at Temperature=25
+	r, _ := NewRuleBuilder("FanSpeed", "Low")
+	built, _ := r.If("Temperature", "Warm").Build()
+	if err := fis.AddRule(built); err != nil {
+		t.Fatalf("AddRule failed: %v", err)
+	}
+	inputs := map[string]float64{"Temperature": 25}
+
+	tests := []struct {
+		policy   string
+		expected string
+	}{
+		{"", "Medium"},               // default: Warm->Medium was added first
+		{ClassifyTieFirst, "Medium"}, // explicit first
+		{ClassifyTieName, "Low"},     // alphabetical
+	}
+	for _, tt := range tests {
+		fis.ClassifyTieBreak = tt.policy
+		got, err := fis.Classify(inputs, "FanSpeed")
+		if err != nil {
+			t.Fatalf("policy %q: Classify failed: %v", tt.policy, err)
+		}
+		if got != tt.expected {
+			t.Errorf("policy %q: expected %s, got %s", tt.policy, tt.expected, got)
+		}
+	}
+
+	if err := fis.SetClassifyTieBreak(ClassifyTieError); err != nil {
+		t.Fatalf("SetClassifyTieBreak failed: %v", err)
+	}
+	if _, err := fis.Classify(inputs, "FanSpeed"); !errors.Is(err, ErrClassifyTie) {
+		t.Errorf("Expected ErrClassifyTie, got %v", err)
+	}
+}
@@ -0,0 +1,39 @@
+package inference
+
+import (
+	"fmt"
+)
+
+// comparedDefuzzMethods lists the defuzzification methods reported by DefuzzComparison
+var comparedDefuzzMethods = []string{DefuzzCOG, DefuzzMOM, DefuzzFOM, DefuzzSOM, DefuzzLOM, DefuzzBIS}
+
+// DefuzzComparison runs inference once and defuzzifies the named output with every
+// method ("centroid", "mom", "fom", "som", "lom", "bisector"), returning the crisp
+// value each method picks, keyed by method name. The system's DefuzzMethod and
+// per-output overrides are ignored; all other defuzzification settings apply.
+// Comparing the entries shows how strongly the choice of method affects an output.
+// Returns error if the output variable does not exist, inference fails, or any
+// method fails to defuzzify.
+func (fis *MamdaniInferenceSystem) DefuzzComparison(inputs map[string]float64, output string) (map[string]float64, error) {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	outputVar, exists := fis.OutputVariables[output]
+	if !exists {
+		return nil, fmt.Errorf("output variable '%s' does not exist", output)
+	}
+	ws := fis.newWorkspace()
+	if err := fis.fire(ws, inputs); err != nil {
+		return nil, err
+	}
+
+	opts := fis.defuzzOptions()
+	results := make(map[string]float64, len(comparedDefuzzMethods))
+	for _, method := range comparedDefuzzMethods {
+		result, err := defuzzifyOutput(method, outputVar, ws.outputMemberships[output], opts)
+		if err != nil {
+			return nil, fmt.Errorf("defuzzification with method '%s' failed: %w", method, err)
+		}
+		results[method] = result
+	}
+	return results, nil
+}
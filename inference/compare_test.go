@@ -0,0 +1,44 @@
+package inference
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDefuzzComparison(t *testing.T) {
+	fis := newTempFanSystem(t)
+	// At 15 Cold fires Low weakly and Warm fires Medium, giving an asymmetric output
+	inputs := map[string]float64{"Temperature": 15}
+
+	results, err := fis.DefuzzComparison(inputs, "FanSpeed")
+	if err != nil {
+		t.Fatalf("DefuzzComparison failed: %v", err)
+	}
+	for _, method := range []string{DefuzzCOG, DefuzzMOM, DefuzzFOM, DefuzzBIS} {
+		if _, ok := results[method]; !ok {
+			t.Errorf("Expected an entry for method %s", method)
+		}
+	}
+	if floatEqual(results[DefuzzCOG], results[DefuzzMOM]) {
+		t.Errorf("Expected COG and MOM to differ on an asymmetric output, both %f", results[DefuzzCOG])
+	}
+
+	// Each entry matches Infer with that method
+	for _, method := range []string{DefuzzCOG, DefuzzMOM} {
+		_ = fis.SetDefuzzificationMethod(method)
+		want, _ := fis.Infer(inputs)
+		if !floatEqual(results[method], want["FanSpeed"]) {
+			t.Errorf("%s: expected %f, got %f", method, want["FanSpeed"], results[method])
+		}
+	}
+}
+
+func TestDefuzzComparison_Errors(t *testing.T) {
+	fis := newTempFanSystem(t)
+	if _, err := fis.DefuzzComparison(map[string]float64{"Temperature": 15}, "Unknown"); err == nil {
+		t.Error("Expected error for unknown output variable, got nil")
+	}
+	if _, err := fis.DefuzzComparison(map[string]float64{"Temperature": 0}, "FanSpeed"); !errors.Is(err, ErrNoRulesFired) {
+		t.Errorf("Expected ErrNoRulesFired, got %v", err)
+	}
+}
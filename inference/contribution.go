@@ -0,0 +1,209 @@
+package inference
+
+import (
+	"fmt"
+	"github.com/loian/fuzzylib/operators"
+	"github.com/loian/fuzzylib/rule"
+)
+
+// InputContributionToSet explains which inputs drove the firing strength of a
+// single output set, e.g. FanSpeed:High. It finds the strongest rule concluding
+// outputVar IS outputSet and attributes that rule's firing strength to the
+// inputs whose conditions determined it: under AND (min) the limiting
+// condition, under OR (max) the dominant one. For grouped rules the decisive
+// groups are found first, then the decisive conditions within them. Operators
+// other than min and max attribute the strength to every condition.
+//
+// The result has an entry for every input variable; inputs that did not limit
+// the winning rule get 0. This is an approximation intended for explanation,
+// not an exact decomposition.
+// Returns error if the output variable or set does not exist, inference fails,
+// or no rule concluding the set fired.
+func (fis *MamdaniInferenceSystem) InputContributionToSet(inputs map[string]float64, outputVar, outputSet string) (map[string]float64, error) {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	v, exists := fis.OutputVariables[outputVar]
+	if !exists {
+		return nil, fmt.Errorf("output variable '%s' does not exist", outputVar)
+	}
+	if _, exists := v.Sets[outputSet]; !exists {
+		return nil, fmt.Errorf("output set '%s' does not exist in variable '%s'", outputSet, outputVar)
+	}
+	ws := fis.newWorkspace()
+	if err := fis.fire(ws, inputs); err != nil {
+		return nil, err
+	}
+
+	// Find the strongest excitatory rule concluding the set
+	winner, best := -1, 0.0
+	for i, r := range fis.Rules {
+		if ws.ruleStrengths[i] > best && !r.Inhibitory && concludes(r, outputVar, outputSet) {
+			winner, best = i, ws.ruleStrengths[i]
+		}
+	}
+	if winner < 0 {
+		return nil, fmt.Errorf("%w: no rule concluding %s.%s fired", ErrNoRulesFired, outputVar, outputSet)
+	}
+
+	contributions := make(map[string]float64, len(fis.InputVariables))
+	for name := range fis.InputVariables {
+		contributions[name] = 0
+	}
+	attribute := func(cond rule.RuleCondition) {
+		if best > contributions[cond.Variable] {
+			contributions[cond.Variable] = best
+		}
+	}
+
+	r := fis.Rules[winner]
+	connectives := fis.connectives()
+	if len(r.Groups) == 0 {
+		conds, err := decisiveConditions(r, r.Conditions, r.Operator, connectives, ws.membershipMap)
+		if err != nil {
+			return nil, err
+		}
+		for _, cond := range conds {
+			attribute(cond)
+		}
+		return contributions, nil
+	}
+
+	groupOp := r.GroupOperator
+	if groupOp == nil {
+		groupOp = operators.AND
+	}
+	groupValues := make([]float64, len(r.Groups))
+	for g, group := range r.Groups {
+		degrees, err := conditionDegrees(r, group.Conditions, connectives.Resolve(groupOp), ws.membershipMap)
+		if err != nil {
+			return nil, err
+		}
+		if groupValues[g], err = connectives.Resolve(groupOp).Apply(degrees...); err != nil {
+			return nil, err
+		}
+	}
+	for _, g := range decisive(groupValues, connectives.Resolve(r.Operator)) {
+		conds, err := decisiveConditions(r, r.Groups[g].Conditions, groupOp, connectives, ws.membershipMap)
+		if err != nil {
+			return nil, err
+		}
+		for _, cond := range conds {
+			attribute(cond)
+		}
+	}
+	return contributions, nil
+}
+
+// DominantRule explains a crisp output by its single most influential rule: the
+// strongest excitatory rule concluding the winning output set, i.e. the set of output with
+// the highest aggregated firing strength. Ties go to the set that sorts first by
+// name and to the rule with the lowest index.
+// Returns the rule's index in fis.Rules and its firing strength.
+// Returns error if the output variable does not exist, inference fails, or no
+// rule concluding output fired; the latter wraps ErrNoRulesFired, including when
+// the winning set's degree came only from the output's default rule.
+func (fis *MamdaniInferenceSystem) DominantRule(inputs map[string]float64, output string) (index int, strength float64, err error) {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	v, exists := fis.OutputVariables[output]
+	if !exists {
+		return -1, 0, fmt.Errorf("output variable '%s' does not exist", output)
+	}
+	ws := fis.newWorkspace()
+	if err := fis.fire(ws, inputs); err != nil {
+		return -1, 0, err
+	}
+
+	winningSet, best := "", 0.0
+	for _, name := range sortedSetNames(v) {
+		if degree := ws.outputMemberships[output][name]; degree > best {
+			winningSet, best = name, degree
+		}
+	}
+	if winningSet == "" {
+		return -1, 0, fmt.Errorf("%w: no rule concluding output '%s' fired", ErrNoRulesFired, output)
+	}
+
+	index = -1
+	for i, r := range fis.Rules {
+		if ws.ruleStrengths[i] > strength && !r.Inhibitory && concludes(r, output, winningSet) {
+			index, strength = i, ws.ruleStrengths[i]
+		}
+	}
+	if index == -1 {
+		return -1, 0, fmt.Errorf("%w: only the default rule for output '%s' fired", ErrNoRulesFired, output)
+	}
+	return index, strength, nil
+}
+
+// concludes reports whether any of the rule's consequents is variable IS set
+func concludes(r *rule.Rule, variable, set string) bool {
+	for _, out := range r.Outputs() {
+		if out.Variable == variable && out.Set == set {
+			return true
+		}
+	}
+	return false
+}
+
+// conditionDegrees returns the weighted degree of each condition of r, as combined
+// by op after connective overrides are resolved
+func conditionDegrees(r *rule.Rule, conds []rule.RuleCondition, op operators.Operator, membershipMap map[string]map[string]float64) ([]float64, error) {
+	degrees := make([]float64, len(conds))
+	for i, cond := range conds {
+		degree, err := r.ConditionDegree(cond, op, membershipMap)
+		if err != nil {
+			return nil, err
+		}
+		degrees[i] = degree
+	}
+	return degrees, nil
+}
+
+// decisiveConditions returns the conditions of r that determine the result of
+// combining conds with op, as resolved by the system's connective overrides
+func decisiveConditions(r *rule.Rule, conds []rule.RuleCondition, op operators.Operator, connectives rule.Connectives, membershipMap map[string]map[string]float64) ([]rule.RuleCondition, error) {
+	resolved := connectives.Resolve(op)
+	degrees, err := conditionDegrees(r, conds, resolved, membershipMap)
+	if err != nil {
+		return nil, err
+	}
+	var result []rule.RuleCondition
+	for _, i := range decisive(degrees, resolved) {
+		result = append(result, conds[i])
+	}
+	return result, nil
+}
+
+// decisive returns the indices of the values that determine op's result: the
+// minimum for min operators, the maximum for max operators, and every index otherwise.
+// Ties all count as decisive.
+func decisive(values []float64, op operators.Operator) []int {
+	var pick func(a, b float64) bool
+	switch op.(type) {
+	case *operators.MinOperator:
+		pick = func(a, b float64) bool { return a < b }
+	case *operators.MaxOperator:
+		pick = func(a, b float64) bool { return a > b }
+	}
+
+	indices := make([]int, 0, len(values))
+	if pick == nil {
+		for i := range values {
+			indices = append(indices, i)
+		}
+		return indices
+	}
+	target := values[0]
+	for _, v := range values[1:] {
+		if pick(v, target) {
+			target = v
+		}
+	}
+	for i, v := range values {
+		if v == target {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
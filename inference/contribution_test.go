@@ -0,0 +1,114 @@
+package inference
+
+import (
+	"errors"
+	"github.com/loian/fuzzylib/membership"
+	"github.com/loian/fuzzylib/operators"
+	"github.com/loian/fuzzylib/rule"
+	"github.com/loian/fuzzylib/set"
+	"github.com/loian/fuzzylib/variable"
+	"testing"
+)
+
+// newHotWetSystem builds a two-input system with a single rule:
+// IF Temperature IS Hot <op> Humidity IS Wet THEN FanSpeed IS High
+func newHotWetSystem(t *testing.T, op operators.Operator) *MamdaniInferenceSystem {
+	t.Helper()
+	fis := NewMamdaniInferenceSystem()
+	tempVar, _ := variable.NewFuzzyVariable("Temperature", 0, 50)
+	tempVar.AddSet(set.NewFuzzySet("Hot", mustMF(membership.NewTriangular(30, 50, 70))))
+	humVar, _ := variable.NewFuzzyVariable("Humidity", 0, 100)
+	humVar.AddSet(set.NewFuzzySet("Wet", mustMF(membership.NewTriangular(60, 100, 140))))
+	fanVar, _ := variable.NewFuzzyVariable("FanSpeed", 0, 100)
+	fanVar.AddSet(set.NewFuzzySet("Low", mustMF(membership.NewTriangular(0, 0, 33))))
+	fanVar.AddSet(set.NewFuzzySet("High", mustMF(membership.NewTriangular(67, 100, 100))))
+	_ = fis.AddInputVariable(tempVar)
+	_ = fis.AddInputVariable(humVar)
+	_ = fis.AddOutputVariable(fanVar)
+
+	r, _ := rule.NewRule(rule.RuleCondition{Variable: "FanSpeed", Set: "High"}, op)
+	r.AddCondition("Temperature", "Hot")
+	r.AddCondition("Humidity", "Wet")
+	if err := fis.AddRule(r); err != nil {
+		t.Fatalf("AddRule failed: %v", err)
+	}
+	return fis
+}
+
+func TestInputContributionToSet_AND(t *testing.T) {
+	fis := newHotWetSystem(t, operators.AND)
+
+	// Hot(45) = 0.75, Wet(80) = 0.5: Humidity limits the AND rule
+	contributions, err := fis.InputContributionToSet(map[string]float64{"Temperature": 45, "Humidity": 80}, "FanSpeed", "High")
+	if err != nil {
+		t.Fatalf("InputContributionToSet failed: %v", err)
+	}
+	if !floatEqual(contributions["Humidity"], 0.5) {
+		t.Errorf("Expected limiting Humidity to get 0.5, got %f", contributions["Humidity"])
+	}
+	if contributions["Temperature"] != 0 {
+		t.Errorf("Expected non-limiting Temperature to get 0, got %f", contributions["Temperature"])
+	}
+}
+
+func TestInputContributionToSet_OR(t *testing.T) {
+	fis := newHotWetSystem(t, operators.OR)
+
+	contributions, err := fis.InputContributionToSet(map[string]float64{"Temperature": 45, "Humidity": 80}, "FanSpeed", "High")
+	if err != nil {
+		t.Fatalf("InputContributionToSet failed: %v", err)
+	}
+	if !floatEqual(contributions["Temperature"], 0.75) || contributions["Humidity"] != 0 {
+		t.Errorf("Expected dominant Temperature to get 0.75 under OR, got %v", contributions)
+	}
+}
+
+func TestInputContributionToSet_Errors(t *testing.T) {
+	fis := newHotWetSystem(t, operators.AND)
+	inputs := map[string]float64{"Temperature": 45, "Humidity": 80}
+
+	if _, err := fis.InputContributionToSet(inputs, "Unknown", "High"); err == nil {
+		t.Error("Expected error for unknown output variable, got nil")
+	}
+	if _, err := fis.InputContributionToSet(inputs, "FanSpeed", "Unknown"); err == nil {
+		t.Error("Expected error for unknown output set, got nil")
+	}
+	if _, err := fis.InputContributionToSet(inputs, "FanSpeed", "Low"); !errors.Is(err, ErrNoRulesFired) {
+		t.Errorf("Expected ErrNoRulesFired for set without a fired rule, got %v", err)
+	}
+}
+
+func TestDominantRule(t *testing.T) {
+	fis := newTempFanSystem(t)
+
+	index, strength, err := fis.DominantRule(map[string]float64{"Temperature": 45}, "FanSpeed")
+	if err != nil {
+		t.Fatalf("DominantRule failed: %v", err)
+	}
+	if index != 2 || fis.Rules[index].Output.Set != "High" {
+		t.Errorf("Expected Hot->High rule (index 2) to dominate, got index %d", index)
+	}
+	if !floatEqual(strength, 0.75) {
+		t.Errorf("Expected strength 0.75, got %f", strength)
+	}
+
+	// Warm (1/3) beats Hot (1/4) at 35
+	if index, _, _ = fis.DominantRule(map[string]float64{"Temperature": 35}, "FanSpeed"); index != 1 {
+		t.Errorf("Expected Warm->Medium rule (index 1) to dominate at 35, got index %d", index)
+	}
+
+	if _, _, err := fis.DominantRule(map[string]float64{"Temperature": 45}, "Unknown"); err == nil {
+		t.Error("Expected error for unknown output variable")
+	}
+	if _, _, err := fis.DominantRule(map[string]float64{"Temperature": 0}, "FanSpeed"); !errors.Is(err, ErrNoRulesFired) {
+		t.Errorf("Expected ErrNoRulesFired, got %v", err)
+	}
+
+	// The default rule makes Medium win at 0, but no rule in fis.Rules produced it
+	if err := fis.SetDefaultRule(&variable.SetRef{Variable: "FanSpeed", Set: "Medium"}, 1); err != nil {
+		t.Fatalf("SetDefaultRule failed: %v", err)
+	}
+	if index, _, err := fis.DominantRule(map[string]float64{"Temperature": 0}, "FanSpeed"); !errors.Is(err, ErrNoRulesFired) || index != -1 {
+		t.Errorf("Expected index -1 and ErrNoRulesFired when only the default rule fired, got %d and %v", index, err)
+	}
+}
@@ -0,0 +1,95 @@
+package inference
+
+import (
+	"fmt"
+	"math"
+)
+
+// MaxRecommendedResolution caps the resolutions tried by RecommendResolution.
+var MaxRecommendedResolution = 1 << 16
+
+// minRecommendedResolution is the first resolution tried by RecommendResolution
+const minRecommendedResolution = 16
+
+// ResolutionConvergence runs inference once and defuzzifies the named output at
+// each of the given resolutions, returning the crisp results in the same order.
+// The system's own Resolution is left unchanged. Comparing successive values
+// shows how the output converges, so the smallest stable resolution can be chosen.
+// Returns error if the output variable does not exist, any resolution is <= 0,
+// inference fails, or defuzzification fails at any resolution.
+func (fis *MamdaniInferenceSystem) ResolutionConvergence(inputs map[string]float64, output string, resolutions []int) ([]float64, error) {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	outputVar, exists := fis.OutputVariables[output]
+	if !exists {
+		return nil, fmt.Errorf("output variable '%s' does not exist", output)
+	}
+	for _, res := range resolutions {
+		if res <= 0 {
+			return nil, fmt.Errorf("%w, got %d", ErrInvalidResolution, res)
+		}
+	}
+	ws := fis.newWorkspace()
+	if err := fis.fire(ws, inputs); err != nil {
+		return nil, err
+	}
+
+	method := fis.defuzzMethodFor(output)
+	opts := fis.defuzzOptions()
+	results := make([]float64, len(resolutions))
+	for i, res := range resolutions {
+		opts.resolution = res
+		result, err := defuzzifyOutput(method, outputVar, ws.outputMemberships[output], opts)
+		if err != nil {
+			return nil, fmt.Errorf("defuzzification failed at resolution %d: %w", res, err)
+		}
+		results[i] = result
+	}
+	return results, nil
+}
+
+// RecommendResolution returns the smallest resolution, doubling from 16, at which
+// the named output's crisp value differs from the one at the previous resolution
+// by less than tolerance. Inference runs once; the system's own Resolution is
+// left unchanged. If the tolerance is not met by MaxRecommendedResolution, that
+// cap is returned together with an error wrapping ErrResolutionNotConverged.
+// Returns error if the output variable does not exist, tolerance is not positive,
+// inference fails, or defuzzification fails at any resolution.
+func (fis *MamdaniInferenceSystem) RecommendResolution(inputs map[string]float64, output string, tolerance float64) (int, error) {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	outputVar, exists := fis.OutputVariables[output]
+	if !exists {
+		return 0, fmt.Errorf("output variable '%s' does not exist", output)
+	}
+	if !(tolerance > 0) {
+		return 0, fmt.Errorf("tolerance must be > 0, got %g", tolerance)
+	}
+	ws := fis.newWorkspace()
+	if err := fis.fire(ws, inputs); err != nil {
+		return 0, err
+	}
+
+	method := fis.defuzzMethodFor(output)
+	opts := fis.defuzzOptions()
+	prev := math.NaN()
+	res := minRecommendedResolution
+	for {
+		if res > MaxRecommendedResolution {
+			res = MaxRecommendedResolution
+		}
+		opts.resolution = res
+		result, err := defuzzifyOutput(method, outputVar, ws.outputMemberships[output], opts)
+		if err != nil {
+			return 0, fmt.Errorf("defuzzification failed at resolution %d: %w", res, err)
+		}
+		if math.Abs(result-prev) < tolerance {
+			return res, nil
+		}
+		if res >= MaxRecommendedResolution {
+			return res, fmt.Errorf("%w: tolerance %g not met at resolution %d", ErrResolutionNotConverged, tolerance, res)
+		}
+		prev = result
+		res *= 2
+	}
+}
@@ -0,0 +1,100 @@
+package inference
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestResolutionConvergence(t *testing.T) {
+	fis := newTempFanSystem(t)
+	_ = fis.SetDefuzzificationMethod(DefuzzCOG)
+	inputs := map[string]float64{"Temperature": 33}
+
+	resolutions := []int{4, 16, 64, 256, 1024}
+	results, err := fis.ResolutionConvergence(inputs, "FanSpeed", resolutions)
+	if err != nil {
+		t.Fatalf("ResolutionConvergence failed: %v", err)
+	}
+	if len(results) != len(resolutions) {
+		t.Fatalf("Expected %d results, got %d", len(resolutions), len(results))
+	}
+
+	prevDiff := math.Inf(1)
+	for i := 1; i < len(results); i++ {
+		diff := math.Abs(results[i] - results[i-1])
+		if diff > prevDiff {
+			t.Errorf("Expected successive differences to shrink, got %v", results)
+			break
+		}
+		prevDiff = diff
+	}
+
+	// The finest result matches a normal Infer at that resolution
+	_ = fis.SetResolution(1024)
+	want, _ := fis.Infer(inputs)
+	if !floatEqual(results[len(results)-1], want["FanSpeed"]) {
+		t.Errorf("Expected %f at resolution 1024, got %f", want["FanSpeed"], results[len(results)-1])
+	}
+}
+
+func TestResolutionConvergence_Validation(t *testing.T) {
+	fis := newTempFanSystem(t)
+	inputs := map[string]float64{"Temperature": 33}
+
+	if _, err := fis.ResolutionConvergence(inputs, "Unknown", []int{10}); err == nil {
+		t.Error("Expected error for unknown output variable, got nil")
+	}
+	if _, err := fis.ResolutionConvergence(inputs, "FanSpeed", []int{10, 0}); !errors.Is(err, ErrInvalidResolution) {
+		t.Errorf("Expected ErrInvalidResolution, got %v", err)
+	}
+	if _, err := fis.ResolutionConvergence(map[string]float64{}, "FanSpeed", []int{10}); !errors.Is(err, ErrMissingInput) {
+		t.Errorf("Expected ErrMissingInput, got %v", err)
+	}
+}
+
+func TestRecommendResolution(t *testing.T) {
+	fis := newTempFanSystem(t)
+	_ = fis.SetDefuzzificationMethod(DefuzzCOG)
+	inputs := map[string]float64{"Temperature": 33}
+
+	res, err := fis.RecommendResolution(inputs, "FanSpeed", 1e-3)
+	if err != nil {
+		t.Fatalf("RecommendResolution failed: %v", err)
+	}
+	if res < minRecommendedResolution || res > MaxRecommendedResolution {
+		t.Fatalf("Expected resolution within [%d, %d], got %d", minRecommendedResolution, MaxRecommendedResolution, res)
+	}
+	results, _ := fis.ResolutionConvergence(inputs, "FanSpeed", []int{res / 2, res})
+	if math.Abs(results[1]-results[0]) >= 1e-3 {
+		t.Errorf("Expected outputs at %d and %d to differ by less than 1e-3, got %v", res/2, res, results)
+	}
+	if fis.Resolution != DefaultResolution {
+		t.Errorf("Expected system resolution to be unchanged, got %d", fis.Resolution)
+	}
+}
+
+func TestRecommendResolution_Cap(t *testing.T) {
+	fis := newTempFanSystem(t)
+	_ = fis.SetDefuzzificationMethod(DefuzzCOG)
+	inputs := map[string]float64{"Temperature": 33}
+
+	saved := MaxRecommendedResolution
+	MaxRecommendedResolution = 100
+	defer func() { MaxRecommendedResolution = saved }()
+
+	res, err := fis.RecommendResolution(inputs, "FanSpeed", 1e-15)
+	if !errors.Is(err, ErrResolutionNotConverged) {
+		t.Fatalf("Expected ErrResolutionNotConverged, got %v", err)
+	}
+	if res != 100 {
+		t.Errorf("Expected the cap 100 to be returned, got %d", res)
+	}
+
+	if _, err := fis.RecommendResolution(inputs, "FanSpeed", 0); err == nil {
+		t.Error("Expected error for zero tolerance, got nil")
+	}
+	if _, err := fis.RecommendResolution(inputs, "Unknown", 1e-3); err == nil {
+		t.Error("Expected error for unknown output variable, got nil")
+	}
+}
@@ -0,0 +1,41 @@
+package inference
+
+// CurvePoint is one sample of an aggregated output membership curve
+type CurvePoint struct {
+	X      float64
+	Degree float64
+}
+
+// InferCurve performs fuzzification and rule evaluation like Infer and returns,
+// per output variable, the aggregated output membership sampled at the
+// Resolution+1 points of the output domain that the defuzzifiers use. The curve
+// goes through the same implication, aggregation and clamping as Infer, so it
+// can be plotted or fed to a custom defuzzifier. Use InferFuzzy for the
+// per-set firing strengths instead.
+// Returns error if the system is not configured or the inputs are invalid.
+func (fis *MamdaniInferenceSystem) InferCurve(inputs map[string]float64) (map[string][]CurvePoint, error) {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	ws := fis.newWorkspace()
+	if err := fis.fire(ws, inputs); err != nil {
+		return nil, err
+	}
+
+	opts := fis.defuzzOptions()
+	if opts.resolution <= 0 {
+		opts.resolution = DefaultResolution
+	}
+	curves := make(map[string][]CurvePoint, len(ws.outputNames))
+	for _, name := range ws.outputNames {
+		outputVar := fis.OutputVariables[name]
+		memberships := ws.outputMemberships[name]
+		step := (outputVar.MaxValue - outputVar.MinValue) / float64(opts.resolution)
+		curve := make([]CurvePoint, opts.resolution+1)
+		for i := range curve {
+			x := outputVar.MinValue + float64(i)*step
+			curve[i] = CurvePoint{X: x, Degree: aggregatedMembership(outputVar, memberships, x, opts)}
+		}
+		curves[name] = curve
+	}
+	return curves, nil
+}
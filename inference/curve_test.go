@@ -0,0 +1,51 @@
+package inference
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestInferCurve(t *testing.T) {
+	fis := newTempFanSystem(t)
+	_ = fis.SetResolution(200)
+	_ = fis.SetDefuzzificationMethod(DefuzzCOG)
+	inputs := map[string]float64{"Temperature": 35} // Warm 1/3, Hot 1/4
+
+	curves, err := fis.InferCurve(inputs)
+	if err != nil {
+		t.Fatalf("InferCurve failed: %v", err)
+	}
+	curve := curves["FanSpeed"]
+	if len(curve) != 201 {
+		t.Fatalf("Expected Resolution+1 = 201 points, got %d", len(curve))
+	}
+	if curve[0].X != 0 || curve[len(curve)-1].X != 100 {
+		t.Errorf("Expected the curve to span [0, 100], got [%f, %f]", curve[0].X, curve[len(curve)-1].X)
+	}
+
+	// Every point matches AggregatedMembershipAt, and the curve's centroid matches Infer
+	numerator, denominator := 0.0, 0.0
+	for _, p := range curve {
+		want, err := fis.AggregatedMembershipAt(inputs, "FanSpeed", p.X)
+		if err != nil {
+			t.Fatalf("AggregatedMembershipAt failed: %v", err)
+		}
+		if !floatEqual(p.Degree, want) {
+			t.Errorf("Degree at %f = %f, expected %f", p.X, p.Degree, want)
+		}
+		numerator += p.X * p.Degree
+		denominator += p.Degree
+	}
+	results, err := fis.Infer(inputs)
+	if err != nil {
+		t.Fatalf("Infer failed: %v", err)
+	}
+	if math.Abs(numerator/denominator-results["FanSpeed"]) > 1e-6 {
+		t.Errorf("Expected curve centroid %f to match Infer %f", numerator/denominator, results["FanSpeed"])
+	}
+
+	if _, err := fis.InferCurve(map[string]float64{}); !errors.Is(err, ErrMissingInput) {
+		t.Errorf("Expected ErrMissingInput, got %v", err)
+	}
+}
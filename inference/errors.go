@@ -0,0 +1,48 @@
+package inference
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors returned (wrapped) by the inference system. Use errors.Is to
+// detect them; the wrapping errors keep their human-readable messages.
+var (
+	// ErrMissingInput indicates that Infer was called without a value for a required input variable.
+	ErrMissingInput = errors.New("missing required input variable")
+	// ErrOutOfBounds indicates that an input value lies outside its variable's domain.
+	ErrOutOfBounds = errors.New("input value out of bounds")
+	// ErrNoRulesFired indicates that every output membership degree was zero, so
+	// there is nothing to defuzzify.
+	ErrNoRulesFired = errors.New("no rules fired: all membership degrees are zero")
+	// ErrNonFiniteOutput indicates that defuzzification produced NaN or an infinite
+	// value, typically because a membership function returned NaN.
+	ErrNonFiniteOutput = errors.New("defuzzified output is not finite")
+	// ErrInvalidResolution indicates a non-positive sampling resolution.
+	ErrInvalidResolution = errors.New("resolution must be > 0")
+	// ErrResolutionNotConverged indicates that RecommendResolution reached
+	// MaxRecommendedResolution without meeting its tolerance.
+	ErrResolutionNotConverged = errors.New("defuzzified output did not converge within the resolution cap")
+	// ErrClassifyTie indicates that Classify found several output sets tied at the
+	// highest strength under the ClassifyTieError policy.
+	ErrClassifyTie = errors.New("several output sets tied at the highest strength")
+	// ErrFrozen indicates an attempt to modify a FrozenSystem.
+	ErrFrozen = errors.New("inference system is frozen")
+)
+
+// OutOfBoundsError captures the input value that fell outside its variable's domain.
+type OutOfBoundsError struct {
+	Variable string
+	Value    float64
+	Min      float64
+	Max      float64
+}
+
+func (e *OutOfBoundsError) Error() string {
+	return fmt.Sprintf("input value %.2f for variable '%s' is out of bounds [%.2f, %.2f]",
+		e.Value, e.Variable, e.Min, e.Max)
+}
+
+func (e *OutOfBoundsError) Unwrap() error {
+	return ErrOutOfBounds
+}
@@ -0,0 +1,56 @@
+package inference
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestErrors_OutOfBounds(t *testing.T) {
+	fis := newTempFanSystem(t)
+
+	_, err := fis.Infer(map[string]float64{"Temperature": 75})
+	if !errors.Is(err, ErrOutOfBounds) {
+		t.Fatalf("Expected ErrOutOfBounds, got %v", err)
+	}
+	var boundsErr *OutOfBoundsError
+	if !errors.As(err, &boundsErr) {
+		t.Fatalf("Expected *OutOfBoundsError, got %T", err)
+	}
+	if boundsErr.Variable != "Temperature" || boundsErr.Value != 75 || boundsErr.Max != 50 {
+		t.Errorf("Unexpected error details: %+v", boundsErr)
+	}
+	if err.Error() != "input value 75.00 for variable 'Temperature' is out of bounds [0.00, 50.00]" {
+		t.Errorf("Unexpected message: %s", err.Error())
+	}
+}
+
+func TestErrors_NaNInput(t *testing.T) {
+	fis := newTempFanSystem(t)
+	if _, err := fis.Infer(map[string]float64{"Temperature": math.NaN()}); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("Expected ErrOutOfBounds for NaN input, got %v", err)
+	}
+}
+
+func TestErrors_MissingInput(t *testing.T) {
+	fis := newTempFanSystem(t)
+	if _, err := fis.Infer(map[string]float64{}); !errors.Is(err, ErrMissingInput) {
+		t.Errorf("Expected ErrMissingInput, got %v", err)
+	}
+}
+
+func TestErrors_NoRulesFired(t *testing.T) {
+	fis := newTempFanSystem(t)
+	// Cold (0,0,20) and Warm (10,25,40) are both zero at exactly 0
+	_, err := fis.Infer(map[string]float64{"Temperature": 0})
+	if !errors.Is(err, ErrNoRulesFired) {
+		t.Errorf("Expected ErrNoRulesFired, got %v", err)
+	}
+}
+
+func TestErrors_InvalidResolution(t *testing.T) {
+	fis := NewMamdaniInferenceSystem()
+	if err := fis.SetResolution(0); !errors.Is(err, ErrInvalidResolution) {
+		t.Errorf("Expected ErrInvalidResolution, got %v", err)
+	}
+}
@@ -0,0 +1,222 @@
+package inference
+
+import (
+	"fmt"
+	"github.com/loian/fuzzylib/operators"
+	"github.com/loian/fuzzylib/rule"
+	"github.com/loian/fuzzylib/set"
+	"github.com/loian/fuzzylib/variable"
+	"sync"
+)
+
+// FrozenSystem is an immutable view of a MamdaniInferenceSystem created by Freeze.
+// It holds a private snapshot of the variables, rules and settings, so later edits
+// to the original system do not affect it, and its mutators always return ErrFrozen.
+//
+// Infer is safe for concurrent use: each call takes a workspace from a pool instead
+// of locking. A logger or metrics sink configured before Freeze is kept and must
+// itself be safe for concurrent use.
+type FrozenSystem struct {
+	fis        *MamdaniInferenceSystem // snapshot, never modified after Freeze
+	workspaces sync.Pool               // *InferenceWorkspace for fis
+}
+
+// Freeze returns an immutable snapshot of the system for safe sharing between goroutines.
+// Sets and membership functions are shared with the original and must not be modified.
+// Returns error if the system has no input variables, output variables or rules.
+func (fis *MamdaniInferenceSystem) Freeze() (*FrozenSystem, error) {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	snapshot := &MamdaniInferenceSystem{
+		InputVariables:      copyVariables(fis.InputVariables),
+		OutputVariables:     copyVariables(fis.OutputVariables),
+		Rules:               make([]*rule.Rule, len(fis.Rules)),
+		Resolution:          fis.Resolution,
+		DefuzzMethod:        fis.DefuzzMethod,
+		OutputDefuzzMethods: copyValues(fis.OutputDefuzzMethods),
+		ImplicationMethod:   fis.ImplicationMethod,
+		AggregationMethod:   fis.AggregationMethod,
+		AndMethod:           fis.AndMethod,
+		OrMethod:            fis.OrMethod,
+		AndOperator:         fis.AndOperator,
+		OrOperator:          fis.OrOperator,
+		InputGains:          copyValues(fis.InputGains),
+		InputDefaults:       copyValues(fis.InputDefaults),
+		DefaultRules:        copyValues(fis.DefaultRules),
+		ClampMembership:     fis.ClampMembership,
+		MinCOGMass:          fis.MinCOGMass,
+		InterpolateMaxima:   fis.InterpolateMaxima,
+		ExtendOutputSupport: fis.ExtendOutputSupport,
+		cogFallback:         fis.cogFallback,
+		hasCOGFallback:      fis.hasCOGFallback,
+		ClassifyTieBreak:    fis.ClassifyTieBreak,
+		Strict:              fis.Strict,
+		inputOrder:          append([]string(nil), fis.inputOrder...),
+		outputOrder:         append([]string(nil), fis.outputOrder...),
+		nextRuleID:          fis.nextRuleID,
+		logger:              fis.logger,
+		metrics:             fis.metrics,
+		generation:          fis.generation,
+	}
+	for i, r := range fis.Rules {
+		snapshot.Rules[i] = copyRule(r)
+	}
+
+	frozen := &FrozenSystem{fis: snapshot}
+	if err := snapshot.checkConfigured(snapshot.newWorkspace()); err != nil {
+		return nil, fmt.Errorf("cannot freeze: %w", err)
+	}
+	frozen.workspaces.New = func() any { return frozen.fis.newWorkspace() }
+	return frozen, nil
+}
+
+// copyVariables copies vars and each variable's set map
+func copyVariables(vars map[string]*variable.FuzzyVariable) map[string]*variable.FuzzyVariable {
+	copied := make(map[string]*variable.FuzzyVariable, len(vars))
+	for name, v := range vars {
+		cp := *v
+		cp.Sets = make(map[string]*set.FuzzySet, len(v.Sets))
+		for setName, s := range v.Sets {
+			cp.Sets[setName] = s
+		}
+		copied[name] = &cp
+	}
+	return copied
+}
+
+// copyRule copies r and its condition and output slices
+func copyRule(r *rule.Rule) *rule.Rule {
+	cp := *r
+	cp.Conditions = append([]rule.RuleCondition(nil), r.Conditions...)
+	cp.AdditionalOutputs = append([]rule.RuleCondition(nil), r.AdditionalOutputs...)
+	if r.Groups != nil {
+		cp.Groups = make([]rule.ConditionGroup, len(r.Groups))
+		for i, g := range r.Groups {
+			cp.Groups[i] = rule.ConditionGroup{Conditions: append([]rule.RuleCondition(nil), g.Conditions...)}
+		}
+	}
+	return &cp
+}
+
+// Infer performs Mamdani inference like MamdaniInferenceSystem.Infer.
+// It is safe to call from multiple goroutines; the returned map is owned by the caller.
+func (f *FrozenSystem) Infer(inputs map[string]float64) (map[string]float64, error) {
+	ws := f.workspaces.Get().(*InferenceWorkspace)
+	defer f.workspaces.Put(ws)
+	var results map[string]float64
+	var err error
+	if f.fis.logger != nil {
+		results, err = f.fis.inferLogged(ws, inputs)
+	} else {
+		results, err = f.fis.inferWith(ws, inputs)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return copyValues(results), nil
+}
+
+// InputDomains returns the [min, max] domain of every input variable keyed by variable name
+func (f *FrozenSystem) InputDomains() map[string][2]float64 {
+	return f.fis.InputDomains()
+}
+
+// OutputDomains returns the [min, max] domain of every output variable keyed by variable name
+func (f *FrozenSystem) OutputDomains() map[string][2]float64 {
+	return f.fis.OutputDomains()
+}
+
+// AllSets lists every set of the system, see MamdaniInferenceSystem.AllSets
+func (f *FrozenSystem) AllSets() []SetInfo {
+	return f.fis.AllSets()
+}
+
+// AddRule always returns ErrFrozen
+func (f *FrozenSystem) AddRule(r *rule.Rule) error {
+	return ErrFrozen
+}
+
+// UpsertRule always returns ErrFrozen
+func (f *FrozenSystem) UpsertRule(r *rule.Rule) error {
+	return ErrFrozen
+}
+
+// RemoveRuleByID always returns ErrFrozen
+func (f *FrozenSystem) RemoveRuleByID(id int) error {
+	return ErrFrozen
+}
+
+// AddInputVariable always returns ErrFrozen
+func (f *FrozenSystem) AddInputVariable(v *variable.FuzzyVariable) error {
+	return ErrFrozen
+}
+
+// AddOutputVariable always returns ErrFrozen
+func (f *FrozenSystem) AddOutputVariable(v *variable.FuzzyVariable) error {
+	return ErrFrozen
+}
+
+// RemoveInputVariable always returns ErrFrozen
+func (f *FrozenSystem) RemoveInputVariable(name string) error {
+	return ErrFrozen
+}
+
+// RemoveOutputVariable always returns ErrFrozen
+func (f *FrozenSystem) RemoveOutputVariable(name string) error {
+	return ErrFrozen
+}
+
+// SetResolution always returns ErrFrozen
+func (f *FrozenSystem) SetResolution(res int) error {
+	return ErrFrozen
+}
+
+// SetDefuzzificationMethod always returns ErrFrozen
+func (f *FrozenSystem) SetDefuzzificationMethod(method string) error {
+	return ErrFrozen
+}
+
+// SetAndOperator always returns ErrFrozen
+func (f *FrozenSystem) SetAndOperator(op operators.Operator) error {
+	return ErrFrozen
+}
+
+// SetOrOperator always returns ErrFrozen
+func (f *FrozenSystem) SetOrOperator(op operators.Operator) error {
+	return ErrFrozen
+}
+
+// SetAggregationMethod always returns ErrFrozen
+func (f *FrozenSystem) SetAggregationMethod(method string) error {
+	return ErrFrozen
+}
+
+// SetImplicationMethod always returns ErrFrozen
+func (f *FrozenSystem) SetImplicationMethod(method string) error {
+	return ErrFrozen
+}
+
+// SetOutputDefuzzificationMethod always returns ErrFrozen
+func (f *FrozenSystem) SetOutputDefuzzificationMethod(output, method string) error {
+	return ErrFrozen
+}
+
+// SetInputGain always returns ErrFrozen
+func (f *FrozenSystem) SetInputGain(varName string, gain float64) error {
+	return ErrFrozen
+}
+
+// SetInputDefault always returns ErrFrozen
+func (f *FrozenSystem) SetInputDefault(varName string, value float64) error {
+	return ErrFrozen
+}
+
+// SetDefaultRule always returns ErrFrozen
+func (f *FrozenSystem) SetDefaultRule(output *variable.SetRef, strength float64) error {
+	return ErrFrozen
+}
+
+// SetMinCOGMass always returns ErrFrozen
+func (f *FrozenSystem) SetMinCOGMass(mass float64, fallback ...float64) error {
+	return ErrFrozen
+}
@@ -0,0 +1,154 @@
+package inference
+
+import (
+	"errors"
+	"github.com/loian/fuzzylib/operators"
+	"github.com/loian/fuzzylib/rule"
+	"github.com/loian/fuzzylib/variable"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFreeze_MutatorsFail(t *testing.T) {
+	frozen, err := newTempFanSystem(t).Freeze()
+	if err != nil {
+		t.Fatalf("Freeze failed: %v", err)
+	}
+
+	r, _ := rule.NewRule(rule.RuleCondition{Variable: "FanSpeed", Set: "High"}, operators.AND)
+	r.AddCondition("Temperature", "Hot")
+	v, _ := variable.NewFuzzyVariable("Humidity", 0, 100)
+
+	mutators := map[string]error{
+		"AddRule":                        frozen.AddRule(r),
+		"UpsertRule":                     frozen.UpsertRule(r),
+		"RemoveRuleByID":                 frozen.RemoveRuleByID(1),
+		"AddInputVariable":               frozen.AddInputVariable(v),
+		"AddOutputVariable":              frozen.AddOutputVariable(v),
+		"RemoveInputVariable":            frozen.RemoveInputVariable("Temperature"),
+		"RemoveOutputVariable":           frozen.RemoveOutputVariable("FanSpeed"),
+		"SetResolution":                  frozen.SetResolution(100),
+		"SetDefuzzificationMethod":       frozen.SetDefuzzificationMethod(DefuzzCOG),
+		"SetImplicationMethod":           frozen.SetImplicationMethod(ImplicationMin),
+		"SetAggregationMethod":           frozen.SetAggregationMethod(AggregationSum),
+		"SetAndOperator":                 frozen.SetAndOperator(operators.PROD),
+		"SetOrOperator":                  frozen.SetOrOperator(operators.PROBOR),
+		"SetOutputDefuzzificationMethod": frozen.SetOutputDefuzzificationMethod("FanSpeed", DefuzzCOG),
+		"SetInputGain":                   frozen.SetInputGain("Temperature", 2),
+		"SetInputDefault":                frozen.SetInputDefault("Temperature", 20),
+		"SetDefaultRule":                 frozen.SetDefaultRule(&variable.SetRef{Variable: "FanSpeed", Set: "Low"}, 1),
+		"SetMinCOGMass":                  frozen.SetMinCOGMass(1),
+	}
+	for name, err := range mutators {
+		if !errors.Is(err, ErrFrozen) {
+			t.Errorf("%s: expected ErrFrozen, got %v", name, err)
+		}
+	}
+}
+
+func TestFreeze_IndependentOfOriginal(t *testing.T) {
+	fis := newTempFanSystem(t)
+	inputs := map[string]float64{"Temperature": 25}
+	want, err := fis.Infer(inputs)
+	if err != nil {
+		t.Fatalf("Infer failed: %v", err)
+	}
+
+	frozen, err := fis.Freeze()
+	if err != nil {
+		t.Fatalf("Freeze failed: %v", err)
+	}
+
+	// Edits to the original after Freeze do not reach the snapshot
+	_ = fis.SetDefuzzificationMethod(DefuzzFOM)
+	_ = fis.SetInputGain("Temperature", 2)
+	fis.Rules[1].Output.Set = "High"
+	delete(fis.InputVariables["Temperature"].Sets, "Warm")
+
+	got, err := frozen.Infer(inputs)
+	if err != nil {
+		t.Fatalf("frozen Infer failed: %v", err)
+	}
+	if !floatEqual(got["FanSpeed"], want["FanSpeed"]) {
+		t.Errorf("Expected frozen result %f, got %f", want["FanSpeed"], got["FanSpeed"])
+	}
+
+	if _, err := NewMamdaniInferenceSystem().Freeze(); err == nil {
+		t.Error("Expected error freezing an unconfigured system")
+	}
+}
+
+func TestFreeze_InferDoesNotLock(t *testing.T) {
+	fis := newTempFanSystem(t)
+	frozen, err := fis.Freeze()
+	if err != nil {
+		t.Fatalf("Freeze failed: %v", err)
+	}
+
+	// Hold the write locks of both the original and the snapshot: a locking
+	// Infer would block until the timeout
+	fis.mu.Lock()
+	defer fis.mu.Unlock()
+	frozen.fis.mu.Lock()
+	defer frozen.fis.mu.Unlock()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := frozen.Infer(map[string]float64{"Temperature": 25})
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("frozen Infer failed: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("frozen Infer blocked on a lock")
+	}
+}
+
+func TestFreeze_ConcurrentInfer(t *testing.T) {
+	fis := newTempFanSystem(t)
+	_ = fis.SetDefuzzificationMethod(DefuzzCOG)
+	frozen, err := fis.Freeze()
+	if err != nil {
+		t.Fatalf("Freeze failed: %v", err)
+	}
+
+	temps := []float64{5, 15, 25, 35, 45}
+	want := make([]float64, len(temps))
+	for i, temp := range temps {
+		out, err := fis.Infer(map[string]float64{"Temperature": temp})
+		if err != nil {
+			t.Fatalf("Infer(%f) failed: %v", temp, err)
+		}
+		want[i] = out["FanSpeed"]
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 200; n++ {
+				i := n % len(temps)
+				out, err := frozen.Infer(map[string]float64{"Temperature": temps[i]})
+				if err != nil {
+					errs <- err
+					return
+				}
+				if !floatEqual(out["FanSpeed"], want[i]) {
+					errs <- errors.New("concurrent Infer returned a different result")
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
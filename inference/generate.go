@@ -0,0 +1,128 @@
+package inference
+
+import (
+	"fmt"
+	"github.com/loian/fuzzylib/operators"
+	"github.com/loian/fuzzylib/rule"
+	"github.com/loian/fuzzylib/variable"
+	"sort"
+	"strings"
+)
+
+// GenerateRulesFromData builds a rule base from labeled samples with the Wang-Mendel
+// method and adds it to fis. inputs[i] holds the crisp input values of sample i and
+// outputs[name][i] its value for output variable name.
+//
+// For each sample, every variable is assigned the set in which the sample's value has
+// the highest membership, giving a rule "IF x1 IS A1 AND ... THEN y IS B" whose degree
+// is the product of those memberships. Rules with the same antecedent conflict; only
+// the one with the highest degree is kept. Samples for which some variable has zero
+// membership in every set are skipped. Conditions follow the sorted input variable names.
+// Returns the number of rules added.
+// Returns error if an output variable does not exist, an output series does not have
+// one value per sample, a sample lacks an input or lies outside a variable's domain,
+// or adding a rule fails.
+func GenerateRulesFromData(fis *MamdaniInferenceSystem, inputs []map[string]float64, outputs map[string][]float64) (int, error) {
+	fis.mu.Lock()
+	defer fis.mu.Unlock()
+	if len(outputs) == 0 {
+		return 0, fmt.Errorf("no output series given")
+	}
+	outputNames := make([]string, 0, len(outputs))
+	for name, series := range outputs {
+		if _, exists := fis.OutputVariables[name]; !exists {
+			return 0, fmt.Errorf("output variable '%s' does not exist", name)
+		}
+		if len(series) != len(inputs) {
+			return 0, fmt.Errorf("output '%s' has %d values for %d samples", name, len(series), len(inputs))
+		}
+		outputNames = append(outputNames, name)
+	}
+	sort.Strings(outputNames)
+	inputNames := sortedKeys(fis.InputVariables)
+
+	type candidate struct {
+		conditions []rule.RuleCondition
+		outputs    []rule.RuleCondition
+		degree     float64
+	}
+	best := make(map[string]*candidate)
+	var order []string // antecedent keys in first-seen order
+	for i, sample := range inputs {
+		c := &candidate{degree: 1}
+		for _, name := range inputNames {
+			value, exists := sample[name]
+			if !exists {
+				return 0, fmt.Errorf("sample %d: %w: %s", i, ErrMissingInput, name)
+			}
+			setName, degree, err := strongestSet(fis.InputVariables[name], value)
+			if err != nil {
+				return 0, fmt.Errorf("sample %d: %w", i, err)
+			}
+			c.conditions = append(c.conditions, rule.RuleCondition{Variable: name, Set: setName})
+			c.degree *= degree
+		}
+		for _, name := range outputNames {
+			setName, degree, err := strongestSet(fis.OutputVariables[name], outputs[name][i])
+			if err != nil {
+				return 0, fmt.Errorf("sample %d: %w", i, err)
+			}
+			c.outputs = append(c.outputs, rule.RuleCondition{Variable: name, Set: setName})
+			c.degree *= degree
+		}
+		if c.degree <= 0 {
+			continue
+		}
+		key := antecedentKey(c.conditions)
+		prev, seen := best[key]
+		if !seen {
+			order = append(order, key)
+		}
+		if !seen || c.degree > prev.degree {
+			best[key] = c
+		}
+	}
+
+	for n, key := range order {
+		c := best[key]
+		r, err := rule.NewRule(c.outputs[0], operators.AND)
+		if err != nil {
+			return n, err
+		}
+		r.Conditions = c.conditions
+		for _, out := range c.outputs[1:] {
+			if err := r.AddOutput(out.Variable, out.Set); err != nil {
+				return n, err
+			}
+		}
+		if err := fis.addRule(r); err != nil {
+			return n, fmt.Errorf("adding generated rule: %w", err)
+		}
+	}
+	return len(order), nil
+}
+
+// strongestSet returns the set of v in which value has the highest membership, and
+// that membership. Ties go to the alphabetically first set.
+// Returns error if value lies outside v's domain.
+func strongestSet(v *variable.FuzzyVariable, value float64) (string, float64, error) {
+	if value < v.MinValue || value > v.MaxValue {
+		return "", 0, fmt.Errorf("%w: value %.2f for variable '%s' is outside [%.2f, %.2f]", ErrOutOfBounds, value, v.Name, v.MinValue, v.MaxValue)
+	}
+	bestName, bestDegree := "", 0.0
+	for _, name := range sortedSetNames(v) {
+		if degree := v.Sets[name].Evaluate(value); degree > bestDegree {
+			bestName, bestDegree = name, degree
+		}
+	}
+	return bestName, bestDegree, nil
+}
+
+// antecedentKey identifies a rule antecedent by its variable/set pairs
+func antecedentKey(conditions []rule.RuleCondition) string {
+	parts := make([]string, len(conditions))
+	for i, c := range conditions {
+		parts[i] = c.Variable + "=" + c.Set
+	}
+	return strings.Join(parts, "&")
+}
@@ -0,0 +1,90 @@
+package inference
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/loian/fuzzylib/membership"
+	"github.com/loian/fuzzylib/set"
+	"github.com/loian/fuzzylib/variable"
+)
+
+// newUntrainedSystem builds an X -> Y system with Low/Mid/High sets on both sides and no rules
+func newUntrainedSystem(t *testing.T) *MamdaniInferenceSystem {
+	t.Helper()
+	fis := NewMamdaniInferenceSystem()
+	for i, name := range []string{"X", "Y"} {
+		v, _ := variable.NewFuzzyVariable(name, 0, 10)
+		v.AddSet(set.NewFuzzySet("Low", mustMF(membership.NewTriangular(-5, 0, 5))))
+		v.AddSet(set.NewFuzzySet("Mid", mustMF(membership.NewTriangular(0, 5, 10))))
+		v.AddSet(set.NewFuzzySet("High", mustMF(membership.NewTriangular(5, 10, 15))))
+		add := fis.AddInputVariable
+		if i == 1 {
+			add = fis.AddOutputVariable
+		}
+		if err := add(v); err != nil {
+			t.Fatalf("adding variable %s failed: %v", name, err)
+		}
+	}
+	return fis
+}
+
+func TestGenerateRulesFromData(t *testing.T) {
+	fis := newUntrainedSystem(t)
+
+	// An inverting plant: Y = 10 - X, with one noisy sample conflicting at X=4.5
+	xs := []float64{0.5, 1, 4.5, 5, 5.5, 9, 9.5, 4.2}
+	ys := []float64{9.5, 9, 5.5, 5, 4.5, 1, 0.5, 9.9}
+	inputs := make([]map[string]float64, len(xs))
+	for i, x := range xs {
+		inputs[i] = map[string]float64{"X": x}
+	}
+
+	n, err := GenerateRulesFromData(fis, inputs, map[string][]float64{"Y": ys})
+	if err != nil {
+		t.Fatalf("GenerateRulesFromData failed: %v", err)
+	}
+	if n != 3 || len(fis.Rules) != 3 {
+		t.Fatalf("Expected 3 rules (one per antecedent), got %d (%d in system)", n, len(fis.Rules))
+	}
+
+	want := map[string]string{"Low": "High", "Mid": "Mid", "High": "Low"}
+	for _, r := range fis.Rules {
+		if got := want[r.Conditions[0].Set]; r.Output.Set != got {
+			t.Errorf("Expected X IS %s -> Y IS %s, got Y IS %s", r.Conditions[0].Set, got, r.Output.Set)
+		}
+	}
+
+	// The generated rule base classifies the consistent training points
+	for i, x := range xs[:len(xs)-1] {
+		got, err := fis.Classify(map[string]float64{"X": x}, "Y")
+		if err != nil {
+			t.Fatalf("Classify(%v) failed: %v", x, err)
+		}
+		wantSet, _, _ := strongestSet(fis.OutputVariables["Y"], ys[i])
+		if got != wantSet {
+			t.Errorf("Classify(%v) = %s, expected %s", x, got, wantSet)
+		}
+	}
+}
+
+func TestGenerateRulesFromData_Errors(t *testing.T) {
+	fis := newUntrainedSystem(t)
+	inputs := []map[string]float64{{"X": 1}, {"X": 2}}
+
+	if _, err := GenerateRulesFromData(fis, inputs, map[string][]float64{"Z": {1, 2}}); err == nil {
+		t.Error("Expected error for unknown output variable, got nil")
+	}
+	if _, err := GenerateRulesFromData(fis, inputs, map[string][]float64{"Y": {1}}); err == nil {
+		t.Error("Expected error for mismatched series length, got nil")
+	}
+	if _, err := GenerateRulesFromData(fis, []map[string]float64{{}}, map[string][]float64{"Y": {1}}); !errors.Is(err, ErrMissingInput) {
+		t.Errorf("Expected ErrMissingInput, got %v", err)
+	}
+	if _, err := GenerateRulesFromData(fis, inputs, map[string][]float64{"Y": {1, 20}}); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("Expected ErrOutOfBounds, got %v", err)
+	}
+	if len(fis.Rules) != 0 {
+		t.Errorf("Expected no rules added on error, got %d", len(fis.Rules))
+	}
+}
@@ -0,0 +1,188 @@
+package inference
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// Names of the checks performed by SelfCheck
+const (
+	CheckReferences   = "references"    // every rule references existing variables and sets
+	CheckReachability = "reachability"  // every output set is the consequent of at least one rule
+	CheckCoverage     = "coverage"      // every input domain is covered by its sets
+	CheckDeadRules    = "dead-rules"    // every rule fires on at least one random input
+	CheckOutputBounds = "output-bounds" // crisp outputs stay within their domains
+)
+
+// HealthCoverageThreshold is the minimum fraction of each input domain that must
+// have non-zero membership in at least one set for the coverage check to pass.
+var HealthCoverageThreshold = 0.95
+
+// selfCheckSamples is the number of random inputs used by the sampling checks
+const selfCheckSamples = 500
+
+// HealthCheck is the outcome of one SelfCheck validator
+type HealthCheck struct {
+	Name    string   // one of the Check* constants
+	Passed  bool     // true if no problems were found
+	Details []string // human-readable description of each problem found
+}
+
+// HealthReport aggregates the results of every SelfCheck validator
+type HealthReport struct {
+	Checks []HealthCheck
+}
+
+// Passed reports whether every check passed
+func (hr *HealthReport) Passed() bool {
+	for _, c := range hr.Checks {
+		if !c.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// Check returns the check with the given name, or nil if it was not run
+func (hr *HealthReport) Check(name string) *HealthCheck {
+	for i := range hr.Checks {
+		if hr.Checks[i].Name == name {
+			return &hr.Checks[i]
+		}
+	}
+	return nil
+}
+
+// String renders the report as one line per check
+func (hr *HealthReport) String() string {
+	var b strings.Builder
+	for _, c := range hr.Checks {
+		status := "PASS"
+		if !c.Passed {
+			status = "FAIL"
+		}
+		fmt.Fprintf(&b, "[%s] %s", status, c.Name)
+		if len(c.Details) > 0 {
+			fmt.Fprintf(&b, ": %s", strings.Join(c.Details, "; "))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// SelfCheck runs every static and sampling-based validator against the
+// configured system and returns a report with pass/fail per check. It is meant
+// to be called once before a system goes live. The sampling checks use a fixed
+// seed, so the report is reproducible.
+func (fis *MamdaniInferenceSystem) SelfCheck() *HealthReport {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	report := &HealthReport{}
+	report.Checks = append(report.Checks,
+		fis.checkReferences(),
+		fis.checkReachability(),
+		fis.checkCoverage(),
+		fis.checkDeadRules(),
+		fis.checkOutputBounds(),
+	)
+	return report
+}
+
+// newHealthCheck builds a check that passes when details is empty
+func newHealthCheck(name string, details []string) HealthCheck {
+	return HealthCheck{Name: name, Passed: len(details) == 0, Details: details}
+}
+
+func (fis *MamdaniInferenceSystem) checkReferences() HealthCheck {
+	var details []string
+	if len(fis.Rules) == 0 {
+		details = append(details, "system has no rules")
+	}
+	for i, r := range fis.Rules {
+		if err := fis.validateRule(r); err != nil {
+			details = append(details, fmt.Sprintf("rule %d: %v", i+1, err))
+		}
+	}
+	return newHealthCheck(CheckReferences, details)
+}
+
+func (fis *MamdaniInferenceSystem) checkReachability() HealthCheck {
+	reached := make(map[string]map[string]bool)
+	for _, r := range fis.Rules {
+		for _, out := range r.Outputs() {
+			if reached[out.Variable] == nil {
+				reached[out.Variable] = make(map[string]bool)
+			}
+			reached[out.Variable][out.Set] = true
+		}
+	}
+	var details []string
+	for _, varName := range sortedKeys(fis.OutputVariables) {
+		for _, setName := range sortedSetNames(fis.OutputVariables[varName]) {
+			if !reached[varName][setName] {
+				details = append(details, fmt.Sprintf("output set '%s.%s' is not the consequent of any rule", varName, setName))
+			}
+		}
+	}
+	return newHealthCheck(CheckReachability, details)
+}
+
+func (fis *MamdaniInferenceSystem) checkCoverage() HealthCheck {
+	resolution := fis.Resolution
+	if resolution <= 0 {
+		resolution = DefaultResolution
+	}
+	var details []string
+	for _, varName := range sortedKeys(fis.InputVariables) {
+		v := fis.InputVariables[varName]
+		step := (v.MaxValue - v.MinValue) / float64(resolution)
+		covered := 0
+		for i := 0; i <= resolution; i++ {
+			x := v.MinValue + float64(i)*step
+			for _, fs := range v.Sets {
+				if fs.Evaluate(x) > 0 {
+					covered++
+					break
+				}
+			}
+		}
+		fraction := float64(covered) / float64(resolution+1)
+		if fraction < HealthCoverageThreshold {
+			details = append(details, fmt.Sprintf("input '%s' coverage %.1f%% is below %.1f%%",
+				varName, fraction*100, HealthCoverageThreshold*100))
+		}
+	}
+	return newHealthCheck(CheckCoverage, details)
+}
+
+func (fis *MamdaniInferenceSystem) checkDeadRules() HealthCheck {
+	fired := make([]bool, len(fis.Rules))
+	ws := fis.newWorkspace()
+	r := rand.New(rand.NewSource(verifySeed))
+	for i := 0; i < selfCheckSamples; i++ {
+		if err := fis.fire(ws, fis.randomInputs(r)); err != nil {
+			continue
+		}
+		for idx, strength := range ws.ruleStrengths {
+			if strength > 0 {
+				fired[idx] = true
+			}
+		}
+	}
+	var details []string
+	for idx, ok := range fired {
+		if !ok {
+			details = append(details, fmt.Sprintf("rule %d never fired on %d random inputs", idx+1, selfCheckSamples))
+		}
+	}
+	return newHealthCheck(CheckDeadRules, details)
+}
+
+func (fis *MamdaniInferenceSystem) checkOutputBounds() HealthCheck {
+	var details []string
+	if err := fis.verifyOutputBounds(selfCheckSamples); err != nil {
+		details = append(details, err.Error())
+	}
+	return newHealthCheck(CheckOutputBounds, details)
+}
@@ -0,0 +1,50 @@
+package inference
+
+import (
+	"github.com/loian/fuzzylib/membership"
+	"github.com/loian/fuzzylib/rule"
+	"github.com/loian/fuzzylib/set"
+	"testing"
+)
+
+func TestSelfCheck_WellFormed(t *testing.T) {
+	fis := newTempFanSystem(t)
+	report := fis.SelfCheck()
+	if !report.Passed() {
+		t.Errorf("Expected well-formed system to pass, got:\n%s", report)
+	}
+	for _, name := range []string{CheckReferences, CheckReachability, CheckCoverage, CheckDeadRules, CheckOutputBounds} {
+		if report.Check(name) == nil {
+			t.Errorf("Expected check %q in report", name)
+		}
+	}
+}
+
+func TestSelfCheck_Broken(t *testing.T) {
+	fis := newTempFanSystem(t)
+
+	// Unreachable output set
+	fis.OutputVariables["FanSpeed"].AddSet(set.NewFuzzySet("Turbo", mustMF(membership.NewTriangular(90, 100, 100))))
+	// Gap in input coverage: remove Warm so [20, 30] has no membership
+	delete(fis.InputVariables["Temperature"].Sets, "Warm")
+	// Rule whose reference breaks after the set is removed (also never fires)
+	fis.Rules[1] = &rule.Rule{
+		Conditions: []rule.RuleCondition{{Variable: "Temperature", Set: "Warm"}},
+		Output:     rule.RuleCondition{Variable: "FanSpeed", Set: "Medium"},
+		Weight:     1,
+		Operator:   fis.Rules[0].Operator,
+	}
+
+	report := fis.SelfCheck()
+	if report.Passed() {
+		t.Fatal("Expected broken system to fail")
+	}
+	for _, name := range []string{CheckReferences, CheckReachability, CheckCoverage, CheckDeadRules} {
+		if c := report.Check(name); c == nil || c.Passed {
+			t.Errorf("Expected check %q to fail, got %+v", name, c)
+		}
+	}
+	if c := report.Check(CheckOutputBounds); c == nil || !c.Passed {
+		t.Errorf("Expected output bounds check to pass, got %+v", c)
+	}
+}
@@ -0,0 +1,47 @@
+package inference
+
+import (
+	"sort"
+)
+
+// RuleImportance ranks the system's rules by their mean firing strength over a
+// representative set of inputs. It returns rule indices (into fis.Rules) sorted
+// from most to least important, together with the matching mean strengths.
+// Rules with equal strength keep their original order. Inputs that fail
+// validation (missing or out-of-bounds values) are skipped; if none are usable,
+// every mean is 0.
+// Rules that rarely fire are candidates for pruning.
+func (fis *MamdaniInferenceSystem) RuleImportance(inputs []map[string]float64) ([]int, []float64) {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	sums := make([]float64, len(fis.Rules))
+	used := 0
+	ws := fis.newWorkspace()
+	for _, in := range inputs {
+		if err := fis.fire(ws, in); err != nil {
+			continue
+		}
+		for i, strength := range ws.ruleStrengths {
+			sums[i] += strength
+		}
+		used++
+	}
+
+	indices := make([]int, len(fis.Rules))
+	means := make([]float64, len(fis.Rules))
+	for i := range indices {
+		indices[i] = i
+		if used > 0 {
+			means[i] = sums[i] / float64(used)
+		}
+	}
+	sort.SliceStable(indices, func(a, b int) bool {
+		return means[indices[a]] > means[indices[b]]
+	})
+
+	ranked := make([]float64, len(indices))
+	for i, idx := range indices {
+		ranked[i] = means[idx]
+	}
+	return indices, ranked
+}
@@ -0,0 +1,39 @@
+package inference
+
+import (
+	"testing"
+)
+
+func TestRuleImportance(t *testing.T) {
+	fis := newTempFanSystem(t)
+
+	// Mostly hot inputs: the Hot rule (index 2) fires often, Cold (index 0) rarely
+	inputs := []map[string]float64{
+		{"Temperature": 45},
+		{"Temperature": 48},
+		{"Temperature": 42},
+		{"Temperature": 38},
+		{"Temperature": 5},
+		{"Temperature": 99}, // out of bounds, skipped
+	}
+	indices, means := fis.RuleImportance(inputs)
+	if len(indices) != 3 || len(means) != 3 {
+		t.Fatalf("Expected 3 ranked rules, got %d indices and %d means", len(indices), len(means))
+	}
+	if indices[0] != 2 {
+		t.Errorf("Expected Hot rule (2) to rank first, got %v (means %v)", indices, means)
+	}
+
+	rank := make(map[int]int)
+	for pos, idx := range indices {
+		rank[idx] = pos
+	}
+	if rank[2] > rank[0] {
+		t.Errorf("Expected frequently-firing Hot rule above rarely-firing Cold rule, got %v", indices)
+	}
+	for i := 1; i < len(means); i++ {
+		if means[i] > means[i-1] {
+			t.Errorf("Expected means sorted descending, got %v", means)
+		}
+	}
+}
@@ -1,11 +1,16 @@
 package inference
 
 import (
+	"errors"
 	"fmt"
+	"github.com/loian/fuzzylib/membership"
 	"github.com/loian/fuzzylib/operators"
 	"github.com/loian/fuzzylib/rule"
 	"github.com/loian/fuzzylib/variable"
 	"math"
+	"math/rand"
+	"sort"
+	"sync"
 )
 
 // DefaultResolution is the default sampling resolution used for defuzzification.
@@ -20,11 +25,35 @@ const (
 	DefuzzCOG = "centroid" // Center of Gravity (default)
 	DefuzzMOM = "mom"      // Mean of Maximum
 	DefuzzFOM = "fom"      // First of Maximum
-	DefuzzLOM = "lom"      // Last of Maximum (mapped to FOM)
-	DefuzzSOM = "som"      // Smallest of Maximum (mapped to FOM)
+	DefuzzLOM = "lom"      // Last of Maximum
+	DefuzzSOM = "som"      // Smallest of Maximum
+	DefuzzBIS = "bisector" // Bisector of area
+)
+
+// Implication method constants
+const (
+	ImplicationProduct = "prod" // Scale the output set by the firing strength (default)
+	ImplicationMin     = "min"  // Clip the output set at the firing strength
+)
+
+// Aggregation method constants
+const (
+	AggregationMax    = "max"    // Combine fired output sets with MAX (default)
+	AggregationSum    = "sum"    // Add the fired output sets
+	AggregationProbOr = "probor" // Probabilistic OR: a + b - a*b
 )
 
 // MamdaniInferenceSystem represents a complete Mamdani FIS
+//
+// A system may be shared between goroutines: its methods hold a read lock for
+// the whole of an inference or analysis call and a write lock for the whole of
+// a mutation (the Add*, Remove*, UpsertRule, InvalidateCaches and Set* methods),
+// so inference runs concurrently with other inference and is serialized against
+// changes. Direct edits to the exported fields, variables or rules, and
+// workspaces themselves, are not synchronized. A logger or metrics sink is
+// called from the inferring goroutine while the read lock is held, so it must be
+// safe for concurrent use and must not modify the system. The zero value is not
+// configured; use NewMamdaniInferenceSystem.
 type MamdaniInferenceSystem struct {
 	InputVariables  map[string]*variable.FuzzyVariable
 	OutputVariables map[string]*variable.FuzzyVariable
@@ -34,81 +63,836 @@ type MamdaniInferenceSystem struct {
 	Resolution int
 	// DefuzzMethod specifies which defuzzification method to use: "centroid", "mom", "fom"
 	DefuzzMethod string
+	// OutputDefuzzMethods holds optional per-output overrides of DefuzzMethod, keyed by output variable name
+	OutputDefuzzMethods map[string]string
+	// ImplicationMethod specifies how a rule's firing strength shapes its output set: "prod" or "min"
+	ImplicationMethod string
+	// AggregationMethod specifies how the shaped output sets of a variable are
+	// combined at each sampled point: "max", "sum" or "probor"
+	AggregationMethod string
+	// AndMethod and OrMethod record the registered names of the AND and OR operators
+	// used by the rules, for display. SetAndOperator and SetOrOperator keep them in
+	// sync with AndOperator and OrOperator; on their own they do not affect inference.
+	AndMethod string
+	OrMethod  string
+	// AndOperator and OrOperator, when set, replace the operator of every AND
+	// (operators.MinOperator) and OR (operators.MaxOperator) connective during
+	// rule evaluation. Set them with SetAndOperator and SetOrOperator.
+	AndOperator operators.Operator
+	OrOperator  operators.Operator
+	// InputGains holds optional per-input multipliers applied to crisp inputs
+	// before bounds checking and fuzzification. Inputs without an entry use a gain of 1.
+	InputGains map[string]float64
+	// InputDefaults holds optional per-input values used by Infer when the
+	// input is absent from the inputs map. Inputs without an entry are required.
+	InputDefaults map[string]float64
+	// DefaultRules holds optional per-output catch-all rules, keyed by output
+	// variable name. Set them with SetDefaultRule.
+	DefaultRules map[string]DefaultRule
+	// ClampMembership limits the per-point aggregated output membership to 1.0
+	// before defuzzification (default true). Without it, strengths above 1 scale
+	// output sets above 1 and distort maximum detection and COG weighting.
+	ClampMembership bool
+	// MinCOGMass is the smallest area under the aggregated output curve for which
+	// centroid defuzzification returns a result (default 0: only an empty curve fails).
+	// Set it with SetMinCOGMass.
+	MinCOGMass float64
+	// InterpolateMaxima refines the FOM/SOM/LOM result between grid samples
+	// (parabolic interpolation around a peak, linear extrapolation of the rising
+	// or, for LOM, falling edge of a plateau) instead of snapping to the
+	// resolution grid (default false).
+	InterpolateMaxima bool
+	// ExtendOutputSupport makes defuzzification sample fired output sets over their
+	// whole bounded support when it extends past the output domain, instead of
+	// ignoring the part outside (default false: output sets are clipped to the
+	// domain). The crisp result is still clamped to the domain. Lint reports such
+	// sets either way.
+	ExtendOutputSupport bool
+	// cogFallback is returned instead of ErrNoRulesFired when the COG mass is
+	// below MinCOGMass and hasCOGFallback is set
+	cogFallback    float64
+	hasCOGFallback bool
+	// ClassifyTieBreak selects how Classify picks among output sets tied at the
+	// highest strength: ClassifyTieFirst (default, also used when empty),
+	// ClassifyTieName or ClassifyTieError. Set it with SetClassifyTieBreak.
+	ClassifyTieBreak string
+	// Strict makes AddRule reject rules that are valid but almost certainly
+	// mistakes, such as OR rules with a single condition (see Lint) or rules
+	// without an Operator.
+	Strict bool
+
+	// inputOrder and outputOrder hold variable names in the order they were
+	// added, for MarshalSystem
+	inputOrder  []string
+	outputOrder []string
+	// nextRuleID is the last ID handed out by AddRule; IDs are never reused
+	nextRuleID int
+	// logger receives an InferRecord after each Infer call when set
+	logger func(InferRecord)
+	// metrics receives per-stage timings from Infer and InferWith when set
+	metrics MetricsSink
+	// generation is bumped by InvalidateCaches; workspaces built for an older
+	// generation are rebuilt on their next use
+	generation uint64
+	// mu is read-locked by the inference methods and write-locked by the mutators
+	mu sync.RWMutex
 }
 
 // NewMamdaniInferenceSystem creates a new inference system
 func NewMamdaniInferenceSystem() *MamdaniInferenceSystem {
 	return &MamdaniInferenceSystem{
-		InputVariables:  make(map[string]*variable.FuzzyVariable),
-		OutputVariables: make(map[string]*variable.FuzzyVariable),
-		Rules:           make([]*rule.Rule, 0),
-		Resolution:      DefaultResolution,
-		DefuzzMethod:    DefuzzMOM, // Default to MOM (current behavior)
+		InputVariables:      make(map[string]*variable.FuzzyVariable),
+		OutputVariables:     make(map[string]*variable.FuzzyVariable),
+		Rules:               make([]*rule.Rule, 0),
+		Resolution:          DefaultResolution,
+		DefuzzMethod:        DefuzzMOM, // Default to MOM (current behavior)
+		ImplicationMethod:   ImplicationProduct,
+		AggregationMethod:   AggregationMax,
+		OutputDefuzzMethods: make(map[string]string),
+		InputGains:          make(map[string]float64),
+		InputDefaults:       make(map[string]float64),
+		ClampMembership:     true,
 	}
 }
 
 // SetResolution sets the sampling resolution used for defuzzification.
 // Resolution must be > 0. Returns error if resolution is invalid.
 func (fis *MamdaniInferenceSystem) SetResolution(res int) error {
+	fis.mu.Lock()
+	defer fis.mu.Unlock()
 	if res <= 0 {
-		return fmt.Errorf("resolution must be > 0, got %d", res)
+		return fmt.Errorf("%w, got %d", ErrInvalidResolution, res)
 	}
 	fis.Resolution = res
 	return nil
 }
 
+// SetMinCOGMass sets the minimum area under the aggregated output curve required for
+// centroid defuzzification. Below it the centroid is numerically unstable, so COG
+// returns ErrNoRulesFired, or fallback if one is given. A mass of 0 disables the check.
+// Returns error if mass is negative, NaN or infinite, or if more than one fallback is given.
+func (fis *MamdaniInferenceSystem) SetMinCOGMass(mass float64, fallback ...float64) error {
+	fis.mu.Lock()
+	defer fis.mu.Unlock()
+	if mass < 0 || math.IsNaN(mass) || math.IsInf(mass, 0) {
+		return fmt.Errorf("minimum COG mass must be a finite number >= 0, got %v", mass)
+	}
+	if len(fallback) > 1 {
+		return fmt.Errorf("at most one COG fallback value may be given, got %d", len(fallback))
+	}
+	fis.MinCOGMass = mass
+	fis.hasCOGFallback = len(fallback) == 1
+	fis.cogFallback = 0
+	if fis.hasCOGFallback {
+		fis.cogFallback = fallback[0]
+	}
+	return nil
+}
+
 // SetDefuzzificationMethod sets the defuzzification method.
-// Valid methods: "centroid", "mom", "fom", "lom", "som"
+// Valid methods: "centroid", "mom", "fom", "lom", "som", "bisector"
 // Returns error if method is not recognized.
 func (fis *MamdaniInferenceSystem) SetDefuzzificationMethod(method string) error {
+	fis.mu.Lock()
+	defer fis.mu.Unlock()
+	if err := validateDefuzzMethod(method); err != nil {
+		return err
+	}
+	fis.DefuzzMethod = method
+	return nil
+}
+
+// SetImplicationMethod sets how a rule's firing strength shapes its output sets.
+// Valid methods: "prod", "min"
+// Returns error if method is not recognized.
+func (fis *MamdaniInferenceSystem) SetImplicationMethod(method string) error {
+	fis.mu.Lock()
+	defer fis.mu.Unlock()
+	switch method {
+	case ImplicationProduct, ImplicationMin:
+		fis.ImplicationMethod = method
+		return nil
+	}
+	return fmt.Errorf("invalid implication method '%s': must be 'prod' or 'min'", method)
+}
+
+// SetAggregationMethod sets how the shaped output sets of a variable are combined
+// at each sampled point before defuzzification. Rules firing into the same set
+// are still combined with MAX.
+// Valid methods: "max", "sum", "probor"
+// Returns error if method is not recognized.
+func (fis *MamdaniInferenceSystem) SetAggregationMethod(method string) error {
+	fis.mu.Lock()
+	defer fis.mu.Unlock()
+	switch method {
+	case AggregationMax, AggregationSum, AggregationProbOr:
+		fis.AggregationMethod = method
+		return nil
+	}
+	return fmt.Errorf("invalid aggregation method '%s': must be 'max', 'sum' or 'probor'", method)
+}
+
+// SetAndOperator makes every AND connective of the rules combine its terms with op,
+// e.g. operators.PROD, instead of MIN, and records its name in AndMethod. A nil op
+// removes the override.
+// Returns error if op is neither registered (see operators.Register) nor a
+// parameterized built-in such as operators.NewHamacherAnd.
+func (fis *MamdaniInferenceSystem) SetAndOperator(op operators.Operator) error {
+	fis.mu.Lock()
+	defer fis.mu.Unlock()
+	name, err := overrideName(op)
+	if err != nil {
+		return fmt.Errorf("invalid AND operator: %w", err)
+	}
+	fis.AndOperator, fis.AndMethod = op, name
+	return nil
+}
+
+// SetOrOperator makes every OR connective of the rules combine its terms with op,
+// e.g. operators.PROBOR, instead of MAX, and records its name in OrMethod. A nil op
+// removes the override.
+// Returns error if op is neither registered (see operators.Register) nor a
+// parameterized built-in such as operators.NewHamacherAnd.
+func (fis *MamdaniInferenceSystem) SetOrOperator(op operators.Operator) error {
+	fis.mu.Lock()
+	defer fis.mu.Unlock()
+	name, err := overrideName(op)
+	if err != nil {
+		return fmt.Errorf("invalid OR operator: %w", err)
+	}
+	fis.OrOperator, fis.OrMethod = op, name
+	return nil
+}
+
+// connectives returns the AND/OR overrides applied during rule evaluation
+func (fis *MamdaniInferenceSystem) connectives() rule.Connectives {
+	return rule.Connectives{And: fis.AndOperator, Or: fis.OrOperator}
+}
+
+// overrideName returns the name of a connective override (see operators.NameOf), or "" for nil
+func overrideName(op operators.Operator) (string, error) {
+	if op == nil {
+		return "", nil
+	}
+	name, ok := operators.NameOf(op)
+	if !ok {
+		return "", fmt.Errorf("%w: %T is not registered", operators.ErrUnknownOperator, op)
+	}
+	return name, nil
+}
+
+// AndMethodName returns the name of the AND operator recorded in AndMethod, or "min" if none was recorded
+func (fis *MamdaniInferenceSystem) AndMethodName() string {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	if fis.AndMethod == "" {
+		return "min"
+	}
+	return fis.AndMethod
+}
+
+// OrMethodName returns the name of the OR operator recorded in OrMethod, or "max" if none was recorded
+func (fis *MamdaniInferenceSystem) OrMethodName() string {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	if fis.OrMethod == "" {
+		return "max"
+	}
+	return fis.OrMethod
+}
+
+// ImpMethodName returns the implication method, ImplicationMethod ("prod" if unset)
+func (fis *MamdaniInferenceSystem) ImpMethodName() string {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	if fis.ImplicationMethod == "" {
+		return ImplicationProduct
+	}
+	return fis.ImplicationMethod
+}
+
+// AggMethodName returns the aggregation method, AggregationMethod ("max" if unset)
+func (fis *MamdaniInferenceSystem) AggMethodName() string {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	if fis.AggregationMethod == "" {
+		return AggregationMax
+	}
+	return fis.AggregationMethod
+}
+
+// SetOutputDefuzzificationMethod overrides the defuzzification method for a single
+// output variable. An empty method removes the override so the output follows DefuzzMethod.
+// Returns error if the output variable does not exist or the method is not recognized.
+func (fis *MamdaniInferenceSystem) SetOutputDefuzzificationMethod(output, method string) error {
+	fis.mu.Lock()
+	defer fis.mu.Unlock()
+	if _, exists := fis.OutputVariables[output]; !exists {
+		return fmt.Errorf("output variable '%s' does not exist", output)
+	}
+	if method == "" {
+		delete(fis.OutputDefuzzMethods, output)
+		return nil
+	}
+	if err := validateDefuzzMethod(method); err != nil {
+		return err
+	}
+	if fis.OutputDefuzzMethods == nil {
+		fis.OutputDefuzzMethods = make(map[string]string)
+	}
+	fis.OutputDefuzzMethods[output] = method
+	return nil
+}
+
+// defuzzMethodFor returns the defuzzification method used for the named output
+func (fis *MamdaniInferenceSystem) defuzzMethodFor(output string) string {
+	if method, ok := fis.OutputDefuzzMethods[output]; ok {
+		return method
+	}
+	return fis.DefuzzMethod
+}
+
+// validateDefuzzMethod returns error if method is not a recognized defuzzification method
+func validateDefuzzMethod(method string) error {
 	switch method {
-	case DefuzzCOG, DefuzzMOM, DefuzzFOM, DefuzzLOM, DefuzzSOM:
-		fis.DefuzzMethod = method
+	case DefuzzCOG, DefuzzMOM, DefuzzFOM, DefuzzLOM, DefuzzSOM, DefuzzBIS:
 		return nil
 	default:
-		return fmt.Errorf("invalid defuzzification method '%s': must be one of: centroid, mom, fom, lom, som", method)
+		return fmt.Errorf("invalid defuzzification method '%s': must be one of: centroid, mom, fom, lom, som, bisector", method)
 	}
 }
 
+// SetInputGain sets a multiplier applied to the named input before it is fuzzified.
+// The gain is applied before bounds checking, so the scaled value (input * gain)
+// must lie within the variable's domain or Infer returns an out-of-bounds error.
+// A gain of 1 restores the unscaled behavior.
+// Returns error if the input variable does not exist or the gain is NaN or infinite.
+func (fis *MamdaniInferenceSystem) SetInputGain(varName string, gain float64) error {
+	fis.mu.Lock()
+	defer fis.mu.Unlock()
+	if _, exists := fis.InputVariables[varName]; !exists {
+		return fmt.Errorf("input variable '%s' does not exist", varName)
+	}
+	if math.IsNaN(gain) || math.IsInf(gain, 0) {
+		return fmt.Errorf("input gain must be a finite number, got %v", gain)
+	}
+	if fis.InputGains == nil {
+		fis.InputGains = make(map[string]float64)
+	}
+	fis.InputGains[varName] = gain
+	return nil
+}
+
+// SetInputDefault sets the value used for the named input when it is missing from the
+// inputs passed to Infer. The default stands in for the crisp input, so any input gain
+// is still applied to it.
+// Returns error if the input variable does not exist or the value lies outside its domain.
+func (fis *MamdaniInferenceSystem) SetInputDefault(varName string, value float64) error {
+	fis.mu.Lock()
+	defer fis.mu.Unlock()
+	inputVar, exists := fis.InputVariables[varName]
+	if !exists {
+		return fmt.Errorf("input variable '%s' does not exist", varName)
+	}
+	if math.IsNaN(value) || value < inputVar.MinValue || value > inputVar.MaxValue {
+		return fmt.Errorf("default for '%s' must be in range [%.2f, %.2f], got %.2f", varName, inputVar.MinValue, inputVar.MaxValue, value)
+	}
+	if fis.InputDefaults == nil {
+		fis.InputDefaults = make(map[string]float64)
+	}
+	fis.InputDefaults[varName] = value
+	return nil
+}
+
+// DefaultRule is an else rule for one output variable: Set fires at Strength
+// whenever no other rule for that output fires.
+type DefaultRule struct {
+	Set      string
+	Strength float64
+}
+
+// SetDefaultRule makes output.Set fire at the given strength whenever no other
+// rule for output.Variable fires, so inputs outside every rule's coverage still
+// produce that set instead of failing defuzzification. Inhibitory rules are
+// still applied afterwards.
+// Returns error if the output variable or set does not exist or strength is not in (0, 1].
+func (fis *MamdaniInferenceSystem) SetDefaultRule(output *variable.SetRef, strength float64) error {
+	fis.mu.Lock()
+	defer fis.mu.Unlock()
+	if output == nil {
+		return fmt.Errorf("default rule output cannot be nil")
+	}
+	outputVar, exists := fis.OutputVariables[output.Variable]
+	if !exists {
+		return fmt.Errorf("output variable '%s' does not exist", output.Variable)
+	}
+	if _, exists := outputVar.Sets[output.Set]; !exists {
+		return fmt.Errorf("output set '%s' does not exist in variable '%s'", output.Set, output.Variable)
+	}
+	if math.IsNaN(strength) || strength <= 0 || strength > 1 {
+		return fmt.Errorf("default rule strength must be in (0, 1], got %v", strength)
+	}
+	if fis.DefaultRules == nil {
+		fis.DefaultRules = make(map[string]DefaultRule)
+	}
+	fis.DefaultRules[output.Variable] = DefaultRule{Set: output.Set, Strength: strength}
+	return nil
+}
+
 // AddInputVariable adds an input variable.
 // Returns error if a variable with the same name already exists.
 func (fis *MamdaniInferenceSystem) AddInputVariable(v *variable.FuzzyVariable) error {
+	fis.mu.Lock()
+	defer fis.mu.Unlock()
 	if _, exists := fis.InputVariables[v.Name]; exists {
 		return fmt.Errorf("input variable '%s' already exists", v.Name)
 	}
 	fis.InputVariables[v.Name] = v
+	fis.inputOrder = append(fis.inputOrder, v.Name)
+	fis.invalidateCaches()
 	return nil
 }
 
 // AddOutputVariable adds an output variable.
 // Returns error if a variable with the same name already exists.
 func (fis *MamdaniInferenceSystem) AddOutputVariable(v *variable.FuzzyVariable) error {
+	fis.mu.Lock()
+	defer fis.mu.Unlock()
 	if _, exists := fis.OutputVariables[v.Name]; exists {
 		return fmt.Errorf("output variable '%s' already exists", v.Name)
 	}
 	fis.OutputVariables[v.Name] = v
+	fis.outputOrder = append(fis.outputOrder, v.Name)
+	fis.invalidateCaches()
+	return nil
+}
+
+// RemoveInputVariable removes the named input variable together with its input
+// gain and default.
+// Returns error if the variable does not exist or a rule has a condition on it.
+func (fis *MamdaniInferenceSystem) RemoveInputVariable(name string) error {
+	fis.mu.Lock()
+	defer fis.mu.Unlock()
+	if _, exists := fis.InputVariables[name]; !exists {
+		return fmt.Errorf("input variable '%s' does not exist", name)
+	}
+	for _, r := range fis.Rules {
+		for _, cond := range r.AllConditions() {
+			if cond.Variable == name {
+				return fmt.Errorf("cannot remove input variable '%s': referenced by rule %d", name, r.ID)
+			}
+		}
+	}
+	delete(fis.InputVariables, name)
+	fis.inputOrder = without(fis.inputOrder, name)
+	delete(fis.InputGains, name)
+	delete(fis.InputDefaults, name)
+	fis.invalidateCaches()
+	return nil
+}
+
+// RemoveOutputVariable removes the named output variable together with its
+// defuzzification method override and default rule.
+// Returns error if the variable does not exist or a rule concludes it.
+func (fis *MamdaniInferenceSystem) RemoveOutputVariable(name string) error {
+	fis.mu.Lock()
+	defer fis.mu.Unlock()
+	if _, exists := fis.OutputVariables[name]; !exists {
+		return fmt.Errorf("output variable '%s' does not exist", name)
+	}
+	for _, r := range fis.Rules {
+		for _, out := range r.Outputs() {
+			if out.Variable == name {
+				return fmt.Errorf("cannot remove output variable '%s': referenced by rule %d", name, r.ID)
+			}
+		}
+	}
+	delete(fis.OutputVariables, name)
+	fis.outputOrder = without(fis.outputOrder, name)
+	delete(fis.OutputDefuzzMethods, name)
+	delete(fis.DefaultRules, name)
+	fis.invalidateCaches()
+	return nil
+}
+
+// InvalidateCaches discards state derived from the system's configuration, so
+// that existing workspaces are rebuilt on their next use. Variables, rules and
+// settings are kept. AddRule, RemoveRuleByID and the Add*Variable and
+// Remove*Variable methods call it automatically; call it explicitly after editing InputVariables,
+// OutputVariables, Rules or a variable's sets directly.
+func (fis *MamdaniInferenceSystem) InvalidateCaches() {
+	fis.mu.Lock()
+	defer fis.mu.Unlock()
+	fis.invalidateCaches()
+}
+
+// invalidateCaches is InvalidateCaches for callers already holding the write lock
+func (fis *MamdaniInferenceSystem) invalidateCaches() {
+	fis.generation++
+}
+
+// InputDomains returns the [min, max] domain of every input variable keyed by variable name.
+// The returned map is a copy and may be modified freely.
+func (fis *MamdaniInferenceSystem) InputDomains() map[string][2]float64 {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	return variableDomains(fis.InputVariables)
+}
+
+// OutputDomains returns the [min, max] domain of every output variable keyed by variable name.
+// The returned map is a copy and may be modified freely.
+func (fis *MamdaniInferenceSystem) OutputDomains() map[string][2]float64 {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	return variableDomains(fis.OutputVariables)
+}
+
+// SetInfo describes one fuzzy set of a system as listed by AllSets
+type SetInfo struct {
+	Variable string    // Name of the variable owning the set
+	Set      string    // Set name
+	Kind     string    // Membership function kind (membership.Kind*), empty for custom functions
+	Params   []float64 // Membership function parameters in the order accepted by membership.New, nil for custom functions
+	IsInput  bool      // True for sets of input variables
+}
+
+// AllSets returns every set of the system as a flat list: input variables first,
+// then output variables, each in variable name order with sets in name order.
+// Sets whose membership function is not a built-in kind have an empty Kind and nil Params.
+func (fis *MamdaniInferenceSystem) AllSets() []SetInfo {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	var infos []SetInfo
+	collect := func(vars map[string]*variable.FuzzyVariable, isInput bool) {
+		for _, varName := range sortedKeys(vars) {
+			v := vars[varName]
+			for _, setName := range sortedSetNames(v) {
+				info := SetInfo{Variable: varName, Set: setName, IsInput: isInput}
+				if p, ok := v.Sets[setName].MembershipFunc.(membership.Parameterized); ok {
+					info.Kind = p.Kind()
+					info.Params = p.Params()
+				}
+				infos = append(infos, info)
+			}
+		}
+	}
+	collect(fis.InputVariables, true)
+	collect(fis.OutputVariables, false)
+	return infos
+}
+
+// variableDomains collects the domain of each variable in vars
+func variableDomains(vars map[string]*variable.FuzzyVariable) map[string][2]float64 {
+	domains := make(map[string][2]float64, len(vars))
+	for name, v := range vars {
+		domains[name] = [2]float64{v.MinValue, v.MaxValue}
+	}
+	return domains
+}
+
+// RandomInputs returns a crisp value for every input variable drawn uniformly
+// from the variable's domain. Variables are visited in name order, so the same
+// seeded source always yields the same inputs. Useful for fuzz and property
+// testing in combination with Infer.
+// If r is nil, the global math/rand source is used.
+func (fis *MamdaniInferenceSystem) RandomInputs(r *rand.Rand) map[string]float64 {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	return fis.randomInputs(r)
+}
+
+// randomInputs is RandomInputs for callers already holding the lock
+func (fis *MamdaniInferenceSystem) randomInputs(r *rand.Rand) map[string]float64 {
+	float := rand.Float64
+	if r != nil {
+		float = r.Float64
+	}
+	inputs := make(map[string]float64, len(fis.InputVariables))
+	for _, name := range sortedKeys(fis.InputVariables) {
+		v := fis.InputVariables[name]
+		inputs[name] = v.MinValue + float()*(v.MaxValue-v.MinValue)
+	}
+	return inputs
+}
+
+// verifySeed seeds the random source used by VerifyOutputBounds so that
+// failures are reproducible between runs.
+const verifySeed = 1
+
+// VerifyOutputBounds runs Infer on samples random inputs (see RandomInputs)
+// and checks that every crisp output lies within its output variable's domain.
+// Samples for which Infer itself returns an error (e.g. no rules fired) are
+// skipped, as they are not bounds violations, except ErrNonFiniteOutput.
+// Returns an error describing the first violation found, or an error if
+// samples <= 0. A NaN or infinite output is reported as a violation.
+func (fis *MamdaniInferenceSystem) VerifyOutputBounds(samples int) error {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	return fis.verifyOutputBounds(samples)
+}
+
+// verifyOutputBounds is VerifyOutputBounds for callers already holding the lock
+func (fis *MamdaniInferenceSystem) verifyOutputBounds(samples int) error {
+	if samples <= 0 {
+		return fmt.Errorf("samples must be > 0, got %d", samples)
+	}
+	r := rand.New(rand.NewSource(verifySeed))
+	for i := 0; i < samples; i++ {
+		inputs := fis.randomInputs(r)
+		outputs, err := fis.infer(inputs)
+		if errors.Is(err, ErrNonFiniteOutput) {
+			return fmt.Errorf("sample %d: %w (inputs: %v)", i+1, err, inputs)
+		}
+		if err != nil {
+			continue
+		}
+		for _, name := range sortedKeys(fis.OutputVariables) {
+			outputVar := fis.OutputVariables[name]
+			value, ok := outputs[name]
+			if !ok {
+				continue
+			}
+			if !(value >= outputVar.MinValue && value <= outputVar.MaxValue) {
+				return fmt.Errorf("sample %d: output %.4f for variable '%s' is out of bounds [%.2f, %.2f] (inputs: %v)",
+					i+1, value, name, outputVar.MinValue, outputVar.MaxValue, inputs)
+			}
+		}
+	}
 	return nil
 }
 
-// AddRule adds a rule to the system.
+// sortedKeys returns the variable names of vars in ascending order
+func sortedKeys(vars map[string]*variable.FuzzyVariable) []string {
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// orderedKeys returns the variable names of vars in the order listed in order,
+// followed by any variables missing from it (e.g. added to the map directly) in
+// ascending order
+func orderedKeys(vars map[string]*variable.FuzzyVariable, order []string) []string {
+	names := make([]string, 0, len(vars))
+	seen := make(map[string]bool, len(vars))
+	for _, name := range order {
+		if _, exists := vars[name]; exists && !seen[name] {
+			names = append(names, name)
+			seen[name] = true
+		}
+	}
+	for _, name := range sortedKeys(vars) {
+		if !seen[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// without returns a copy of names with every occurrence of name removed
+func without(names []string, name string) []string {
+	kept := make([]string, 0, len(names))
+	for _, n := range names {
+		if n != name {
+			kept = append(kept, n)
+		}
+	}
+	return kept
+}
+
+// sortedSetNames returns the set names of v in ascending order
+func sortedSetNames(v *variable.FuzzyVariable) []string {
+	names := make([]string, 0, len(v.Sets))
+	for name := range v.Sets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// AddRule adds a rule to the system and assigns it the next rule ID.
+// IDs increase monotonically and are not reused after removal, so unlike slice
+// indices they remain valid references across edits.
 // Returns error if the rule references non-existent variables or sets, or if the rule has no conditions.
+// A rule without an Operator is given the default AND.
+// In Strict mode, also returns error for OR rules with fewer than two conditions
+// and for rules without an Operator.
 func (fis *MamdaniInferenceSystem) AddRule(r *rule.Rule) error {
-	// Validate rule has at least one condition
-	if len(r.Conditions) == 0 {
-		return fmt.Errorf("rule must have at least one condition")
+	fis.mu.Lock()
+	defer fis.mu.Unlock()
+	return fis.addRule(r)
+}
+
+// addRule is AddRule for callers already holding the write lock
+func (fis *MamdaniInferenceSystem) addRule(r *rule.Rule) error {
+	if err := fis.validateRule(r); err != nil {
+		return err
+	}
+	if err := fis.defaultOperator(r); err != nil {
+		return err
 	}
+	if fis.Strict && isSingleTermOR(r) {
+		return fmt.Errorf("rule uses OR with fewer than two conditions")
+	}
+	fis.appendRule(r)
+	return nil
+}
 
-	// Validate output variable and set exist
-	outputVar, exists := fis.OutputVariables[r.Output.Variable]
+// defaultOperator gives r the default AND operator if it has none, e.g. when it
+// was built as a struct literal. Returns error instead in Strict mode.
+func (fis *MamdaniInferenceSystem) defaultOperator(r *rule.Rule) error {
+	if r.Operator != nil {
+		return nil
+	}
+	if fis.Strict {
+		return fmt.Errorf("rule for output '%s.%s' has no operator", r.Output.Variable, r.Output.Set)
+	}
+	r.Operator = operators.AND
+	return nil
+}
+
+// appendRule assigns r the next rule ID and appends it without validation
+func (fis *MamdaniInferenceSystem) appendRule(r *rule.Rule) {
+	fis.nextRuleID++
+	r.ID = fis.nextRuleID
+	fis.Rules = append(fis.Rules, r)
+	fis.invalidateCaches()
+}
+
+// UpsertRule adds r, or replaces the existing rule with identical antecedents,
+// operators and outputs so that re-running a rule generator does not append
+// duplicates. A replaced rule keeps its position and ID.
+// Returns error under the same conditions as AddRule.
+func (fis *MamdaniInferenceSystem) UpsertRule(r *rule.Rule) error {
+	fis.mu.Lock()
+	defer fis.mu.Unlock()
+	if err := fis.defaultOperator(r); err != nil {
+		return err
+	}
+	for i, existing := range fis.Rules {
+		if !sameRuleShape(existing, r) {
+			continue
+		}
+		if err := fis.validateRule(r); err != nil {
+			return err
+		}
+		r.ID = existing.ID
+		fis.Rules[i] = r
+		fis.invalidateCaches()
+		return nil
+	}
+	return fis.addRule(r)
+}
+
+// sameRuleShape reports whether a and b have identical antecedents, operators and
+// outputs, ignoring their IDs and weights
+func sameRuleShape(a, b *rule.Rule) bool {
+	if a.Operator != b.Operator || a.Complement != b.Complement || a.Inhibitory != b.Inhibitory ||
+		a.ConditionWeighting != b.ConditionWeighting ||
+		!sameConditions(a.Conditions, b.Conditions) ||
+		!sameConditions(a.Outputs(), b.Outputs()) ||
+		len(a.Groups) != len(b.Groups) {
+		return false
+	}
+	if len(a.Groups) > 0 && a.GroupOperator != b.GroupOperator {
+		return false
+	}
+	for i := range a.Groups {
+		if !sameConditions(a.Groups[i].Conditions, b.Groups[i].Conditions) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameConditions reports whether a and b hold the same conditions in the same order
+func sameConditions(a, b []rule.RuleCondition) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// RuleByID returns the rule with the given ID and true, or nil and false if no such rule exists
+func (fis *MamdaniInferenceSystem) RuleByID(id int) (*rule.Rule, bool) {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	for _, r := range fis.Rules {
+		if r.ID == id {
+			return r, true
+		}
+	}
+	return nil, false
+}
+
+// RemoveRuleByID removes the rule with the given ID, preserving the order of the remaining rules.
+// Returns error if no rule has the given ID.
+func (fis *MamdaniInferenceSystem) RemoveRuleByID(id int) error {
+	fis.mu.Lock()
+	defer fis.mu.Unlock()
+	for i, r := range fis.Rules {
+		if r.ID == id {
+			fis.Rules = append(fis.Rules[:i], fis.Rules[i+1:]...)
+			fis.invalidateCaches()
+			return nil
+		}
+	}
+	return fmt.Errorf("rule with ID %d does not exist", id)
+}
+
+// validateRule checks that a rule has conditions and only references existing variables and sets
+func (fis *MamdaniInferenceSystem) validateRule(r *rule.Rule) error {
+	if err := fis.validateConditions(r); err != nil {
+		return err
+	}
+
+	// Validate output variables and sets exist
+	for _, out := range r.Outputs() {
+		outputVar, err := fis.validateOutputVariable(out)
+		if err != nil {
+			return err
+		}
+		if _, exists := outputVar.Sets[out.Set]; !exists {
+			return fmt.Errorf("rule references non-existent output set '%s' in variable '%s'", out.Set, out.Variable)
+		}
+	}
+	return nil
+}
+
+// validateOutputVariable checks that a rule output is not negated and names an
+// existing output variable, which it returns
+func (fis *MamdaniInferenceSystem) validateOutputVariable(out rule.RuleCondition) (*variable.FuzzyVariable, error) {
+	if out.Negated {
+		return nil, fmt.Errorf("rule output '%s.%s' cannot be negated", out.Variable, out.Set)
+	}
+	outputVar, exists := fis.OutputVariables[out.Variable]
 	if !exists {
-		return fmt.Errorf("rule references non-existent output variable '%s'", r.Output.Variable)
+		return nil, fmt.Errorf("rule references non-existent output variable '%s'", out.Variable)
 	}
-	if _, exists := outputVar.Sets[r.Output.Set]; !exists {
-		return fmt.Errorf("rule references non-existent output set '%s' in variable '%s'", r.Output.Set, r.Output.Variable)
+	return outputVar, nil
+}
+
+// validateConditions checks that a rule has conditions and that they only
+// reference existing input variables and sets
+func (fis *MamdaniInferenceSystem) validateConditions(r *rule.Rule) error {
+	// Validate rule has at least one condition
+	conditions := r.AllConditions()
+	if len(conditions) == 0 {
+		return fmt.Errorf("rule must have at least one condition")
+	}
+	if len(r.Conditions) > 0 && len(r.Groups) > 0 {
+		return fmt.Errorf("rule cannot mix plain conditions and condition groups")
 	}
 
 	// Validate all input conditions
-	for i, cond := range r.Conditions {
+	for i, cond := range conditions {
 		inputVar, exists := fis.InputVariables[cond.Variable]
 		if !exists {
 			return fmt.Errorf("rule condition %d references non-existent input variable '%s'", i+1, cond.Variable)
@@ -117,8 +901,6 @@ func (fis *MamdaniInferenceSystem) AddRule(r *rule.Rule) error {
 			return fmt.Errorf("rule condition %d references non-existent input set '%s' in variable '%s'", i+1, cond.Set, cond.Variable)
 		}
 	}
-
-	fis.Rules = append(fis.Rules, r)
 	return nil
 }
 
@@ -131,99 +913,239 @@ func (fis *MamdaniInferenceSystem) AddRule(r *rule.Rule) error {
 //   - Input values are outside variable bounds
 //   - No rules fired (all membership degrees are zero)
 func (fis *MamdaniInferenceSystem) Infer(inputs map[string]float64) (map[string]float64, error) {
-	// Validate system is configured
-	if len(fis.InputVariables) == 0 {
-		return nil, fmt.Errorf("inference system has no input variables")
-	}
-	if len(fis.OutputVariables) == 0 {
-		return nil, fmt.Errorf("inference system has no output variables")
-	}
-	if len(fis.Rules) == 0 {
-		return nil, fmt.Errorf("inference system has no rules")
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	return fis.infer(inputs)
+}
+
+// infer is Infer for callers already holding the lock
+func (fis *MamdaniInferenceSystem) infer(inputs map[string]float64) (map[string]float64, error) {
+	// A per-call workspace keeps the returned map independent of later calls
+	ws := fis.newWorkspace()
+	if fis.logger != nil {
+		return fis.inferLogged(ws, inputs)
 	}
+	return fis.inferWith(ws, inputs)
+}
 
-	// Validate that all required inputs are provided
-	for varName, inputVar := range fis.InputVariables {
-		value, exists := inputs[varName]
-		if !exists {
-			return nil, fmt.Errorf("missing required input variable: %s", varName)
+// InferKV is Infer with the inputs given as alternating name, value arguments,
+// e.g. InferKV("Temperature", 40.0, "Humidity", 65.0). Values may be float64 or int.
+// Returns error if the arguments are not name/value pairs, a name repeats, or for
+// any reason Infer would.
+func (fis *MamdaniInferenceSystem) InferKV(pairs ...any) (map[string]float64, error) {
+	if len(pairs)%2 != 0 {
+		return nil, fmt.Errorf("InferKV requires name/value pairs, got %d arguments", len(pairs))
+	}
+	inputs := make(map[string]float64, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		name, ok := pairs[i].(string)
+		if !ok {
+			return nil, fmt.Errorf("argument %d must be an input name, got %T", i+1, pairs[i])
+		}
+		if _, exists := inputs[name]; exists {
+			return nil, fmt.Errorf("input '%s' given more than once", name)
 		}
-		// Validate bounds
-		if value < inputVar.MinValue || value > inputVar.MaxValue {
-			return nil, fmt.Errorf("input value %.2f for variable '%s' is out of bounds [%.2f, %.2f]",
-				value, varName, inputVar.MinValue, inputVar.MaxValue)
+		switch v := pairs[i+1].(type) {
+		case float64:
+			inputs[name] = v
+		case int:
+			inputs[name] = float64(v)
+		default:
+			return nil, fmt.Errorf("value for input '%s' must be a number, got %T", name, pairs[i+1])
 		}
 	}
+	return fis.Infer(inputs)
+}
 
-	// Step 1: Fuzzification - convert crisp inputs to membership degrees
-	membershipMap := make(map[string]map[string]float64)
-	for varName, crispValue := range inputs {
-		if inputVar, ok := fis.InputVariables[varName]; ok {
-			membershipMap[varName] = inputVar.Fuzzify(crispValue)
-		}
+// InferOutputSupport runs inference and returns the smallest interval [lo, hi]
+// within the output variable's domain outside which the aggregated output
+// membership is zero. The interval is resolved on the sampling grid
+// (fis.Resolution): lo and hi are the last zero-valued samples bracketing the
+// active region, or the domain bounds if the region touches them.
+// ok is false if inference fails, the output variable does not exist, or no
+// output set fired.
+func (fis *MamdaniInferenceSystem) InferOutputSupport(inputs map[string]float64, output string) (lo, hi float64, ok bool) {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	outputVar, exists := fis.OutputVariables[output]
+	if !exists {
+		return 0, 0, false
+	}
+	ws := fis.newWorkspace()
+	if err := fis.fire(ws, inputs); err != nil {
+		return 0, 0, false
 	}
+	outputMemberships := ws.outputMemberships
 
-	// Step 2: Rule evaluation - fire rules and collect outputs
-	outputMemberships := make(map[string]map[string]float64)
-	for outputName := range fis.OutputVariables {
-		outputMemberships[outputName] = make(map[string]float64)
+	opts := fis.defuzzOptions()
+	resolution := opts.resolution
+	if resolution <= 0 {
+		resolution = DefaultResolution
 	}
+	step := (outputVar.MaxValue - outputVar.MinValue) / float64(resolution)
 
-	for _, r := range fis.Rules {
-		firingStrength, err := r.Evaluate(membershipMap)
-		if err != nil {
-			return nil, fmt.Errorf("error evaluating rule: %w", err)
-		}
-		// Each rule contributes to its output set
-		if _, ok := outputMemberships[r.Output.Variable]; ok {
-			// Use MAX aggregation for multiple rules firing to same set
-			if current, exists := outputMemberships[r.Output.Variable][r.Output.Set]; exists {
-				if firingStrength > current {
-					outputMemberships[r.Output.Variable][r.Output.Set] = firingStrength
-				}
-			} else {
-				outputMemberships[r.Output.Variable][r.Output.Set] = firingStrength
+	first, last := -1, -1
+	for i := 0; i <= resolution; i++ {
+		x := outputVar.MinValue + float64(i)*step
+		if aggregatedMembership(outputVar, outputMemberships[output], x, opts) > 0 {
+			if first < 0 {
+				first = i
 			}
+			last = i
 		}
 	}
+	if first < 0 {
+		return 0, 0, false
+	}
 
-	// Step 3: Defuzzification - convert fuzzy outputs to crisp values
-	results := make(map[string]float64)
-	for varName, outputVar := range fis.OutputVariables {
-		var result float64
-		var err error
-		switch fis.DefuzzMethod {
-		case DefuzzCOG:
-			result, err = defuzzifyCOGWithResolution(outputVar, outputMemberships[varName], fis.Resolution)
-		case DefuzzMOM:
-			result, err = defuzzifyMOMWithResolution(outputVar, outputMemberships[varName], fis.Resolution)
-		case DefuzzFOM, DefuzzLOM, DefuzzSOM:
-			result, err = defuzzifyFOMWithResolution(outputVar, outputMemberships[varName], fis.Resolution)
-		default:
-			// Default to MOM if unknown method
-			result, err = defuzzifyMOMWithResolution(outputVar, outputMemberships[varName], fis.Resolution)
-		}
-		if err != nil {
-			return nil, fmt.Errorf("defuzzification failed for variable '%s': %w", varName, err)
-		}
-		results[varName] = result
+	// Widen to the bracketing zero samples so the interval is conservative
+	if first > 0 {
+		first--
+	}
+	if last < resolution {
+		last++
 	}
+	return outputVar.MinValue + float64(first)*step, outputVar.MinValue + float64(last)*step, true
+}
 
-	return results, nil
+// AggregatedMembershipAt runs inference and returns the aggregated output
+// membership of output at the single point x, as sampled by the defuzzifiers:
+// each fired set is shaped by ImplicationMethod, sets are combined by
+// AggregationMethod (MAX by default) and the result is clamped when
+// ClampMembership is set. It returns 0 if no set of output fired.
+// Returns error if the output variable does not exist, x is outside its domain,
+// or inference fails.
+func (fis *MamdaniInferenceSystem) AggregatedMembershipAt(inputs map[string]float64, output string, x float64) (float64, error) {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	outputVar, exists := fis.OutputVariables[output]
+	if !exists {
+		return 0, fmt.Errorf("output variable '%s' does not exist", output)
+	}
+	if !outputVar.IsValid(x) {
+		return 0, fmt.Errorf("x %.2f is outside the domain [%.2f, %.2f] of output variable '%s'",
+			x, outputVar.MinValue, outputVar.MaxValue, output)
+	}
+	ws := fis.newWorkspace()
+	if err := fis.fire(ws, inputs); err != nil {
+		return 0, err
+	}
+	return aggregatedMembership(outputVar, ws.outputMemberships[output], x, fis.defuzzOptions()), nil
+}
+
+// defuzzOptions carries the system settings that shape the aggregated output
+// curve sampled by the defuzzifiers.
+type defuzzOptions struct {
+	resolution  int     // number of sampling intervals across the output domain
+	clamp       bool    // clamp per-point aggregated membership to 1.0
+	implication string  // ImplicationProduct or ImplicationMin
+	aggregation string  // AggregationMax, AggregationSum or AggregationProbOr
+	minMass     float64 // minimum aggregated area for COG
+	fallback    float64 // COG result when the mass is below minMass, if hasFallback
+	hasFallback bool
+	interpolate bool // refine FOM between samples
+	extend      bool // sample past the domain to cover fired sets' supports
+}
+
+// defaultDefuzzOptions returns the options used by a freshly created system
+func defaultDefuzzOptions() defuzzOptions {
+	return defuzzOptions{resolution: DefaultResolution, clamp: true, implication: ImplicationProduct}
+}
+
+// defuzzOptions returns the defuzzification options derived from the system configuration
+func (fis *MamdaniInferenceSystem) defuzzOptions() defuzzOptions {
+	return defuzzOptions{
+		resolution:  fis.Resolution,
+		clamp:       fis.ClampMembership,
+		implication: fis.ImplicationMethod,
+		aggregation: fis.AggregationMethod,
+		minMass:     fis.MinCOGMass,
+		fallback:    fis.cogFallback,
+		hasFallback: fis.hasCOGFallback,
+		interpolate: fis.InterpolateMaxima,
+		extend:      fis.ExtendOutputSupport,
+	}
+}
+
+// applyImplication shapes an output set's membership degree by a rule firing strength
+// using the configured implication method. Every defuzzifier samples output sets through
+// this function so that the methods cannot diverge. Unknown methods use product.
+func (opts defuzzOptions) applyImplication(setValue, strength float64) float64 {
+	if opts.implication == ImplicationMin {
+		return math.Min(setValue, strength)
+	}
+	return setValue * strength
+}
+
+// aggregate combines the shaped degree of one more output set into the running
+// aggregate using the configured aggregation method. Unknown methods use MAX.
+func (opts defuzzOptions) aggregate(acc, degree float64) float64 {
+	switch opts.aggregation {
+	case AggregationSum:
+		return acc + degree
+	case AggregationProbOr:
+		return acc + degree - acc*degree
+	}
+	return math.Max(acc, degree)
+}
+
+// aggregatedMembership returns the aggregated membership of the fired output sets at x,
+// with each set shaped by its firing strength via applyImplication and combined via
+// aggregate. If opts.clamp is set, the result is limited to 1.0 so that strengths above 1
+// (or a sum of overlapping sets) cannot distort the maximum or the weighting.
+// Returns NaN if any set evaluates to NaN at x.
+func aggregatedMembership(outputVar *variable.FuzzyVariable, memberships map[string]float64, x float64, opts defuzzOptions) float64 {
+	membership := 0.0
+	for setName, strength := range memberships {
+		if outputSet, ok := outputVar.Sets[setName]; ok {
+			degree := opts.applyImplication(outputSet.Evaluate(x), strength)
+			if math.IsNaN(degree) {
+				// Surface broken membership functions instead of skipping the point
+				return degree
+			}
+			membership = opts.aggregate(membership, degree)
+		}
+	}
+	if opts.clamp && membership > 1 {
+		membership = 1
+	}
+	return membership
 }
 
 // defuzzifyCOG uses Center of Gravity method for defuzzification
-// defuzzifyCOG is a wrapper that calls the resolution-aware implementation
+// defuzzifyCOG is a wrapper that calls the options-aware implementation
 func defuzzifyCOG(outputVar *variable.FuzzyVariable, memberships map[string]float64) (float64, error) {
-	return defuzzifyCOGWithResolution(outputVar, memberships, DefaultResolution)
+	return defuzzifyCOGWithOptions(outputVar, memberships, defaultDefuzzOptions())
 }
 
-func defuzzifyCOGWithResolution(outputVar *variable.FuzzyVariable, memberships map[string]float64, resolution int) (float64, error) {
+// defuzzifyCOGWithOptions computes the centroid in closed form when analyticCOG
+// supports the fired sets, and by sampling the aggregated curve otherwise
+func defuzzifyCOGWithOptions(outputVar *variable.FuzzyVariable, memberships map[string]float64, opts defuzzOptions) (float64, error) {
 	if len(memberships) == 0 {
-		return 0, fmt.Errorf("no rules fired: all membership degrees are zero")
+		return 0, ErrNoRulesFired
 	}
 
+	centroid, area, ok := analyticCOG(outputVar, memberships, opts)
+	if !ok {
+		centroid, area = sampledCOG(outputVar, memberships, opts)
+	}
+
+	// A near-empty curve gives an unstable centroid
+	if area == 0 || area < opts.minMass {
+		if opts.hasFallback && opts.minMass > 0 {
+			return opts.fallback, nil
+		}
+		return 0, ErrNoRulesFired
+	}
+
+	return centroid, nil
+}
+
+// sampledCOG estimates the centroid and area of the aggregated output curve from
+// its values at opts.resolution+1 evenly spaced points
+func sampledCOG(outputVar *variable.FuzzyVariable, memberships map[string]float64, opts defuzzOptions) (centroid, area float64) {
 	// Validate resolution
+	resolution := opts.resolution
 	if resolution <= 0 {
 		resolution = DefaultResolution
 	}
@@ -237,129 +1159,312 @@ func defuzzifyCOGWithResolution(outputVar *variable.FuzzyVariable, memberships m
 	for i := 0; i <= resolution; i++ {
 		x := outputVar.MinValue + float64(i)*step
 
-		// Get maximum membership degree at this point across all sets
-		maxMembership := 0.0
-		for setName, strength := range memberships {
-			if outputSet, ok := outputVar.Sets[setName]; ok {
-				degree := outputSet.Evaluate(x) * strength
-				if degree > maxMembership {
-					maxMembership = degree
-				}
-			}
-		}
+		// Get aggregated membership degree at this point across all sets
+		membership := aggregatedMembership(outputVar, memberships, x, opts)
 
-		numerator += x * maxMembership
-		denominator += maxMembership
+		numerator += x * membership
+		denominator += membership
 	}
 
 	if denominator == 0 {
-		return 0, fmt.Errorf("no rules fired: all membership degrees are zero")
+		return 0, 0
 	}
-
-	return numerator / denominator, nil
+	// denominator*step approximates the area
+	return numerator / denominator, denominator * step
 }
 
 // DefuzzifyMOM uses Mean of Maximum method
-// defuzzifyMOM is a wrapper that calls the resolution-aware implementation
+// defuzzifyMOM is a wrapper that calls the options-aware implementation
 func defuzzifyMOM(outputVar *variable.FuzzyVariable, memberships map[string]float64) (float64, error) {
-	return defuzzifyMOMWithResolution(outputVar, memberships, DefaultResolution)
+	return defuzzifyMOMWithOptions(outputVar, memberships, defaultDefuzzOptions())
 }
 
-func defuzzifyMOMWithResolution(outputVar *variable.FuzzyVariable, memberships map[string]float64, resolution int) (float64, error) {
+func defuzzifyMOMWithOptions(outputVar *variable.FuzzyVariable, memberships map[string]float64, opts defuzzOptions) (float64, error) {
 	if len(memberships) == 0 {
-		return 0, fmt.Errorf("no rules fired: all membership degrees are zero")
+		return 0, ErrNoRulesFired
 	}
 
 	// Validate resolution
+	resolution := opts.resolution
 	if resolution <= 0 {
 		resolution = DefaultResolution
 	}
 
 	maxMembership := 0.0
-	var points []float64
+	// Running sum and count of the maximum points, so no slice is allocated
+	sum := 0.0
+	count := 0
 
 	step := (outputVar.MaxValue - outputVar.MinValue) / float64(resolution)
 
 	for i := 0; i <= resolution; i++ {
 		x := outputVar.MinValue + float64(i)*step
 
-		currentMax := 0.0
-		for setName, strength := range memberships {
-			if outputSet, ok := outputVar.Sets[setName]; ok {
-				degree := outputSet.Evaluate(x) * strength
-				if degree > currentMax {
-					currentMax = degree
-				}
-			}
+		currentMax := aggregatedMembership(outputVar, memberships, x, opts)
+		if math.IsNaN(currentMax) {
+			return currentMax, nil
 		}
 
 		if i == 0 || currentMax > maxMembership {
 			maxMembership = currentMax
-			points = []float64{x}
+			sum, count = x, 1
 		} else if math.Abs(currentMax-maxMembership) < epsilon {
-			points = append(points, x)
+			sum += x
+			count++
 		}
 	}
 
-	if len(points) == 0 || maxMembership == 0 {
-		return 0, fmt.Errorf("no rules fired: all membership degrees are zero")
+	if count == 0 || maxMembership == 0 {
+		return 0, ErrNoRulesFired
 	}
 
 	// Return average of maximum points
-	sum := 0.0
-	for _, p := range points {
-		sum += p
-	}
-	return sum / float64(len(points)), nil
+	return sum / float64(count), nil
 }
 
 // DefuzzifyFOM uses First of Maximum method
-// defuzzifyFOM is a wrapper that calls the resolution-aware implementation
+// defuzzifyFOM is a wrapper that calls the options-aware implementation
 func defuzzifyFOM(outputVar *variable.FuzzyVariable, memberships map[string]float64) (float64, error) {
-	return defuzzifyFOMWithResolution(outputVar, memberships, DefaultResolution)
+	return defuzzifyFOMWithOptions(outputVar, memberships, defaultDefuzzOptions())
 }
 
-func defuzzifyFOMWithResolution(outputVar *variable.FuzzyVariable, memberships map[string]float64, resolution int) (float64, error) {
+func defuzzifyFOMWithOptions(outputVar *variable.FuzzyVariable, memberships map[string]float64, opts defuzzOptions) (float64, error) {
 	if len(memberships) == 0 {
-		return 0, fmt.Errorf("no rules fired: all membership degrees are zero")
+		return 0, ErrNoRulesFired
 	}
 
 	// Validate resolution
+	resolution := opts.resolution
 	if resolution <= 0 {
 		resolution = DefaultResolution
 	}
 
 	maxMembership := 0.0
 	result := outputVar.MinValue
+	first := 0
 
 	step := (outputVar.MaxValue - outputVar.MinValue) / float64(resolution)
 
 	for i := 0; i <= resolution; i++ {
 		x := outputVar.MinValue + float64(i)*step
 
-		currentMax := 0.0
-		for setName, strength := range memberships {
-			if outputSet, ok := outputVar.Sets[setName]; ok {
-				degree := outputSet.Evaluate(x) * strength
-				if degree > currentMax {
-					currentMax = degree
-				}
-			}
+		currentMax := aggregatedMembership(outputVar, memberships, x, opts)
+		if math.IsNaN(currentMax) {
+			return currentMax, nil
 		}
 
 		if currentMax > maxMembership {
 			maxMembership = currentMax
 			result = x
+			first = i
 		}
 	}
 
 	if maxMembership == 0 {
-		return 0, fmt.Errorf("no rules fired: all membership degrees are zero")
+		return 0, ErrNoRulesFired
 	}
 
+	if opts.interpolate && first > 0 && first < resolution {
+		return refineFirstMaximum(outputVar, memberships, opts, result, step, maxMembership), nil
+	}
 	return result, nil
 }
 
+// defuzzifySOMWithOptions returns the smallest x at which the aggregated curve reaches
+// its maximum. Unlike FOM, which moves to any later sample that is higher at all,
+// samples within epsilon of the maximum count as ties like MOM, so a numerically
+// flat plateau reports its left edge.
+func defuzzifySOMWithOptions(outputVar *variable.FuzzyVariable, memberships map[string]float64, opts defuzzOptions) (float64, error) {
+	if len(memberships) == 0 {
+		return 0, ErrNoRulesFired
+	}
+
+	// Validate resolution
+	resolution := opts.resolution
+	if resolution <= 0 {
+		resolution = DefaultResolution
+	}
+
+	maxMembership := 0.0
+	result := outputVar.MinValue
+	smallest := 0
+
+	step := (outputVar.MaxValue - outputVar.MinValue) / float64(resolution)
+
+	for i := 0; i <= resolution; i++ {
+		x := outputVar.MinValue + float64(i)*step
+
+		currentMax := aggregatedMembership(outputVar, memberships, x, opts)
+		if math.IsNaN(currentMax) {
+			return currentMax, nil
+		}
+
+		if currentMax >= maxMembership+epsilon {
+			result = x
+			smallest = i
+		}
+		maxMembership = math.Max(maxMembership, currentMax)
+	}
+
+	if maxMembership == 0 {
+		return 0, ErrNoRulesFired
+	}
+
+	if opts.interpolate && smallest > 0 && smallest < resolution {
+		return refineFirstMaximum(outputVar, memberships, opts, result, step, maxMembership), nil
+	}
+	return result, nil
+}
+
+// defuzzifyLOMWithOptions returns the largest x at which the aggregated curve reaches
+// its maximum, treating samples within epsilon of the maximum as ties like MOM.
+func defuzzifyLOMWithOptions(outputVar *variable.FuzzyVariable, memberships map[string]float64, opts defuzzOptions) (float64, error) {
+	if len(memberships) == 0 {
+		return 0, ErrNoRulesFired
+	}
+
+	// Validate resolution
+	resolution := opts.resolution
+	if resolution <= 0 {
+		resolution = DefaultResolution
+	}
+
+	maxMembership := 0.0
+	result := outputVar.MinValue
+	last := 0
+
+	step := (outputVar.MaxValue - outputVar.MinValue) / float64(resolution)
+
+	for i := 0; i <= resolution; i++ {
+		x := outputVar.MinValue + float64(i)*step
+
+		currentMax := aggregatedMembership(outputVar, memberships, x, opts)
+		if math.IsNaN(currentMax) {
+			return currentMax, nil
+		}
+
+		if currentMax > maxMembership || (maxMembership > 0 && math.Abs(currentMax-maxMembership) < epsilon) {
+			maxMembership = math.Max(maxMembership, currentMax)
+			result = x
+			last = i
+		}
+	}
+
+	if maxMembership == 0 {
+		return 0, ErrNoRulesFired
+	}
+
+	if opts.interpolate && last > 0 && last < resolution {
+		// Scanning from the right, the last maximum is the first one
+		return refineFirstMaximum(outputVar, memberships, opts, result, -step, maxMembership), nil
+	}
+	return result, nil
+}
+
+// refineFirstMaximum refines the first grid maximum x (with degree peak) of the
+// aggregated curve to a location between samples. A peak, whose next-but-one
+// sample is lower, is located at the vertex of the parabola through its
+// neighbours. A plateau starts somewhere after the previous sample, so the
+// rising edge is extended linearly until it reaches the plateau level.
+// Plateaus narrower than three samples cannot be told apart from a peak.
+// A negative step scans from the right, refining the last maximum instead.
+// The result stays within one step of x.
+func refineFirstMaximum(outputVar *variable.FuzzyVariable, memberships map[string]float64, opts defuzzOptions, x, step, peak float64) float64 {
+	at := func(x float64) float64 {
+		if x < outputVar.MinValue || x > outputVar.MaxValue {
+			return 0
+		}
+		return aggregatedMembership(outputVar, memberships, x, opts)
+	}
+	prev, next := at(x-step), at(x+step)
+
+	if next < peak || at(x+2*step) < peak {
+		// Isolated peak: vertex of the parabola through (x-step, x, x+step)
+		curvature := prev - 2*peak + next
+		if curvature >= 0 {
+			return x
+		}
+		offset := 0.5 * (prev - next) / curvature
+		return x + math.Max(-1, math.Min(1, offset))*step
+	}
+
+	// Plateau: extend the edge through x-2*step and x-step up to the plateau level
+	slope := prev - at(x-2*step)
+	if slope <= 0 {
+		return x
+	}
+	return x - step + math.Min(1, (peak-prev)/slope)*step
+}
+
+// defuzzifyBisectorWithOptions returns the x that splits the area under the aggregated
+// curve into two equal halves. The curve is the same one COG samples (including
+// clamping); its area is integrated with the trapezoidal rule and the splitting
+// point is solved exactly within the crossing interval, treating the curve as
+// linear between samples, rather than snapping to a grid point.
+func defuzzifyBisectorWithOptions(outputVar *variable.FuzzyVariable, memberships map[string]float64, opts defuzzOptions) (float64, error) {
+	if len(memberships) == 0 {
+		return 0, ErrNoRulesFired
+	}
+
+	// Validate resolution
+	resolution := opts.resolution
+	if resolution <= 0 {
+		resolution = DefaultResolution
+	}
+
+	step := (outputVar.MaxValue - outputVar.MinValue) / float64(resolution)
+
+	// First pass: total area
+	total := 0.0
+	prev := aggregatedMembership(outputVar, memberships, outputVar.MinValue, opts)
+	for i := 1; i <= resolution; i++ {
+		y := aggregatedMembership(outputVar, memberships, outputVar.MinValue+float64(i)*step, opts)
+		total += (prev + y) * step / 2
+		prev = y
+	}
+	if math.IsNaN(total) {
+		return total, nil
+	}
+	if total <= 0 {
+		return 0, ErrNoRulesFired
+	}
+
+	// Second pass: find the interval where the cumulative area crosses half
+	half := total / 2
+	cumulative := 0.0
+	prev = aggregatedMembership(outputVar, memberships, outputVar.MinValue, opts)
+	for i := 1; i <= resolution; i++ {
+		x0 := outputVar.MinValue + float64(i-1)*step
+		y := aggregatedMembership(outputVar, memberships, x0+step, opts)
+		segment := (prev + y) * step / 2
+		if cumulative+segment >= half {
+			return x0 + linearAreaOffset(prev, y, step, half-cumulative), nil
+		}
+		cumulative += segment
+		prev = y
+	}
+	return outputVar.MaxValue, nil
+}
+
+// linearAreaOffset returns t in [0, width] such that the area under the line from
+// (0, y0) to (width, y1) over [0, t] equals target: y0*t + (y1-y0)*t²/(2*width) = target.
+func linearAreaOffset(y0, y1, width, target float64) float64 {
+	slope := (y1 - y0) / width
+	if math.Abs(slope) < epsilon {
+		if y0 <= 0 {
+			return 0
+		}
+		return math.Min(target/y0, width)
+	}
+	// Solve slope/2*t² + y0*t - target = 0 for the root in [0, width]
+	disc := y0*y0 + 2*slope*target
+	if disc < 0 {
+		disc = 0
+	}
+	t := (-y0 + math.Sqrt(disc)) / slope
+	return math.Max(0, math.Min(t, width))
+}
+
 // RuleBuilder is a helper for building rules with fluent API
 type RuleBuilder struct {
 	output rule.RuleCondition
@@ -445,6 +1550,17 @@ func (rb *RuleBuilder) Or() *RuleBuilder {
 	return rb
 }
 
+// Operator specifies the rule operator by its registered name ("min", "max", "prod", "probor", ...; see operators.ByName).
+// Returns error if no operator is registered under name.
+func (rb *RuleBuilder) Operator(name string) (*RuleBuilder, error) {
+	op, err := operators.ByName(name)
+	if err != nil {
+		return nil, err
+	}
+	rb.op = op
+	return rb, nil
+}
+
 // Weight specifies rule weight (0-1). More natural than With() for weight setting.
 // Weight must be in range [0, 1].
 func (rb *RuleBuilder) Weight(weight float64) (*RuleBuilder, error) {
@@ -1,12 +1,17 @@
 package inference
 
 import (
+	"errors"
 	"github.com/loian/fuzzylib/membership"
 	"github.com/loian/fuzzylib/operators"
 	"github.com/loian/fuzzylib/rule"
 	"github.com/loian/fuzzylib/set"
 	"github.com/loian/fuzzylib/variable"
 	"math"
+	"math/rand"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -22,6 +27,43 @@ func mustMF(mf membership.MembershipFunction, err error) membership.MembershipFu
 	return mf
 }
 
+// newTempFanSystem builds a single-input system (Temperature [0,50] -> FanSpeed [0,100])
+// with Cold/Warm/Hot rules mapping to Low/Medium/High. Hot peaks at the upper
+// domain bound so that Temperature=50 fires it fully.
+func newTempFanSystem(tb testing.TB) *MamdaniInferenceSystem {
+	tb.Helper()
+	fis := NewMamdaniInferenceSystem()
+
+	tempVar, _ := variable.NewFuzzyVariable("Temperature", 0, 50)
+	tempVar.AddSet(set.NewFuzzySet("Cold", mustMF(membership.NewTriangular(0, 0, 20))))
+	tempVar.AddSet(set.NewFuzzySet("Warm", mustMF(membership.NewTriangular(10, 25, 40))))
+	tempVar.AddSet(set.NewFuzzySet("Hot", mustMF(membership.NewTriangular(30, 50, 70))))
+
+	fanVar, _ := variable.NewFuzzyVariable("FanSpeed", 0, 100)
+	fanVar.AddSet(set.NewFuzzySet("Low", mustMF(membership.NewTriangular(0, 0, 33))))
+	fanVar.AddSet(set.NewFuzzySet("Medium", mustMF(membership.NewTriangular(20, 50, 80))))
+	fanVar.AddSet(set.NewFuzzySet("High", mustMF(membership.NewTriangular(67, 100, 100))))
+
+	if err := fis.AddInputVariable(tempVar); err != nil {
+		tb.Fatalf("AddInputVariable failed: %v", err)
+	}
+	if err := fis.AddOutputVariable(fanVar); err != nil {
+		tb.Fatalf("AddOutputVariable failed: %v", err)
+	}
+
+	for _, pair := range [][2]string{{"Cold", "Low"}, {"Warm", "Medium"}, {"Hot", "High"}} {
+		r, _ := NewRuleBuilder("FanSpeed", pair[1])
+		built, err := r.If("Temperature", pair[0]).Build()
+		if err != nil {
+			tb.Fatalf("Build failed: %v", err)
+		}
+		if err := fis.AddRule(built); err != nil {
+			tb.Fatalf("AddRule failed: %v", err)
+		}
+	}
+	return fis
+}
+
 func TestNewMamdaniInferenceSystem(t *testing.T) {
 	fis := NewMamdaniInferenceSystem()
 
@@ -64,6 +106,60 @@ func TestMamdaniInferenceSystem_AddVariables(t *testing.T) {
 	}
 }
 
+func TestMamdaniInferenceSystem_RemoveVariables(t *testing.T) {
+	fis := newTempFanSystem(t)
+	humidity, _ := variable.NewFuzzyVariable("Humidity", 0, 100)
+	noise, _ := variable.NewFuzzyVariable("Noise", 0, 10)
+	_ = fis.AddInputVariable(humidity)
+	_ = fis.AddOutputVariable(noise)
+	_ = fis.SetInputDefault("Humidity", 50)
+
+	// Unreferenced variables are removed with their per-variable settings
+	if err := fis.RemoveInputVariable("Humidity"); err != nil {
+		t.Fatalf("RemoveInputVariable failed: %v", err)
+	}
+	if err := fis.RemoveOutputVariable("Noise"); err != nil {
+		t.Fatalf("RemoveOutputVariable failed: %v", err)
+	}
+	if _, ok := fis.InputVariables["Humidity"]; ok {
+		t.Error("Humidity variable not removed")
+	}
+	if _, ok := fis.InputDefaults["Humidity"]; ok {
+		t.Error("Humidity default not removed")
+	}
+	if _, ok := fis.OutputVariables["Noise"]; ok {
+		t.Error("Noise variable not removed")
+	}
+	if _, err := fis.Infer(map[string]float64{"Temperature": 25}); err != nil {
+		t.Errorf("Infer after removal failed: %v", err)
+	}
+
+	// Variables used by rules are kept
+	tests := []struct {
+		name   string
+		remove func(string) error
+		arg    string
+	}{
+		{"referenced input", fis.RemoveInputVariable, "Temperature"},
+		{"referenced output", fis.RemoveOutputVariable, "FanSpeed"},
+		{"unknown input", fis.RemoveInputVariable, "Humidity"},
+		{"unknown output", fis.RemoveOutputVariable, "Noise"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.remove(tt.arg); err == nil {
+				t.Errorf("Expected error removing '%s', got nil", tt.arg)
+			}
+		})
+	}
+	if _, ok := fis.InputVariables["Temperature"]; !ok {
+		t.Error("Referenced Temperature variable was removed")
+	}
+	if _, ok := fis.OutputVariables["FanSpeed"]; !ok {
+		t.Error("Referenced FanSpeed variable was removed")
+	}
+}
+
 func TestMamdaniInferenceSystem_SimpleInference(t *testing.T) {
 	fis := NewMamdaniInferenceSystem()
 
@@ -270,6 +366,28 @@ func TestRuleBuilder(t *testing.T) {
 	}
 }
 
+func TestRuleBuilder_OperatorByName(t *testing.T) {
+	builder, err := NewRuleBuilder("FanSpeed", "High")
+	if err != nil {
+		t.Fatalf("NewRuleBuilder failed: %v", err)
+	}
+	builder, err = builder.If("Temperature", "Hot").Operator("prod")
+	if err != nil {
+		t.Fatalf("Operator failed: %v", err)
+	}
+	r, err := builder.If("Humidity", "Wet").Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if r.Operator != operators.PROD {
+		t.Errorf("Expected PROD operator, got %T", r.Operator)
+	}
+
+	if _, err := builder.Operator("unknown"); !errors.Is(err, operators.ErrUnknownOperator) {
+		t.Errorf("Expected ErrUnknownOperator, got %v", err)
+	}
+}
+
 func TestTemperatureControlSystem(t *testing.T) {
 	// Create a complete temperature control system
 	fis := NewMamdaniInferenceSystem()
@@ -641,7 +759,7 @@ func TestSetDefuzzificationMethod_Validation(t *testing.T) {
 	fis := NewMamdaniInferenceSystem()
 
 	// Test valid methods
-	validMethods := []string{DefuzzCOG, DefuzzMOM, DefuzzFOM, DefuzzLOM, DefuzzSOM}
+	validMethods := []string{DefuzzCOG, DefuzzMOM, DefuzzFOM, DefuzzLOM, DefuzzSOM, DefuzzBIS}
 	for _, method := range validMethods {
 		err := fis.SetDefuzzificationMethod(method)
 		if err != nil {
@@ -658,3 +776,1242 @@ func TestSetDefuzzificationMethod_Validation(t *testing.T) {
 		t.Error("Expected error for invalid method, got nil")
 	}
 }
+
+func TestSetImplicationMethod(t *testing.T) {
+	fis := newTempFanSystem(t)
+	if err := fis.SetDefuzzificationMethod(DefuzzCOG); err != nil {
+		t.Fatalf("SetDefuzzificationMethod failed: %v", err)
+	}
+	inputs := map[string]float64{"Temperature": 5} // only Cold fires, at 0.75
+
+	centroids := make(map[string]float64)
+	for _, method := range []string{ImplicationProduct, ImplicationMin} {
+		if err := fis.SetImplicationMethod(method); err != nil {
+			t.Fatalf("SetImplicationMethod(%s) failed: %v", method, err)
+		}
+		results, err := fis.Infer(inputs)
+		if err != nil {
+			t.Fatalf("Infer with %s implication failed: %v", method, err)
+		}
+		centroids[method] = results["FanSpeed"]
+	}
+
+	// Scaling keeps the Low triangle [0 0 33] in shape (centroid 11), while clipping
+	// flattens its peak and moves the centroid right
+	if math.Abs(centroids[ImplicationProduct]-11) > 0.2 {
+		t.Errorf("Expected scaled centroid near 11, got %f", centroids[ImplicationProduct])
+	}
+	if centroids[ImplicationMin] <= centroids[ImplicationProduct]+0.5 {
+		t.Errorf("Expected clipped centroid %f right of scaled centroid %f", centroids[ImplicationMin], centroids[ImplicationProduct])
+	}
+
+	if err := fis.SetImplicationMethod("max"); err == nil {
+		t.Error("Expected error for invalid implication method, got nil")
+	}
+	if fis.ImplicationMethod != ImplicationMin {
+		t.Errorf("Expected invalid method to leave %s, got %s", ImplicationMin, fis.ImplicationMethod)
+	}
+}
+
+func TestSetAggregationMethod(t *testing.T) {
+	fis := newTempFanSystem(t)
+	if err := fis.SetDefuzzificationMethod(DefuzzCOG); err != nil {
+		t.Fatalf("SetDefuzzificationMethod failed: %v", err)
+	}
+	// Medium [20 50 80] and High [67 100 100] both fire and overlap on [67, 80],
+	// right of the MAX-aggregated centroid
+	inputs := map[string]float64{"Temperature": 35}
+
+	centroids := make(map[string]float64)
+	for _, method := range []string{AggregationMax, AggregationSum, AggregationProbOr} {
+		if err := fis.SetAggregationMethod(method); err != nil {
+			t.Fatalf("SetAggregationMethod(%s) failed: %v", method, err)
+		}
+		if got := fis.AggMethodName(); got != method {
+			t.Errorf("AggMethodName() = %q, expected %q", got, method)
+		}
+		results, err := fis.Infer(inputs)
+		if err != nil {
+			t.Fatalf("Infer with %s aggregation failed: %v", method, err)
+		}
+		centroids[method] = results["FanSpeed"]
+	}
+
+	if centroids[AggregationSum] <= centroids[AggregationMax] {
+		t.Errorf("Expected sum centroid %f above max centroid %f", centroids[AggregationSum], centroids[AggregationMax])
+	}
+	// probor lies between max and sum wherever sets overlap
+	if centroids[AggregationProbOr] < centroids[AggregationMax] || centroids[AggregationProbOr] > centroids[AggregationSum] {
+		t.Errorf("Expected probor centroid %f between max %f and sum %f",
+			centroids[AggregationProbOr], centroids[AggregationMax], centroids[AggregationSum])
+	}
+
+	if err := fis.SetAggregationMethod("min"); err == nil {
+		t.Error("Expected error for invalid aggregation method, got nil")
+	}
+	if fis.AggregationMethod != AggregationProbOr {
+		t.Errorf("Expected invalid method to leave %s, got %s", AggregationProbOr, fis.AggregationMethod)
+	}
+}
+
+func TestAggregate_ClampsSum(t *testing.T) {
+	outVar, _ := variable.NewFuzzyVariable("Out", 0, 100)
+	outVar.AddSet(set.NewFuzzySet("A", mustMF(membership.NewTriangular(0, 50, 100))))
+	outVar.AddSet(set.NewFuzzySet("B", mustMF(membership.NewTriangular(0, 50, 100))))
+	memberships := map[string]float64{"A": 0.8, "B": 0.7}
+
+	opts := defaultDefuzzOptions()
+	opts.aggregation = AggregationSum
+	if got := aggregatedMembership(outVar, memberships, 50, opts); got != 1 {
+		t.Errorf("Expected clamped sum 1.0, got %f", got)
+	}
+	opts.clamp = false
+	if got := aggregatedMembership(outVar, memberships, 50, opts); !floatEqual(got, 1.5) {
+		t.Errorf("Expected unclamped sum 1.5, got %f", got)
+	}
+	opts.aggregation = AggregationProbOr
+	if got := aggregatedMembership(outVar, memberships, 50, opts); !floatEqual(got, 0.94) {
+		t.Errorf("Expected probor 0.94, got %f", got)
+	}
+}
+
+func TestSetAndOrOperator(t *testing.T) {
+	fis := newTempFanSystem(t)
+	both, _ := rule.NewRule(rule.RuleCondition{Variable: "FanSpeed", Set: "Medium"}, operators.AND)
+	_ = both.AddCondition("Temperature", "Warm")
+	_ = both.AddCondition("Temperature", "Hot")
+	either, _ := rule.NewRule(rule.RuleCondition{Variable: "FanSpeed", Set: "High"}, operators.OR)
+	_ = either.AddCondition("Temperature", "Warm")
+	_ = either.AddCondition("Temperature", "Hot")
+	_ = fis.AddRule(both)
+	_ = fis.AddRule(either)
+
+	// Warm 1/3 and Hot 1/4 at 35
+	inputs := map[string]float64{"Temperature": 35}
+	warm, hot := 1.0/3, 0.25
+	strengths := func() []float64 {
+		t.Helper()
+		ws := fis.NewWorkspace()
+		if err := fis.fire(ws, inputs); err != nil {
+			t.Fatalf("fire failed: %v", err)
+		}
+		return ws.ruleStrengths[3:]
+	}
+
+	got := strengths()
+	if !floatEqual(got[0], hot) || !floatEqual(got[1], warm) {
+		t.Errorf("Expected MIN/MAX strengths %f and %f, got %v", hot, warm, got)
+	}
+
+	if err := fis.SetAndOperator(operators.PROD); err != nil {
+		t.Fatalf("SetAndOperator failed: %v", err)
+	}
+	if err := fis.SetOrOperator(operators.PROBOR); err != nil {
+		t.Fatalf("SetOrOperator failed: %v", err)
+	}
+	got = strengths()
+	if !floatEqual(got[0], warm*hot) || !floatEqual(got[1], warm+hot-warm*hot) {
+		t.Errorf("Expected PROD/PROBOR strengths %f and %f, got %v", warm*hot, warm+hot-warm*hot, got)
+	}
+	if fis.AndMethodName() != "prod" || fis.OrMethodName() != "probor" {
+		t.Errorf("Expected method names prod/probor, got %s/%s", fis.AndMethodName(), fis.OrMethodName())
+	}
+
+	// Rules with operators other than MIN/MAX are not overridden
+	lukRule, _ := rule.NewRule(rule.RuleCondition{Variable: "FanSpeed", Set: "Low"}, operators.LUKAND)
+	_ = lukRule.AddCondition("Temperature", "Warm")
+	_ = lukRule.AddCondition("Temperature", "Hot")
+	_ = fis.AddRule(lukRule)
+	if got := strengths(); got[2] != 0 {
+		t.Errorf("Expected Lukasiewicz AND to stay 0, got %f", got[2])
+	}
+
+	// nil removes the override
+	if err := fis.SetAndOperator(nil); err != nil {
+		t.Fatalf("SetAndOperator(nil) failed: %v", err)
+	}
+	if got := strengths(); !floatEqual(got[0], hot) {
+		t.Errorf("Expected MIN strength %f after removing the override, got %f", hot, got[0])
+	}
+	if fis.AndMethodName() != "min" {
+		t.Errorf("Expected AndMethodName min after removing the override, got %s", fis.AndMethodName())
+	}
+
+	// Parameterized operators need not be registered
+	if err := fis.SetAndOperator(&operators.HamacherAndOperator{Gamma: 2}); err != nil {
+		t.Fatalf("SetAndOperator(Hamacher) failed: %v", err)
+	}
+	if fis.AndMethodName() != "hamacher_and:2" {
+		t.Errorf("Expected AndMethodName hamacher_and:2, got %s", fis.AndMethodName())
+	}
+
+	type unregistered struct{ operators.MinOperator }
+	if err := fis.SetAndOperator(&unregistered{}); !errors.Is(err, operators.ErrUnknownOperator) {
+		t.Errorf("Expected ErrUnknownOperator for an unregistered operator, got %v", err)
+	}
+}
+
+func TestInferKV(t *testing.T) {
+	fis := newTempFanSystem(t)
+
+	expected, err := fis.Infer(map[string]float64{"Temperature": 40})
+	if err != nil {
+		t.Fatalf("Infer failed: %v", err)
+	}
+	for _, value := range []any{40.0, 40} {
+		got, err := fis.InferKV("Temperature", value)
+		if err != nil {
+			t.Fatalf("InferKV(%T) failed: %v", value, err)
+		}
+		if !floatEqual(got["FanSpeed"], expected["FanSpeed"]) {
+			t.Errorf("InferKV(%T) = %f, expected %f", value, got["FanSpeed"], expected["FanSpeed"])
+		}
+	}
+
+	malformed := map[string][]any{
+		"odd argument count": {"Temperature"},
+		"non-string name":    {40.0, "Temperature"},
+		"non-numeric value":  {"Temperature", "hot"},
+		"repeated name":      {"Temperature", 40.0, "Temperature", 30.0},
+	}
+	for name, pairs := range malformed {
+		if _, err := fis.InferKV(pairs...); err == nil {
+			t.Errorf("%s: expected error, got nil", name)
+		}
+	}
+	if _, err := fis.InferKV(); !errors.Is(err, ErrMissingInput) {
+		t.Errorf("Expected ErrMissingInput for no arguments, got %v", err)
+	}
+}
+
+func TestSetInputGain(t *testing.T) {
+	fis := newTempFanSystem(t)
+
+	expected, err := fis.Infer(map[string]float64{"Temperature": 50})
+	if err != nil {
+		t.Fatalf("Infer failed: %v", err)
+	}
+
+	if err := fis.SetInputGain("Temperature", 2); err != nil {
+		t.Fatalf("SetInputGain failed: %v", err)
+	}
+	got, err := fis.Infer(map[string]float64{"Temperature": 25})
+	if err != nil {
+		t.Fatalf("Infer with gain failed: %v", err)
+	}
+	if !floatEqual(got["FanSpeed"], expected["FanSpeed"]) {
+		t.Errorf("Expected gain 2 on 25 to behave like 50 (%f), got %f", expected["FanSpeed"], got["FanSpeed"])
+	}
+
+	// Scaled value is bounds-checked: 30 * 2 = 60 is outside [0, 50]
+	if _, err := fis.Infer(map[string]float64{"Temperature": 30}); err == nil {
+		t.Error("Expected out-of-bounds error for scaled input, got nil")
+	}
+}
+
+func TestSetInputGain_Validation(t *testing.T) {
+	fis := newTempFanSystem(t)
+
+	if err := fis.SetInputGain("Unknown", 2); err == nil {
+		t.Error("Expected error for unknown input variable, got nil")
+	}
+	if err := fis.SetInputGain("Temperature", math.NaN()); err == nil {
+		t.Error("Expected error for NaN gain, got nil")
+	}
+	if err := fis.SetInputGain("Temperature", math.Inf(1)); err == nil {
+		t.Error("Expected error for infinite gain, got nil")
+	}
+}
+
+func TestSetInputDefault(t *testing.T) {
+	fis := NewMamdaniInferenceSystem()
+
+	tempVar, _ := variable.NewFuzzyVariable("Temperature", 0, 50)
+	tempVar.AddSet(set.NewFuzzySet("Hot", mustMF(membership.NewTriangular(30, 50, 70))))
+	humVar, _ := variable.NewFuzzyVariable("Humidity", 0, 100)
+	humVar.AddSet(set.NewFuzzySet("Wet", mustMF(membership.NewTriangular(60, 100, 140))))
+	fanVar, _ := variable.NewFuzzyVariable("FanSpeed", 0, 100)
+	fanVar.AddSet(set.NewFuzzySet("High", mustMF(membership.NewTriangular(67, 100, 100))))
+
+	_ = fis.AddInputVariable(tempVar)
+	_ = fis.AddInputVariable(humVar)
+	_ = fis.AddOutputVariable(fanVar)
+
+	r, _ := rule.NewRule(rule.RuleCondition{Variable: "FanSpeed", Set: "High"}, operators.AND)
+	r.AddCondition("Temperature", "Hot")
+	r.AddCondition("Humidity", "Wet")
+	_ = fis.AddRule(r)
+
+	expected, err := fis.Infer(map[string]float64{"Temperature": 40, "Humidity": 80})
+	if err != nil {
+		t.Fatalf("Infer failed: %v", err)
+	}
+
+	// Humidity is still required until a default is set
+	if _, err := fis.Infer(map[string]float64{"Temperature": 40}); !errors.Is(err, ErrMissingInput) {
+		t.Fatalf("Expected ErrMissingInput without a default, got %v", err)
+	}
+
+	if err := fis.SetInputDefault("Humidity", 80); err != nil {
+		t.Fatalf("SetInputDefault failed: %v", err)
+	}
+	got, err := fis.Infer(map[string]float64{"Temperature": 40})
+	if err != nil {
+		t.Fatalf("Infer with defaulted input failed: %v", err)
+	}
+	if !floatEqual(got["FanSpeed"], expected["FanSpeed"]) {
+		t.Errorf("Expected defaulted Humidity to behave like 80 (%f), got %f", expected["FanSpeed"], got["FanSpeed"])
+	}
+
+	// Temperature has no default and is still required
+	if _, err := fis.Infer(map[string]float64{"Humidity": 80}); !errors.Is(err, ErrMissingInput) {
+		t.Errorf("Expected ErrMissingInput for Temperature, got %v", err)
+	}
+}
+
+func TestSetInputDefault_Validation(t *testing.T) {
+	fis := newTempFanSystem(t)
+
+	if err := fis.SetInputDefault("Unknown", 10); err == nil {
+		t.Error("Expected error for unknown input variable, got nil")
+	}
+	if err := fis.SetInputDefault("Temperature", 60); err == nil {
+		t.Error("Expected error for default outside domain, got nil")
+	}
+	if err := fis.SetInputDefault("Temperature", math.NaN()); err == nil {
+		t.Error("Expected error for NaN default, got nil")
+	}
+}
+
+func TestAddRule_NilOperator(t *testing.T) {
+	fis := newTempFanSystem(t)
+	literal := func() *rule.Rule {
+		return &rule.Rule{
+			Conditions: []rule.RuleCondition{{Variable: "Temperature", Set: "Hot"}},
+			Output:     rule.RuleCondition{Variable: "FanSpeed", Set: "High"},
+			Weight:     1.0,
+		}
+	}
+
+	r := literal()
+	if err := fis.AddRule(r); err != nil {
+		t.Fatalf("AddRule failed: %v", err)
+	}
+	if r.Operator != operators.AND {
+		t.Errorf("Expected nil operator to default to AND, got %v", r.Operator)
+	}
+	if _, err := fis.Infer(map[string]float64{"Temperature": 45}); err != nil {
+		t.Errorf("Infer with defaulted operator failed: %v", err)
+	}
+
+	fis.Strict = true
+	if err := fis.AddRule(literal()); err == nil {
+		t.Error("Expected strict AddRule to reject a rule without an operator, got nil")
+	}
+	if err := fis.UpsertRule(literal()); err == nil {
+		t.Error("Expected strict UpsertRule to reject a rule without an operator, got nil")
+	}
+}
+
+func TestSetDefaultRule(t *testing.T) {
+	fis := newTempFanSystem(t)
+	// Without the Warm rule nothing covers temperatures between 20 and 30
+	if err := fis.RemoveRuleByID(2); err != nil {
+		t.Fatalf("RemoveRuleByID failed: %v", err)
+	}
+	if _, err := fis.Infer(map[string]float64{"Temperature": 25}); err == nil {
+		t.Fatal("Expected an error for an uncovered input without a default rule, got nil")
+	}
+
+	if err := fis.SetDefaultRule(&variable.SetRef{Variable: "FanSpeed", Set: "Medium"}, 1); err != nil {
+		t.Fatalf("SetDefaultRule failed: %v", err)
+	}
+	got, err := fis.Infer(map[string]float64{"Temperature": 25})
+	if err != nil {
+		t.Fatalf("Infer with default rule failed: %v", err)
+	}
+	if math.Abs(got["FanSpeed"]-50) > 1 {
+		t.Errorf("Expected the default Medium set (50), got %f", got["FanSpeed"])
+	}
+
+	// Covered inputs ignore the default rule
+	hot, err := fis.Infer(map[string]float64{"Temperature": 45})
+	if err != nil {
+		t.Fatalf("Infer failed: %v", err)
+	}
+	if hot["FanSpeed"] < 67 {
+		t.Errorf("Expected the High set to win over the default rule, got %f", hot["FanSpeed"])
+	}
+}
+
+func TestSetDefaultRule_Validation(t *testing.T) {
+	fis := newTempFanSystem(t)
+
+	tests := []struct {
+		name     string
+		output   *variable.SetRef
+		strength float64
+	}{
+		{"nil output", nil, 1},
+		{"unknown variable", &variable.SetRef{Variable: "Unknown", Set: "Low"}, 1},
+		{"unknown set", &variable.SetRef{Variable: "FanSpeed", Set: "Unknown"}, 1},
+		{"zero strength", &variable.SetRef{Variable: "FanSpeed", Set: "Low"}, 0},
+		{"strength above 1", &variable.SetRef{Variable: "FanSpeed", Set: "Low"}, 1.5},
+		{"NaN strength", &variable.SetRef{Variable: "FanSpeed", Set: "Low"}, math.NaN()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := fis.SetDefaultRule(tt.output, tt.strength); err == nil {
+				t.Error("Expected error, got nil")
+			}
+		})
+	}
+	if len(fis.DefaultRules) != 0 {
+		t.Errorf("Expected no default rules after failed calls, got %v", fis.DefaultRules)
+	}
+}
+
+func TestInputOutputDomains(t *testing.T) {
+	fis := newTempFanSystem(t)
+
+	inputs := fis.InputDomains()
+	if len(inputs) != 1 {
+		t.Fatalf("Expected 1 input domain, got %d", len(inputs))
+	}
+	if got := inputs["Temperature"]; got != [2]float64{0, 50} {
+		t.Errorf("Expected Temperature domain [0 50], got %v", got)
+	}
+
+	outputs := fis.OutputDomains()
+	if got := outputs["FanSpeed"]; got != [2]float64{0, 100} {
+		t.Errorf("Expected FanSpeed domain [0 100], got %v", got)
+	}
+
+	// Returned maps are copies
+	inputs["Temperature"] = [2]float64{-1, 1}
+	if fis.InputVariables["Temperature"].MinValue != 0 {
+		t.Error("Modifying returned domains should not affect the system")
+	}
+}
+
+func TestAllSets(t *testing.T) {
+	fis := newTempFanSystem(t)
+	gauss := mustMF(membership.NewGaussian(50, 10))
+	fis.OutputVariables["FanSpeed"].AddSet(set.NewFuzzySet("Normal", gauss))
+
+	expected := []SetInfo{
+		{"Temperature", "Cold", membership.KindTriangular, []float64{0, 0, 20}, true},
+		{"Temperature", "Hot", membership.KindTriangular, []float64{30, 50, 70}, true},
+		{"Temperature", "Warm", membership.KindTriangular, []float64{10, 25, 40}, true},
+		{"FanSpeed", "High", membership.KindTriangular, []float64{67, 100, 100}, false},
+		{"FanSpeed", "Low", membership.KindTriangular, []float64{0, 0, 33}, false},
+		{"FanSpeed", "Medium", membership.KindTriangular, []float64{20, 50, 80}, false},
+		{"FanSpeed", "Normal", membership.KindGaussian, []float64{50, 10}, false},
+	}
+	got := fis.AllSets()
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("AllSets mismatch:\n got      %v\n expected %v", got, expected)
+	}
+
+	// Every listed set can be rebuilt from its kind and params
+	for _, info := range got {
+		mf, err := membership.New(info.Kind, info.Params)
+		if err != nil {
+			t.Fatalf("membership.New(%s, %v) failed: %v", info.Kind, info.Params, err)
+		}
+		v := fis.InputVariables[info.Variable]
+		if !info.IsInput {
+			v = fis.OutputVariables[info.Variable]
+		}
+		for x := v.MinValue; x <= v.MaxValue; x += 5 {
+			if !floatEqual(mf.Evaluate(x), v.Sets[info.Set].Evaluate(x)) {
+				t.Errorf("%s.%s rebuilt from %s %v differs at x=%v", info.Variable, info.Set, info.Kind, info.Params, x)
+			}
+		}
+	}
+
+	// Custom membership functions are listed without kind or params
+	fis.InputVariables["Temperature"].AddSet(set.NewFuzzySet("Mild", (&membership.Gaussian{Center: 20, Width: 5}).RisingHalf()))
+	for _, info := range fis.AllSets() {
+		if info.Set == "Mild" && (info.Kind != "" || info.Params != nil) {
+			t.Errorf("Expected custom set without kind/params, got %+v", info)
+		}
+	}
+}
+
+func TestRandomInputs(t *testing.T) {
+	fis := newTempFanSystem(t)
+	r := rand.New(rand.NewSource(42))
+
+	for i := 0; i < 1000; i++ {
+		inputs := fis.RandomInputs(r)
+		value, ok := inputs["Temperature"]
+		if !ok {
+			t.Fatal("Temperature missing from random inputs")
+		}
+		if !fis.InputVariables["Temperature"].IsValid(value) {
+			t.Fatalf("Random input %f is out of bounds [0, 50]", value)
+		}
+		if _, err := fis.Infer(inputs); err != nil {
+			t.Fatalf("Infer failed for random input %f: %v", value, err)
+		}
+	}
+}
+
+func TestRandomInputs_Deterministic(t *testing.T) {
+	fis := newTempFanSystem(t)
+	a := fis.RandomInputs(rand.New(rand.NewSource(7)))
+	b := fis.RandomInputs(rand.New(rand.NewSource(7)))
+	if a["Temperature"] != b["Temperature"] {
+		t.Errorf("Expected identical inputs for identical seeds, got %f and %f", a["Temperature"], b["Temperature"])
+	}
+}
+
+// unclampedMF is a deliberately broken membership function whose degrees are
+// not limited to [0, 1]. Its huge degrees overflow the COG sums to +Inf,
+// which turns the centroid into NaN.
+type unclampedMF struct{}
+
+func (unclampedMF) Evaluate(x float64) float64 {
+	if x >= 20 && x <= 80 {
+		return math.MaxFloat64
+	}
+	return 0
+}
+
+func (unclampedMF) Support() (left, right float64, bounded bool) {
+	return math.Inf(-1), math.Inf(1), false
+}
+
+func TestVerifyOutputBounds(t *testing.T) {
+	fis := newTempFanSystem(t)
+	for _, method := range []string{DefuzzCOG, DefuzzMOM, DefuzzFOM} {
+		_ = fis.SetDefuzzificationMethod(method)
+		if err := fis.VerifyOutputBounds(500); err != nil {
+			t.Errorf("Expected standard settings with %s to pass, got: %v", method, err)
+		}
+	}
+
+	if err := fis.VerifyOutputBounds(0); err == nil {
+		t.Error("Expected error for zero samples, got nil")
+	}
+}
+
+func TestVerifyOutputBounds_DetectsViolation(t *testing.T) {
+	fis := newTempFanSystem(t)
+	_ = fis.SetDefuzzificationMethod(DefuzzCOG)
+	fis.ClampMembership = false
+	fis.OutputVariables["FanSpeed"].Sets["Medium"].MembershipFunc = unclampedMF{}
+
+	if err := fis.VerifyOutputBounds(500); err == nil {
+		t.Error("Expected out-of-bounds violation for unclamped membership, got nil")
+	}
+}
+
+// nanMF is a buggy membership function that returns NaN inside its support
+type nanMF struct{}
+
+func (nanMF) Evaluate(x float64) float64 {
+	if x >= 20 && x <= 80 {
+		return math.NaN()
+	}
+	return 0
+}
+
+func (nanMF) Support() (left, right float64, bounded bool) {
+	return math.Inf(-1), math.Inf(1), false
+}
+
+func TestInfer_NonFiniteOutput(t *testing.T) {
+	fis := newTempFanSystem(t)
+	fis.OutputVariables["FanSpeed"].Sets["Medium"].MembershipFunc = nanMF{}
+
+	for _, method := range []string{DefuzzCOG, DefuzzMOM, DefuzzFOM, DefuzzBIS} {
+		_ = fis.SetDefuzzificationMethod(method)
+		_, err := fis.Infer(map[string]float64{"Temperature": 25})
+		if !errors.Is(err, ErrNonFiniteOutput) {
+			t.Fatalf("%s: expected ErrNonFiniteOutput, got %v", method, err)
+		}
+		if !strings.Contains(err.Error(), "FanSpeed") {
+			t.Errorf("%s: expected error to name the output variable, got %v", method, err)
+		}
+	}
+}
+
+func TestAddRule_ValidatesGroupedConditions(t *testing.T) {
+	fis := newTempFanSystem(t)
+
+	r, _ := rule.NewRule(rule.RuleCondition{Variable: "FanSpeed", Set: "High"}, operators.OR)
+	_ = r.AddGroup(rule.RuleCondition{Variable: "Temperature", Set: "Hot"})
+	_ = r.AddGroup(rule.RuleCondition{Variable: "Temperature", Set: "Scorching"})
+	if err := fis.AddRule(r); err == nil {
+		t.Error("Expected error for grouped condition referencing non-existent set, got nil")
+	}
+
+	ok, _ := rule.NewRule(rule.RuleCondition{Variable: "FanSpeed", Set: "High"}, operators.OR)
+	_ = ok.AddGroup(rule.RuleCondition{Variable: "Temperature", Set: "Hot"})
+	_ = ok.AddGroup(rule.RuleCondition{Variable: "Temperature", Set: "Warm"})
+	if err := fis.AddRule(ok); err != nil {
+		t.Fatalf("Expected grouped rule to be accepted, got: %v", err)
+	}
+	if _, err := fis.Infer(map[string]float64{"Temperature": 40}); err != nil {
+		t.Errorf("Infer with grouped rule failed: %v", err)
+	}
+}
+
+func TestRuleIDs(t *testing.T) {
+	fis := newTempFanSystem(t)
+
+	if len(fis.Rules) != 3 {
+		t.Fatalf("Expected 3 rules, got %d", len(fis.Rules))
+	}
+	ids := []int{fis.Rules[0].ID, fis.Rules[1].ID, fis.Rules[2].ID}
+	if ids[0] == ids[1] || ids[1] == ids[2] || ids[0] == ids[2] {
+		t.Fatalf("Expected distinct rule IDs, got %v", ids)
+	}
+
+	if err := fis.RemoveRuleByID(ids[1]); err != nil {
+		t.Fatalf("RemoveRuleByID failed: %v", err)
+	}
+	if _, ok := fis.RuleByID(ids[1]); ok {
+		t.Error("Expected removed rule to no longer be found")
+	}
+	for _, id := range []int{ids[0], ids[2]} {
+		r, ok := fis.RuleByID(id)
+		if !ok {
+			t.Fatalf("Expected rule %d to still exist", id)
+		}
+		if r.ID != id {
+			t.Errorf("Expected rule ID %d to be unchanged, got %d", id, r.ID)
+		}
+	}
+	if fis.Rules[1].ID != ids[2] {
+		t.Errorf("Expected remaining rules to keep their order, got ID %d at index 1", fis.Rules[1].ID)
+	}
+
+	// IDs are not reused after removal
+	r, _ := rule.NewRule(rule.RuleCondition{Variable: "FanSpeed", Set: "Medium"}, operators.AND)
+	r.AddCondition("Temperature", "Warm")
+	if err := fis.AddRule(r); err != nil {
+		t.Fatalf("AddRule failed: %v", err)
+	}
+	for _, id := range ids {
+		if r.ID == id {
+			t.Errorf("Expected new rule to get a fresh ID, got reused ID %d", id)
+		}
+	}
+
+	if err := fis.RemoveRuleByID(ids[1]); err == nil {
+		t.Error("Expected error removing unknown rule ID, got nil")
+	}
+}
+
+func TestUpsertRule(t *testing.T) {
+	fis := newTempFanSystem(t)
+	before := len(fis.Rules)
+
+	newRule := func(weight float64) *rule.Rule {
+		r, _ := rule.NewRule(rule.RuleCondition{Variable: "FanSpeed", Set: "Medium"}, operators.AND)
+		r.AddCondition("Temperature", "Hot")
+		r.Weight = weight
+		return r
+	}
+
+	first := newRule(0.5)
+	if err := fis.UpsertRule(first); err != nil {
+		t.Fatalf("UpsertRule failed: %v", err)
+	}
+	second := newRule(0.8)
+	if err := fis.UpsertRule(second); err != nil {
+		t.Fatalf("UpsertRule failed: %v", err)
+	}
+	if len(fis.Rules) != before+1 {
+		t.Fatalf("Expected %d rules after upserting twice, got %d", before+1, len(fis.Rules))
+	}
+	got, ok := fis.RuleByID(first.ID)
+	if !ok {
+		t.Fatalf("Expected upserted rule to keep ID %d", first.ID)
+	}
+	if got != second || got.Weight != 0.8 {
+		t.Errorf("Expected the latest rule with weight 0.8, got weight %f", got.Weight)
+	}
+
+	// A different output is a different rule
+	other, _ := rule.NewRule(rule.RuleCondition{Variable: "FanSpeed", Set: "Low"}, operators.AND)
+	other.AddCondition("Temperature", "Hot")
+	if err := fis.UpsertRule(other); err != nil {
+		t.Fatalf("UpsertRule failed: %v", err)
+	}
+	if len(fis.Rules) != before+2 {
+		t.Errorf("Expected %d rules, got %d", before+2, len(fis.Rules))
+	}
+
+	invalid, _ := rule.NewRule(rule.RuleCondition{Variable: "FanSpeed", Set: "Medium"}, operators.AND)
+	invalid.AddCondition("Temperature", "Scorching")
+	if err := fis.UpsertRule(invalid); err == nil {
+		t.Error("Expected error upserting rule with non-existent set, got nil")
+	}
+}
+
+func TestInferOutputSupport(t *testing.T) {
+	fis := newTempFanSystem(t)
+
+	// Only Hot fires at 45, so only FanSpeed.High [67, 100] contributes
+	lo, hi, ok := fis.InferOutputSupport(map[string]float64{"Temperature": 45}, "FanSpeed")
+	if !ok {
+		t.Fatal("Expected support to be found")
+	}
+	if math.Abs(lo-67) > 1e-6 || math.Abs(hi-100) > 1e-6 {
+		t.Errorf("Expected support [67, 100], got [%f, %f]", lo, hi)
+	}
+
+	if _, _, ok := fis.InferOutputSupport(map[string]float64{"Temperature": 45}, "Unknown"); ok {
+		t.Error("Expected ok=false for unknown output variable")
+	}
+	if _, _, ok := fis.InferOutputSupport(map[string]float64{"Temperature": 99}, "FanSpeed"); ok {
+		t.Error("Expected ok=false for out-of-bounds input")
+	}
+}
+
+func TestDefuzzify_ClampsStrengthAboveOne(t *testing.T) {
+	fanVar, _ := variable.NewFuzzyVariable("FanSpeed", 0, 100)
+	fanVar.AddSet(set.NewFuzzySet("Medium", mustMF(membership.NewTriangular(20, 50, 80))))
+	fanVar.AddSet(set.NewFuzzySet("High", mustMF(membership.NewTriangular(60, 100, 100))))
+
+	// Strengths above 1, as produced by sum aggregation of several rules
+	memberships := map[string]float64{"Medium": 1.6, "High": 1.2}
+
+	clamped := defaultDefuzzOptions()
+	unclamped := clamped
+	unclamped.clamp = false
+
+	// Unclamped MOM only sees the single scaled Medium peak at 50
+	raw, err := defuzzifyMOMWithOptions(fanVar, memberships, unclamped)
+	if err != nil {
+		t.Fatalf("Unclamped MOM failed: %v", err)
+	}
+	if !floatEqual(raw, 50) {
+		t.Errorf("Expected unclamped MOM at 50, got %f", raw)
+	}
+
+	// Clamped MOM averages the full-membership plateaus: Medium [38.75, 61.25] and High [~93.3, 100)
+	result, err := defuzzifyMOMWithOptions(fanVar, memberships, clamped)
+	if err != nil {
+		t.Fatalf("Clamped MOM failed: %v", err)
+	}
+	if result <= 50 || result >= 100 {
+		t.Errorf("Expected clamped MOM between plateaus, got %f", result)
+	}
+
+	// Clamped FOM is the start of the first plateau
+	first, _ := defuzzifyFOMWithOptions(fanVar, memberships, clamped)
+	if math.Abs(first-38.75) > 0.1 {
+		t.Errorf("Expected clamped FOM near 38.75, got %f", first)
+	}
+
+	// Clamped COG stays within the domain
+	cog, _ := defuzzifyCOGWithOptions(fanVar, memberships, clamped)
+	if !fanVar.IsValid(cog) {
+		t.Errorf("Expected clamped COG within domain, got %f", cog)
+	}
+}
+
+// nearestGridBisector is the naive bisector: the sample point whose cumulative
+// trapezoidal area is closest to half the total.
+func nearestGridBisector(outputVar *variable.FuzzyVariable, memberships map[string]float64, opts defuzzOptions) float64 {
+	step := (outputVar.MaxValue - outputVar.MinValue) / float64(opts.resolution)
+	cumulative := make([]float64, opts.resolution+1)
+	prev := aggregatedMembership(outputVar, memberships, outputVar.MinValue, opts)
+	for i := 1; i <= opts.resolution; i++ {
+		y := aggregatedMembership(outputVar, memberships, outputVar.MinValue+float64(i)*step, opts)
+		cumulative[i] = cumulative[i-1] + (prev+y)*step/2
+		prev = y
+	}
+	half := cumulative[opts.resolution] / 2
+	best := 0
+	for i := range cumulative {
+		if math.Abs(cumulative[i]-half) < math.Abs(cumulative[best]-half) {
+			best = i
+		}
+	}
+	return outputVar.MinValue + float64(best)*step
+}
+
+func TestDefuzzifyBisector_InterpolatedAccuracy(t *testing.T) {
+	outVar, _ := variable.NewFuzzyVariable("Out", 0, 100)
+	outVar.AddSet(set.NewFuzzySet("Skewed", mustMF(membership.NewTriangular(0, 10, 100))))
+	memberships := map[string]float64{"Skewed": 1.0}
+
+	// Right of the peak the area is (100-x)²/180, so the exact bisector is 100 - sqrt(4500)
+	exact := 100 - math.Sqrt(4500)
+
+	opts := defaultDefuzzOptions()
+	opts.resolution = 10
+	interpolated, err := defuzzifyBisectorWithOptions(outVar, memberships, opts)
+	if err != nil {
+		t.Fatalf("Bisector failed: %v", err)
+	}
+	grid := nearestGridBisector(outVar, memberships, opts)
+
+	if math.Abs(interpolated-exact) >= math.Abs(grid-exact) {
+		t.Errorf("Expected interpolated bisector (%f) closer to %f than grid bisector (%f)", interpolated, exact, grid)
+	}
+	if math.Abs(interpolated-exact) > 1e-6 {
+		t.Errorf("Expected interpolated bisector %f, got %f", exact, interpolated)
+	}
+}
+
+func TestInfer_Bisector(t *testing.T) {
+	fis := newTempFanSystem(t)
+	if err := fis.SetDefuzzificationMethod(DefuzzBIS); err != nil {
+		t.Fatalf("SetDefuzzificationMethod failed: %v", err)
+	}
+	results, err := fis.Infer(map[string]float64{"Temperature": 45})
+	if err != nil {
+		t.Fatalf("Infer failed: %v", err)
+	}
+	if results["FanSpeed"] < 67 || results["FanSpeed"] > 100 {
+		t.Errorf("Expected bisector within High support, got %f", results["FanSpeed"])
+	}
+}
+
+func TestApplyImplication_ConsistentAcrossDefuzzifiers(t *testing.T) {
+	// Clipping Triangular(20, 50, 80) at 0.5 gives the same curve as scaling
+	// Trapezoidal(20, 35, 65, 80) by 0.5, so every defuzzifier must agree
+	triVar, _ := variable.NewFuzzyVariable("Out", 0, 100)
+	triVar.AddSet(set.NewFuzzySet("Set", mustMF(membership.NewTriangular(20, 50, 80))))
+	trapVar, _ := variable.NewFuzzyVariable("Out", 0, 100)
+	trapVar.AddSet(set.NewFuzzySet("Set", mustMF(membership.NewTrapezoidal(20, 35, 65, 80))))
+	memberships := map[string]float64{"Set": 0.5}
+
+	minOpts := defaultDefuzzOptions()
+	minOpts.implication = ImplicationMin
+	prodOpts := defaultDefuzzOptions()
+
+	defuzzifiers := map[string]func(*variable.FuzzyVariable, map[string]float64, defuzzOptions) (float64, error){
+		DefuzzCOG: defuzzifyCOGWithOptions,
+		DefuzzMOM: defuzzifyMOMWithOptions,
+		DefuzzFOM: defuzzifyFOMWithOptions,
+		DefuzzSOM: defuzzifySOMWithOptions,
+		DefuzzLOM: defuzzifyLOMWithOptions,
+		DefuzzBIS: defuzzifyBisectorWithOptions,
+	}
+	for name, defuzz := range defuzzifiers {
+		clipped, err := defuzz(triVar, memberships, minOpts)
+		if err != nil {
+			t.Fatalf("%s with min implication failed: %v", name, err)
+		}
+		want, err := defuzz(trapVar, memberships, prodOpts)
+		if err != nil {
+			t.Fatalf("%s with product implication failed: %v", name, err)
+		}
+		if math.Abs(clipped-want) > 0.2 {
+			t.Errorf("%s: min-clipped triangle gave %f, equivalent scaled trapezoid gave %f", name, clipped, want)
+		}
+	}
+
+	// The plateau created by clipping moves the first maximum left of the peak
+	scaled, _ := defuzzifyFOMWithOptions(triVar, memberships, prodOpts)
+	clipped, _ := defuzzifyFOMWithOptions(triVar, memberships, minOpts)
+	if math.Abs(scaled-50) > 0.2 || math.Abs(clipped-35) > 0.2 {
+		t.Errorf("Expected FOM 50 with product and 35 with min implication, got %f and %f", scaled, clipped)
+	}
+}
+
+func TestSetMinCOGMass(t *testing.T) {
+	fis := newTempFanSystem(t)
+	fanVar := fis.OutputVariables["FanSpeed"]
+	weak := map[string]float64{"Medium": 1e-9} // area about 3e-8
+
+	// Without a threshold the weak firing still yields a centroid
+	if _, err := defuzzifyCOGWithOptions(fanVar, weak, fis.defuzzOptions()); err != nil {
+		t.Fatalf("Expected weak firing to defuzzify without threshold, got %v", err)
+	}
+
+	if err := fis.SetMinCOGMass(1e-6); err != nil {
+		t.Fatalf("SetMinCOGMass failed: %v", err)
+	}
+	if _, err := defuzzifyCOGWithOptions(fanVar, weak, fis.defuzzOptions()); !errors.Is(err, ErrNoRulesFired) {
+		t.Errorf("Expected ErrNoRulesFired below the mass threshold, got %v", err)
+	}
+	// Normal firing is unaffected
+	if got, err := defuzzifyCOGWithOptions(fanVar, map[string]float64{"Medium": 0.5}, fis.defuzzOptions()); err != nil || math.Abs(got-50) > 0.1 {
+		t.Errorf("Expected centroid 50 above the threshold, got %f (%v)", got, err)
+	}
+
+	if err := fis.SetMinCOGMass(1e-6, 42); err != nil {
+		t.Fatalf("SetMinCOGMass with fallback failed: %v", err)
+	}
+	if got, err := defuzzifyCOGWithOptions(fanVar, weak, fis.defuzzOptions()); err != nil || got != 42 {
+		t.Errorf("Expected fallback 42 below the mass threshold, got %f (%v)", got, err)
+	}
+
+	if err := fis.SetMinCOGMass(-1); err == nil {
+		t.Error("Expected error for negative mass, got nil")
+	}
+	if err := fis.SetMinCOGMass(1, 2, 3); err == nil {
+		t.Error("Expected error for multiple fallbacks, got nil")
+	}
+}
+
+func TestSetOutputDefuzzificationMethod(t *testing.T) {
+	fis := newTempFanSystem(t)
+	inputs := map[string]float64{"Temperature": 35}
+
+	_ = fis.SetDefuzzificationMethod(DefuzzFOM)
+	fom, _ := fis.Infer(inputs)
+	_ = fis.SetDefuzzificationMethod(DefuzzCOG)
+	cog, _ := fis.Infer(inputs)
+
+	if err := fis.SetOutputDefuzzificationMethod("FanSpeed", DefuzzFOM); err != nil {
+		t.Fatalf("SetOutputDefuzzificationMethod failed: %v", err)
+	}
+	if got, _ := fis.Infer(inputs); got["FanSpeed"] != fom["FanSpeed"] {
+		t.Errorf("Expected override to use FOM (%f), got %f", fom["FanSpeed"], got["FanSpeed"])
+	}
+
+	// Removing the override restores the system method
+	if err := fis.SetOutputDefuzzificationMethod("FanSpeed", ""); err != nil {
+		t.Fatalf("Removing override failed: %v", err)
+	}
+	if got, _ := fis.Infer(inputs); got["FanSpeed"] != cog["FanSpeed"] {
+		t.Errorf("Expected system COG (%f) after removing override, got %f", cog["FanSpeed"], got["FanSpeed"])
+	}
+
+	if err := fis.SetOutputDefuzzificationMethod("Unknown", DefuzzMOM); err == nil {
+		t.Error("Expected error for unknown output variable, got nil")
+	}
+	if err := fis.SetOutputDefuzzificationMethod("FanSpeed", "median"); err == nil {
+		t.Error("Expected error for unknown method, got nil")
+	}
+}
+
+func TestDefuzzifyFOM_Interpolated(t *testing.T) {
+	// Smooth peak at 37 sampled every 10 units: the grid snaps to 40
+	gaussVar, _ := variable.NewFuzzyVariable("Out", 0, 100)
+	gaussVar.AddSet(set.NewFuzzySet("Peak", mustMF(membership.NewGaussian(37, 10))))
+	memberships := map[string]float64{"Peak": 1}
+
+	opts := defaultDefuzzOptions()
+	opts.resolution = 10
+	snapped, err := defuzzifyFOMWithOptions(gaussVar, memberships, opts)
+	if err != nil {
+		t.Fatalf("FOM failed: %v", err)
+	}
+	opts.interpolate = true
+	interpolated, err := defuzzifyFOMWithOptions(gaussVar, memberships, opts)
+	if err != nil {
+		t.Fatalf("Interpolated FOM failed: %v", err)
+	}
+	if math.Abs(interpolated-37) >= math.Abs(snapped-37) {
+		t.Errorf("Expected interpolated FOM %f to be closer to 37 than grid FOM %f", interpolated, snapped)
+	}
+	if math.Abs(interpolated-37) > 0.5 {
+		t.Errorf("Expected interpolated FOM near 37, got %f", interpolated)
+	}
+
+	// Plateau starting at 33: the rising edge is extended to the plateau level
+	trapVar, _ := variable.NewFuzzyVariable("Out", 0, 100)
+	trapVar.AddSet(set.NewFuzzySet("Flat", mustMF(membership.NewTrapezoidal(0, 33, 70, 100))))
+	plateau, err := defuzzifyFOMWithOptions(trapVar, map[string]float64{"Flat": 1}, opts)
+	if err != nil {
+		t.Fatalf("Interpolated FOM failed: %v", err)
+	}
+	if math.Abs(plateau-33) > 1e-6 {
+		t.Errorf("Expected interpolated plateau start 33, got %f", plateau)
+	}
+}
+
+func TestDefuzzifyLOM_Plateau(t *testing.T) {
+	// Flat top from 33 to 70: FOM and LOM return the two edges of the plateau
+	trapVar, _ := variable.NewFuzzyVariable("Out", 0, 100)
+	trapVar.AddSet(set.NewFuzzySet("Flat", mustMF(membership.NewTrapezoidal(0, 33, 70, 100))))
+	memberships := map[string]float64{"Flat": 1}
+
+	opts := defaultDefuzzOptions()
+	opts.resolution = 100
+	first, err := defuzzifyFOMWithOptions(trapVar, memberships, opts)
+	if err != nil {
+		t.Fatalf("FOM failed: %v", err)
+	}
+	last, err := defuzzifyLOMWithOptions(trapVar, memberships, opts)
+	if err != nil {
+		t.Fatalf("LOM failed: %v", err)
+	}
+	if !floatEqual(first, 33) || !floatEqual(last, 70) {
+		t.Errorf("Expected FOM 33 and LOM 70, got %f and %f", first, last)
+	}
+
+	// Off-grid plateau end: interpolation extends the falling edge back up to the plateau
+	opts.resolution = 10
+	opts.interpolate = true
+	interpolated, err := defuzzifyLOMWithOptions(trapVar, memberships, opts)
+	if err != nil {
+		t.Fatalf("Interpolated LOM failed: %v", err)
+	}
+	if math.Abs(interpolated-70) > 1e-6 {
+		t.Errorf("Expected interpolated plateau end 70, got %f", interpolated)
+	}
+
+	if _, err := defuzzifyLOMWithOptions(trapVar, map[string]float64{}, opts); !errors.Is(err, ErrNoRulesFired) {
+		t.Errorf("Expected ErrNoRulesFired, got %v", err)
+	}
+}
+
+func TestDefuzzifyMaxima_TrapezoidRegression(t *testing.T) {
+	opts := defaultDefuzzOptions()
+	opts.resolution = 100
+	methods := map[string]func(*variable.FuzzyVariable, map[string]float64, defuzzOptions) (float64, error){
+		DefuzzSOM: defuzzifySOMWithOptions,
+		DefuzzMOM: defuzzifyMOMWithOptions,
+		DefuzzLOM: defuzzifyLOMWithOptions,
+	}
+
+	// Symmetric single peak at 50: all three agree
+	peakVar, _ := variable.NewFuzzyVariable("Out", 0, 100)
+	peakVar.AddSet(set.NewFuzzySet("Peak", mustMF(membership.NewTriangular(20, 50, 80))))
+	for method, defuzz := range methods {
+		got, err := defuzz(peakVar, map[string]float64{"Peak": 1}, opts)
+		if err != nil {
+			t.Fatalf("%s failed: %v", method, err)
+		}
+		if !floatEqual(got, 50) {
+			t.Errorf("%s: expected 50 on a single peak, got %f", method, got)
+		}
+	}
+
+	// Trapezoidal aggregate with a plateau on [30, 60]: left edge, middle, right edge
+	trapVar, _ := variable.NewFuzzyVariable("Out", 0, 100)
+	trapVar.AddSet(set.NewFuzzySet("Flat", mustMF(membership.NewTrapezoidal(10, 30, 60, 90))))
+	want := map[string]float64{DefuzzSOM: 30, DefuzzMOM: 45, DefuzzLOM: 60}
+	for method, defuzz := range methods {
+		got, err := defuzz(trapVar, map[string]float64{"Flat": 0.8}, opts)
+		if err != nil {
+			t.Fatalf("%s failed: %v", method, err)
+		}
+		if !floatEqual(got, want[method]) {
+			t.Errorf("%s: expected %f on the plateau, got %f", method, want[method], got)
+		}
+	}
+
+	// Infer routes SOM to its own implementation
+	fis := newTempFanSystem(t)
+	_ = fis.SetDefuzzificationMethod(DefuzzSOM)
+	if got, err := fis.Infer(map[string]float64{"Temperature": 25}); err != nil || !floatEqual(got["FanSpeed"], 50) {
+		t.Errorf("Expected SOM 50 via Infer, got %v, %v", got, err)
+	}
+}
+
+func TestInfer_LOMRouting(t *testing.T) {
+	fis := newTempFanSystem(t)
+	inputs := map[string]float64{"Temperature": 25}
+
+	_ = fis.SetDefuzzificationMethod(DefuzzFOM)
+	fom, _ := fis.Infer(inputs)
+	_ = fis.SetDefuzzificationMethod(DefuzzLOM)
+	lom, err := fis.Infer(inputs)
+	if err != nil {
+		t.Fatalf("Infer with LOM failed: %v", err)
+	}
+	// Medium peaks at 50, so the single maximum is both first and last
+	if !floatEqual(fom["FanSpeed"], 50) || !floatEqual(lom["FanSpeed"], 50) {
+		t.Errorf("Expected FOM and LOM at the peak 50, got %f and %f", fom["FanSpeed"], lom["FanSpeed"])
+	}
+}
+
+func TestInfer_InterpolateMaxima(t *testing.T) {
+	fis := newTempFanSystem(t)
+	_ = fis.SetDefuzzificationMethod(DefuzzFOM)
+	_ = fis.SetResolution(7)
+	inputs := map[string]float64{"Temperature": 25}
+
+	snapped, _ := fis.Infer(inputs)
+	fis.InterpolateMaxima = true
+	interpolated, err := fis.Infer(inputs)
+	if err != nil {
+		t.Fatalf("Infer failed: %v", err)
+	}
+	// Warm fires fully: Medium peaks at 50
+	if math.Abs(interpolated["FanSpeed"]-50) >= math.Abs(snapped["FanSpeed"]-50) {
+		t.Errorf("Expected interpolated FOM %f to be closer to 50 than grid FOM %f", interpolated["FanSpeed"], snapped["FanSpeed"])
+	}
+}
+
+func TestInfer_InhibitoryRule(t *testing.T) {
+	fis := newTempFanSystem(t)
+	_ = fis.SetDefuzzificationMethod(DefuzzCOG)
+	inputs := map[string]float64{"Temperature": 38} // Warm 2/15, Hot 0.4
+	before, err := fis.Infer(inputs)
+	if err != nil {
+		t.Fatalf("Infer failed: %v", err)
+	}
+
+	// IF Temperature IS Warm THEN suppress FanSpeed IS High
+	inhibit, _ := rule.NewRule(rule.RuleCondition{Variable: "FanSpeed", Set: "High"}, operators.AND)
+	inhibit.AddCondition("Temperature", "Warm")
+	inhibit.Inhibitory = true
+	if err := fis.AddRule(inhibit); err != nil {
+		t.Fatalf("AddRule failed: %v", err)
+	}
+
+	fired, err := fis.InferFuzzy(inputs)
+	if err != nil {
+		t.Fatalf("InferFuzzy failed: %v", err)
+	}
+	if got := fired["FanSpeed"]["High"]; !floatEqual(got, 0.4-2.0/15) {
+		t.Errorf("Expected High strength 0.4 - 2/15, got %f", got)
+	}
+	after, err := fis.Infer(inputs)
+	if err != nil {
+		t.Fatalf("Infer failed: %v", err)
+	}
+	if after["FanSpeed"] >= before["FanSpeed"] {
+		t.Errorf("Expected inhibition to lower the output, got %f before and %f after", before["FanSpeed"], after["FanSpeed"])
+	}
+
+	// Inhibition floors at 0 and is independent of rule order
+	fis.Rules = append([]*rule.Rule{fis.Rules[3]}, fis.Rules[:3]...)
+	inhibit.Conditions[0].Set = "Hot"
+	fired, _ = fis.InferFuzzy(inputs)
+	if got := fired["FanSpeed"]["High"]; got != 0 {
+		t.Errorf("Expected fully inhibited High strength 0, got %f", got)
+	}
+}
+
+func TestAggregatedMembershipAt(t *testing.T) {
+	fis := newTempFanSystem(t)
+	inputs := map[string]float64{"Temperature": 35} // Warm 1/3, Hot 1/4
+	fanSpeed := fis.OutputVariables["FanSpeed"]
+
+	for _, implication := range []string{ImplicationProduct, ImplicationMin} {
+		fis.ImplicationMethod = implication
+		fired, err := fis.InferFuzzy(inputs)
+		if err != nil {
+			t.Fatalf("InferFuzzy failed: %v", err)
+		}
+		// Sample the full aggregated curve independently and compare point by point
+		for x := fanSpeed.MinValue; x <= fanSpeed.MaxValue; x += 2.5 {
+			want := 0.0
+			for setName, strength := range fired["FanSpeed"] {
+				degree := fanSpeed.Sets[setName].Evaluate(x)
+				if implication == ImplicationMin {
+					degree = math.Min(degree, strength)
+				} else {
+					degree *= strength
+				}
+				want = math.Max(want, degree)
+			}
+			got, err := fis.AggregatedMembershipAt(inputs, "FanSpeed", x)
+			if err != nil {
+				t.Fatalf("AggregatedMembershipAt(%f) failed: %v", x, err)
+			}
+			if !floatEqual(got, want) {
+				t.Errorf("%s: AggregatedMembershipAt(%f) = %f, expected %f", implication, x, got, want)
+			}
+		}
+	}
+
+	if _, err := fis.AggregatedMembershipAt(inputs, "Unknown", 50); err == nil {
+		t.Error("Expected error for unknown output variable")
+	}
+	if _, err := fis.AggregatedMembershipAt(inputs, "FanSpeed", 150); err == nil {
+		t.Error("Expected error for x outside the output domain")
+	}
+}
+
+func TestExtendOutputSupport(t *testing.T) {
+	fis := NewMamdaniInferenceSystem()
+	_ = fis.SetDefuzzificationMethod(DefuzzCOG)
+	temp, _ := variable.NewFuzzyVariable("Temperature", 0, 50)
+	temp.AddSet(set.NewFuzzySet("Hot", mustMF(membership.NewTriangular(30, 50, 70))))
+	heat, _ := variable.NewFuzzyVariable("Cooling", 0, 100)
+	// Hot leaks past the domain: only its rising half [60, 100] is inside
+	heat.AddSet(set.NewFuzzySet("Hot", mustMF(membership.NewTriangular(60, 100, 140))))
+	heat.AddSet(set.NewFuzzySet("Low", mustMF(membership.NewTriangular(0, 0, 40))))
+	_ = fis.AddInputVariable(temp)
+	_ = fis.AddOutputVariable(heat)
+	r, _ := rule.NewRule(rule.RuleCondition{Variable: "Cooling", Set: "Hot"}, operators.AND)
+	r.AddCondition("Temperature", "Hot")
+	_ = fis.AddRule(r)
+
+	inputs := map[string]float64{"Temperature": 50}
+	clipped, err := fis.Infer(inputs)
+	if err != nil {
+		t.Fatalf("Infer failed: %v", err)
+	}
+	// Centroid of the rising edge alone is 60 + 2/3*40
+	if math.Abs(clipped["Cooling"]-(60+80.0/3)) > 0.1 {
+		t.Errorf("Expected clipped centroid ~86.67, got %f", clipped["Cooling"])
+	}
+
+	fis.ExtendOutputSupport = true
+	extended, err := fis.Infer(inputs)
+	if err != nil {
+		t.Fatalf("Infer failed: %v", err)
+	}
+	// The full triangle centers on 100, which is also the domain bound
+	if !floatEqual(extended["Cooling"], 100) {
+		t.Errorf("Expected extended centroid 100, got %f", extended["Cooling"])
+	}
+
+	warnings := strings.Join(fis.Lint(), "\n")
+	if !strings.Contains(warnings, "output set 'Hot' of variable 'Cooling'") {
+		t.Errorf("Expected Lint to report the leaking output set, got %q", warnings)
+	}
+	if strings.Contains(warnings, "'Low'") {
+		t.Errorf("Expected no warning for the in-domain Low set, got %q", warnings)
+	}
+}
+
+func TestInfer_ZeroValueSystem(t *testing.T) {
+	var fis MamdaniInferenceSystem
+	if _, err := fis.Infer(map[string]float64{"Temperature": 25}); err == nil {
+		t.Error("Expected not-configured error from a zero-value system, got nil")
+	}
+	if err := fis.SetResolution(100); err != nil {
+		t.Errorf("SetResolution on a zero-value system failed: %v", err)
+	}
+}
+
+// TestInferConcurrentWithMutators runs Infer from many goroutines while another
+// goroutine reconfigures the system. Run with -race to check for data races.
+func TestInferConcurrentWithMutators(t *testing.T) {
+	fis := newTempFanSystem(t)
+	inputs := map[string]float64{"Temperature": 25}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				if _, err := fis.Infer(inputs); err != nil {
+					errs <- err
+					return
+				}
+				// Analysis methods hold the lock for the whole call too
+				if _, err := fis.Classify(inputs, "FanSpeed"); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			_ = fis.SetResolution(100 + i%2*100)
+			_ = fis.SetAggregationMethod([]string{AggregationMax, AggregationSum}[i%2])
+			fis.InvalidateCaches()
+		}
+	}()
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("Concurrent Infer failed: %v", err)
+	}
+}
@@ -0,0 +1,111 @@
+package inference
+
+import (
+	"fmt"
+	"github.com/loian/fuzzylib/operators"
+	"github.com/loian/fuzzylib/rule"
+	"github.com/loian/fuzzylib/variable"
+)
+
+// Lint inspects the system configuration and returns human-readable warnings
+// about definitions that are valid but likely unintended. Lint never modifies
+// the system and an empty result means no issues were found.
+//
+// Current checks:
+//   - Sets outside the domain: sets with zero membership everywhere within
+//     their variable's domain (e.g. after SetDomain); they can never fire.
+//   - Non-normal fuzzy sets: sets that never reach membership 1.0 within their
+//     variable's domain. Non-normal input sets cap the firing strength of every
+//     rule that uses them.
+//   - Leaking output sets: output sets whose bounded support extends past the
+//     variable's domain. Defuzzification ignores the part outside, biasing the
+//     result inward, unless ExtendOutputSupport is set.
+//   - Narrow output domains: output variables whose domain is effectively a
+//     single point. Defuzzification cannot sample them and returns MinValue.
+//   - Single-term OR rules: rules combining fewer than two terms with OR, where
+//     the operator has no effect. With Strict set, AddRule rejects these.
+//
+// Warnings are returned in a deterministic order (inputs before outputs,
+// variables and sets sorted by name, then rules in order).
+func (fis *MamdaniInferenceSystem) Lint() []string {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	warnings := make([]string, 0)
+	warnings = append(warnings, lintNonNormalSets("input", fis.InputVariables, fis.Resolution)...)
+	warnings = append(warnings, lintNonNormalSets("output", fis.OutputVariables, fis.Resolution)...)
+	warnings = append(warnings, lintLeakingOutputSets(fis.OutputVariables)...)
+	warnings = append(warnings, lintNarrowOutputDomains(fis.OutputVariables)...)
+	for i, r := range fis.Rules {
+		if isSingleTermOR(r) {
+			warnings = append(warnings, fmt.Sprintf("rule %d (ID %d) uses OR with fewer than two conditions; OR has no effect",
+				i, r.ID))
+		}
+	}
+	return warnings
+}
+
+// isSingleTermOR reports whether r combines fewer than two terms (conditions,
+// or groups if the rule is grouped) with an OR operator
+func isSingleTermOR(r *rule.Rule) bool {
+	if _, isOR := r.Operator.(*operators.MaxOperator); !isOR {
+		return false
+	}
+	terms := len(r.Conditions)
+	if len(r.Groups) > 0 {
+		terms = len(r.Groups)
+	}
+	return terms < 2
+}
+
+// lintNarrowOutputDomains reports every output variable whose domain is too narrow to sample
+func lintNarrowOutputDomains(vars map[string]*variable.FuzzyVariable) []string {
+	var warnings []string
+	for _, varName := range sortedKeys(vars) {
+		v := vars[varName]
+		if narrowDomain(v) {
+			warnings = append(warnings, fmt.Sprintf("output variable '%s' has a near-degenerate domain [%g, %g]; defuzzification returns its minimum",
+				varName, v.MinValue, v.MaxValue))
+		}
+	}
+	return warnings
+}
+
+// lintLeakingOutputSets reports every output set whose bounded support extends past its variable's domain
+func lintLeakingOutputSets(vars map[string]*variable.FuzzyVariable) []string {
+	var warnings []string
+	for _, varName := range sortedKeys(vars) {
+		v := vars[varName]
+		for _, setName := range sortedSetNames(v) {
+			left, right, bounded := v.Sets[setName].MembershipFunc.Support()
+			if bounded && (left < v.MinValue || right > v.MaxValue) {
+				warnings = append(warnings, fmt.Sprintf("output set '%s' of variable '%s' has support [%.2f, %.2f] extending past the domain [%.2f, %.2f]",
+					setName, varName, left, right, v.MinValue, v.MaxValue))
+			}
+		}
+	}
+	return warnings
+}
+
+// lintNonNormalSets reports every set that fails FuzzySet.IsNormal over its variable's domain,
+// distinguishing sets that do not overlap the domain at all
+func lintNonNormalSets(kind string, vars map[string]*variable.FuzzyVariable, resolution int) []string {
+	if resolution <= 0 {
+		resolution = DefaultResolution
+	}
+	var warnings []string
+	for _, varName := range sortedKeys(vars) {
+		v := vars[varName]
+		for _, setName := range sortedSetNames(v) {
+			fs := v.Sets[setName]
+			peak := fs.MaxMembership(v.MinValue, v.MaxValue, resolution)
+			if peak == 0 {
+				warnings = append(warnings, fmt.Sprintf("%s set '%s.%s' lies outside domain [%.2f, %.2f] and can never fire",
+					kind, varName, setName, v.MinValue, v.MaxValue))
+			} else if !fs.IsNormal(v.MinValue, v.MaxValue, resolution) {
+				warnings = append(warnings, fmt.Sprintf("%s set '%s.%s' is non-normal: max membership %.4f within domain [%.2f, %.2f]",
+					kind, varName, setName, peak, v.MinValue, v.MaxValue))
+			}
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,135 @@
+package inference
+
+import (
+	"errors"
+	"github.com/loian/fuzzylib/membership"
+	"github.com/loian/fuzzylib/operators"
+	"github.com/loian/fuzzylib/rule"
+	"github.com/loian/fuzzylib/set"
+	"github.com/loian/fuzzylib/variable"
+	"strings"
+	"testing"
+)
+
+func TestLint_NoWarnings(t *testing.T) {
+	fis := NewMamdaniInferenceSystem()
+	temp, _ := variable.NewFuzzyVariable("Temperature", 0, 50)
+	temp.AddSet(set.NewFuzzySet("Cold", mustMF(membership.NewTriangular(0, 0, 25))))
+	temp.AddSet(set.NewFuzzySet("Hot", mustMF(membership.NewTriangular(25, 50, 50))))
+	_ = fis.AddInputVariable(temp)
+
+	if warnings := fis.Lint(); len(warnings) != 0 {
+		t.Errorf("Expected no warnings, got %v", warnings)
+	}
+}
+
+func TestLint_NonNormalInputSet(t *testing.T) {
+	fis := NewMamdaniInferenceSystem()
+	temp, _ := variable.NewFuzzyVariable("Temperature", 0, 40)
+	temp.AddSet(set.NewFuzzySet("Cold", mustMF(membership.NewTriangular(0, 0, 20))))
+	// Peak at 50 lies outside the domain: max membership at 40 is 0.8
+	temp.AddSet(set.NewFuzzySet("Hot", mustMF(membership.NewTriangular(0, 50, 60))))
+	_ = fis.AddInputVariable(temp)
+
+	warnings := fis.Lint()
+	if len(warnings) != 1 {
+		t.Fatalf("Expected 1 warning, got %d: %v", len(warnings), warnings)
+	}
+	if !strings.Contains(warnings[0], "Temperature.Hot") || !strings.Contains(warnings[0], "0.8000") {
+		t.Errorf("Unexpected warning text: %s", warnings[0])
+	}
+}
+
+func TestLint_SetOutsideDomain(t *testing.T) {
+	fis := NewMamdaniInferenceSystem()
+	temp, _ := variable.NewFuzzyVariable("Temperature", 0, 50)
+	temp.AddSet(set.NewFuzzySet("Cold", mustMF(membership.NewTriangular(0, 0, 25))))
+	temp.AddSet(set.NewFuzzySet("Hot", mustMF(membership.NewTriangular(25, 50, 50))))
+	_ = fis.AddInputVariable(temp)
+	_ = temp.SetDomain(0, 20)
+
+	warnings := fis.Lint()
+	found := false
+	for _, w := range warnings {
+		if strings.Contains(w, "Temperature.Hot") && strings.Contains(w, "never fire") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected warning for Hot outside domain, got %v", warnings)
+	}
+}
+
+func TestLint_SingleConditionOR(t *testing.T) {
+	fis := newTempFanSystem(t)
+
+	orRule, _ := rule.NewRule(rule.RuleCondition{Variable: "FanSpeed", Set: "High"}, operators.OR)
+	orRule.AddCondition("Temperature", "Hot")
+	if err := fis.AddRule(orRule); err != nil {
+		t.Fatalf("Expected non-strict AddRule to accept single-condition OR rule, got %v", err)
+	}
+
+	warnings := fis.Lint()
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "rule 3") || !strings.Contains(warnings[0], "OR") {
+		t.Fatalf("Expected one warning for rule 3, got %v", warnings)
+	}
+
+	// Single-condition AND rules stay valid in strict mode
+	fis.Strict = true
+	andRule, _ := rule.NewRule(rule.RuleCondition{Variable: "FanSpeed", Set: "Low"}, operators.AND)
+	andRule.AddCondition("Temperature", "Cold")
+	if err := fis.AddRule(andRule); err != nil {
+		t.Errorf("Expected strict AddRule to accept single-condition AND rule, got %v", err)
+	}
+
+	strictOR, _ := rule.NewRule(rule.RuleCondition{Variable: "FanSpeed", Set: "High"}, operators.OR)
+	strictOR.AddCondition("Temperature", "Hot")
+	if err := fis.AddRule(strictOR); err == nil {
+		t.Error("Expected strict AddRule to reject single-condition OR rule, got nil")
+	}
+
+	twoTermOR, _ := rule.NewRule(rule.RuleCondition{Variable: "FanSpeed", Set: "High"}, operators.OR)
+	twoTermOR.AddCondition("Temperature", "Hot")
+	twoTermOR.AddCondition("Temperature", "Warm")
+	if err := fis.AddRule(twoTermOR); err != nil {
+		t.Errorf("Expected strict AddRule to accept two-condition OR rule, got %v", err)
+	}
+}
+
+func TestLint_NarrowOutputDomain(t *testing.T) {
+	fis := NewMamdaniInferenceSystem()
+	temp, _ := variable.NewFuzzyVariable("Temperature", 0, 50)
+	temp.AddSet(set.NewFuzzySet("Hot", mustMF(membership.NewTriangular(25, 50, 50))))
+	valve, _ := variable.NewFuzzyVariable("Valve", 10, 10+1e-12)
+	valve.AddSet(set.NewFuzzySet("Open", mustMF(membership.NewTriangular(10, 10, 10+1e-12))))
+	_ = fis.AddInputVariable(temp)
+	_ = fis.AddOutputVariable(valve)
+	r, _ := NewRuleBuilder("Valve", "Open")
+	built, _ := r.If("Temperature", "Hot").Build()
+	_ = fis.AddRule(built)
+
+	found := false
+	for _, w := range fis.Lint() {
+		if strings.Contains(w, "'Valve'") && strings.Contains(w, "near-degenerate") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected narrow domain warning for Valve, got %v", fis.Lint())
+	}
+
+	// Every defuzzification method returns the domain minimum instead of sampling
+	for _, method := range []string{DefuzzCOG, DefuzzMOM, DefuzzFOM, DefuzzLOM, DefuzzSOM, DefuzzBIS} {
+		_ = fis.SetDefuzzificationMethod(method)
+		results, err := fis.Infer(map[string]float64{"Temperature": 40})
+		if err != nil {
+			t.Fatalf("%s: Infer failed: %v", method, err)
+		}
+		if results["Valve"] != 10 {
+			t.Errorf("%s: expected 10, got %v", method, results["Valve"])
+		}
+	}
+	if _, err := fis.Infer(map[string]float64{"Temperature": 10}); !errors.Is(err, ErrNoRulesFired) {
+		t.Errorf("Expected ErrNoRulesFired when no rule fired, got %v", err)
+	}
+}
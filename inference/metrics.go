@@ -0,0 +1,48 @@
+package inference
+
+import (
+	"time"
+)
+
+// Inference stages reported to a MetricsSink
+const (
+	StageFuzzification   = "fuzzification"
+	StageRuleEvaluation  = "rule_evaluation"
+	StageDefuzzification = "defuzzification"
+)
+
+// MetricsSink receives per-stage timings from Infer and InferWith.
+// ObserveStage is called once per completed stage, in order: fuzzification,
+// rule evaluation, defuzzification. Stages that are not reached because an
+// earlier one failed are not reported.
+type MetricsSink interface {
+	ObserveStage(stage string, d time.Duration)
+}
+
+// SetMetricsSink installs a sink that receives stage durations for every Infer and
+// InferWith call. Pass nil to disable instrumentation; without a sink no clock
+// reads are made and inference stays allocation-free.
+// Analysis helpers such as RuleImportance and SelfCheck are not instrumented.
+func (fis *MamdaniInferenceSystem) SetMetricsSink(sink MetricsSink) {
+	fis.mu.Lock()
+	defer fis.mu.Unlock()
+	fis.metrics = sink
+}
+
+// stageStart returns the start time of a stage, or the zero time when ws is not instrumented
+func (ws *InferenceWorkspace) stageStart() time.Time {
+	if ws.sink == nil {
+		return time.Time{}
+	}
+	return time.Now()
+}
+
+// stageDone reports the stage that began at start and returns the start time of the next one
+func (ws *InferenceWorkspace) stageDone(stage string, start time.Time) time.Time {
+	if ws.sink == nil {
+		return time.Time{}
+	}
+	now := time.Now()
+	ws.sink.ObserveStage(stage, now.Sub(start))
+	return now
+}
@@ -0,0 +1,68 @@
+package inference
+
+import (
+	"testing"
+	"time"
+)
+
+// recordingSink collects stage names and durations in call order
+type recordingSink struct {
+	stages    []string
+	durations []time.Duration
+}
+
+func (s *recordingSink) ObserveStage(stage string, d time.Duration) {
+	s.stages = append(s.stages, stage)
+	s.durations = append(s.durations, d)
+}
+
+func TestSetMetricsSink(t *testing.T) {
+	fis := newTempFanSystem(t)
+	sink := &recordingSink{}
+	fis.SetMetricsSink(sink)
+
+	if _, err := fis.Infer(map[string]float64{"Temperature": 35}); err != nil {
+		t.Fatalf("Infer failed: %v", err)
+	}
+	want := []string{StageFuzzification, StageRuleEvaluation, StageDefuzzification}
+	if len(sink.stages) != len(want) {
+		t.Fatalf("Expected %d stage timings, got %v", len(want), sink.stages)
+	}
+	for i, stage := range want {
+		if sink.stages[i] != stage {
+			t.Errorf("Expected stage %d to be %s, got %s", i, stage, sink.stages[i])
+		}
+		if sink.durations[i] < 0 {
+			t.Errorf("Expected non-negative duration for %s, got %v", stage, sink.durations[i])
+		}
+	}
+
+	// Stages after a failure are not reported
+	sink.stages = nil
+	if _, err := fis.Infer(map[string]float64{"Temperature": 99}); err == nil {
+		t.Fatal("Expected out-of-bounds error, got nil")
+	}
+	if len(sink.stages) != 0 {
+		t.Errorf("Expected no stage timings for rejected inputs, got %v", sink.stages)
+	}
+
+	fis.SetMetricsSink(nil)
+	if _, err := fis.Infer(map[string]float64{"Temperature": 35}); err != nil {
+		t.Fatalf("Infer failed: %v", err)
+	}
+	if len(sink.stages) != 0 {
+		t.Errorf("Expected no stage timings after removing the sink, got %v", sink.stages)
+	}
+}
+
+func TestInferWith_NoSinkAllocationFree(t *testing.T) {
+	fis := newTempFanSystem(t)
+	ws := fis.NewWorkspace()
+	inputs := map[string]float64{"Temperature": 35}
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = fis.InferWith(ws, inputs)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected InferWith without a metrics sink to be allocation-free, got %v allocs per run", allocs)
+	}
+}
@@ -0,0 +1,63 @@
+package inference
+
+import (
+	"fmt"
+	"math"
+)
+
+// OutputModes runs inference and returns the x-locations of the local maxima
+// (modes) of the aggregated output curve for the named output variable,
+// sampled at resolution+1 points. A flat-topped maximum is reported once, at
+// the centre of its plateau. Modes are returned in ascending order.
+// More than one mode means the aggregated output is multimodal and a single
+// crisp value such as the COG may sit in a valley between them.
+// If resolution <= 0, the system's Resolution is used.
+// Returns error if the output variable does not exist, inference fails, or no
+// output set fired.
+func (fis *MamdaniInferenceSystem) OutputModes(inputs map[string]float64, output string, resolution int) ([]float64, error) {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	outputVar, exists := fis.OutputVariables[output]
+	if !exists {
+		return nil, fmt.Errorf("output variable '%s' does not exist", output)
+	}
+	ws := fis.newWorkspace()
+	if err := fis.fire(ws, inputs); err != nil {
+		return nil, err
+	}
+
+	opts := fis.defuzzOptions()
+	if resolution > 0 {
+		opts.resolution = resolution
+	}
+	if opts.resolution <= 0 {
+		opts.resolution = DefaultResolution
+	}
+	step := (outputVar.MaxValue - outputVar.MinValue) / float64(opts.resolution)
+	memberships := ws.outputMemberships[output]
+
+	ys := make([]float64, opts.resolution+1)
+	for i := range ys {
+		ys[i] = aggregatedMembership(outputVar, memberships, outputVar.MinValue+float64(i)*step, opts)
+	}
+
+	var modes []float64
+	for i := 0; i < len(ys); {
+		// Extend over a plateau of (numerically) equal values
+		j := i
+		for j+1 < len(ys) && math.Abs(ys[j+1]-ys[i]) < epsilon {
+			j++
+		}
+		risesLeft := i == 0 || ys[i-1] < ys[i]
+		fallsRight := j == len(ys)-1 || ys[j+1] < ys[j]
+		if ys[i] > 0 && risesLeft && fallsRight {
+			modes = append(modes, outputVar.MinValue+float64(i+j)/2*step)
+		}
+		i = j + 1
+	}
+
+	if len(modes) == 0 {
+		return nil, ErrNoRulesFired
+	}
+	return modes, nil
+}
@@ -0,0 +1,47 @@
+package inference
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestOutputModes_TwoPeaks(t *testing.T) {
+	fis := newTempFanSystem(t)
+
+	// At 15 both Cold (0.25) and Warm (0.33) fire: Low near 0 and Medium at 50
+	modes, err := fis.OutputModes(map[string]float64{"Temperature": 15}, "FanSpeed", 1000)
+	if err != nil {
+		t.Fatalf("OutputModes failed: %v", err)
+	}
+	if len(modes) != 2 {
+		t.Fatalf("Expected 2 modes, got %d: %v", len(modes), modes)
+	}
+	if modes[0] > 1 {
+		t.Errorf("Expected first mode near 0, got %f", modes[0])
+	}
+	if math.Abs(modes[1]-50) > 1e-6 {
+		t.Errorf("Expected second mode at 50, got %f", modes[1])
+	}
+}
+
+func TestOutputModes_SinglePeak(t *testing.T) {
+	fis := newTempFanSystem(t)
+	modes, err := fis.OutputModes(map[string]float64{"Temperature": 25}, "FanSpeed", 0)
+	if err != nil {
+		t.Fatalf("OutputModes failed: %v", err)
+	}
+	if len(modes) != 1 || math.Abs(modes[0]-50) > 1e-6 {
+		t.Errorf("Expected single mode at 50, got %v", modes)
+	}
+}
+
+func TestOutputModes_Errors(t *testing.T) {
+	fis := newTempFanSystem(t)
+	if _, err := fis.OutputModes(map[string]float64{"Temperature": 25}, "Unknown", 100); err == nil {
+		t.Error("Expected error for unknown output, got nil")
+	}
+	if _, err := fis.OutputModes(map[string]float64{"Temperature": 0}, "FanSpeed", 100); !errors.Is(err, ErrNoRulesFired) {
+		t.Errorf("Expected ErrNoRulesFired, got %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package inference
+
+import (
+	"time"
+)
+
+// InferRecord is a structured summary of a single Infer call, passed to the
+// logger installed with SetLogger.
+type InferRecord struct {
+	Inputs        map[string]float64            // crisp inputs as supplied by the caller
+	Fuzzification map[string]map[string]float64 // input variable -> set -> degree (nil if inputs were rejected)
+	FiredRules    []FiredRule                   // rules with non-zero firing strength, in rule order
+	Outputs       map[string]float64            // crisp outputs (nil on error)
+	Duration      time.Duration                 // wall-clock time spent in Infer
+	Err           error                         // error returned by Infer, if any
+}
+
+// FiredRule identifies a rule that fired during an inference run
+type FiredRule struct {
+	Index    int     // index into fis.Rules
+	ID       int     // stable rule ID
+	Strength float64 // weighted firing strength
+}
+
+// SetLogger installs a function that receives an InferRecord after every Infer
+// call, including failed ones. Pass nil to disable logging; without a logger
+// Infer does no extra work. InferWith is not logged.
+func (fis *MamdaniInferenceSystem) SetLogger(logger func(InferRecord)) {
+	fis.mu.Lock()
+	defer fis.mu.Unlock()
+	fis.logger = logger
+}
+
+// inferLogged performs Infer on ws and reports the run to the installed logger
+func (fis *MamdaniInferenceSystem) inferLogged(ws *InferenceWorkspace, inputs map[string]float64) (map[string]float64, error) {
+	start := time.Now()
+	record := InferRecord{Inputs: copyValues(inputs)}
+	ws.sink = fis.metrics
+
+	var results map[string]float64
+	err := fis.fire(ws, inputs)
+	if err == nil {
+		record.Fuzzification = make(map[string]map[string]float64, len(ws.membershipMap))
+		for name, degrees := range ws.membershipMap {
+			record.Fuzzification[name] = copyValues(degrees)
+		}
+		for i, strength := range ws.ruleStrengths {
+			if strength > 0 {
+				record.FiredRules = append(record.FiredRules, FiredRule{Index: i, ID: fis.Rules[i].ID, Strength: strength})
+			}
+		}
+		results, err = fis.defuzzifyAll(ws)
+		if err == nil {
+			record.Outputs = copyValues(results)
+		}
+	}
+
+	record.Duration = time.Since(start)
+	record.Err = err
+	fis.logger(record)
+	return results, err
+}
+
+// copyValues returns a shallow copy of m
+func copyValues[V any](m map[string]V) map[string]V {
+	out := make(map[string]V, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,60 @@
+package inference
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSetLogger(t *testing.T) {
+	fis := newTempFanSystem(t)
+
+	var records []InferRecord
+	fis.SetLogger(func(r InferRecord) { records = append(records, r) })
+
+	results, err := fis.Infer(map[string]float64{"Temperature": 45})
+	if err != nil {
+		t.Fatalf("Infer failed: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("Expected 1 record, got %d", len(records))
+	}
+
+	rec := records[0]
+	if rec.Err != nil {
+		t.Errorf("Expected no error in record, got %v", rec.Err)
+	}
+	if rec.Inputs["Temperature"] != 45 {
+		t.Errorf("Expected record input Temperature=45, got %v", rec.Inputs)
+	}
+	if !floatEqual(rec.Outputs["FanSpeed"], results["FanSpeed"]) {
+		t.Errorf("Expected record output %f, got %v", results["FanSpeed"], rec.Outputs)
+	}
+	if rec.Fuzzification["Temperature"]["Hot"] <= 0 {
+		t.Errorf("Expected Hot membership in record, got %v", rec.Fuzzification)
+	}
+	if len(rec.FiredRules) != 1 || rec.FiredRules[0].Index != 2 || rec.FiredRules[0].ID != fis.Rules[2].ID {
+		t.Errorf("Expected only the Hot rule to fire, got %+v", rec.FiredRules)
+	}
+	if rec.Duration < 0 {
+		t.Errorf("Expected non-negative duration, got %v", rec.Duration)
+	}
+
+	// Failed runs are logged too
+	if _, err := fis.Infer(map[string]float64{}); err == nil {
+		t.Fatal("Expected error for missing input, got nil")
+	}
+	if len(records) != 2 || !errors.Is(records[1].Err, ErrMissingInput) {
+		t.Fatalf("Expected failed run to be logged with ErrMissingInput, got %+v", records)
+	}
+	if records[1].Outputs != nil || records[1].Fuzzification != nil {
+		t.Errorf("Expected no fuzzification or outputs for rejected inputs, got %+v", records[1])
+	}
+
+	fis.SetLogger(nil)
+	if _, err := fis.Infer(map[string]float64{"Temperature": 45}); err != nil {
+		t.Fatalf("Infer failed: %v", err)
+	}
+	if len(records) != 2 {
+		t.Errorf("Expected no record after removing the logger, got %d records", len(records))
+	}
+}
@@ -0,0 +1,203 @@
+package inference
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/loian/fuzzylib/operators"
+	"github.com/loian/fuzzylib/rule"
+)
+
+// ruleJSON is the serialized form of a rule used by MarshalRules and UnmarshalRules
+type ruleJSON struct {
+	Conditions         []conditionJSON   `json:"conditions,omitempty"`
+	Groups             [][]conditionJSON `json:"groups,omitempty"`
+	Outputs            []conditionJSON   `json:"outputs"`
+	Operator           string            `json:"operator"`
+	GroupOperator      string            `json:"group_operator,omitempty"`
+	Complement         string            `json:"complement,omitempty"` // absent means the standard NOT
+	Weight             *float64          `json:"weight,omitempty"`     // absent means 1.0
+	ConditionWeighting string            `json:"condition_weighting,omitempty"`
+	Inhibitory         bool              `json:"inhibitory,omitempty"`
+}
+
+// conditionJSON is the serialized form of a rule condition or consequent
+type conditionJSON struct {
+	Variable string  `json:"variable"`
+	Set      string  `json:"set"`
+	Negated  bool    `json:"negated,omitempty"`
+	Weight   float64 `json:"weight,omitempty"`
+}
+
+// MarshalRules encodes rules as JSON, independently of the variables they reference,
+// so a rule base can be stored apart from a shared variable library. Operators are
+// stored by their registered name, or with their parameter for parameterized ones
+// such as "hamacher_and:0.5" (see operators.NameOf); so is a non-standard
+// complement, e.g. "yager_not:2". Rule IDs are not stored; they are assigned
+// again when the rules are added to a system.
+// Returns error if a rule uses an operator that is neither registered nor parameterized.
+func MarshalRules(rules []*rule.Rule) ([]byte, error) {
+	encoded := make([]ruleJSON, len(rules))
+	for i, r := range rules {
+		rj, err := encodeRule(r)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i+1, err)
+		}
+		encoded[i] = rj
+	}
+	return json.Marshal(encoded)
+}
+
+// UnmarshalRules decodes rules written by MarshalRules. References to variables and
+// sets are not checked; adding the rules to a system with AddRule validates them.
+// Returns error if data is not valid JSON, an operator name is unknown, or a rule
+// is malformed (no outputs, empty names, weights out of range).
+func UnmarshalRules(data []byte) ([]*rule.Rule, error) {
+	var encoded []ruleJSON
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return nil, fmt.Errorf("invalid rules JSON: %w", err)
+	}
+	rules := make([]*rule.Rule, len(encoded))
+	for i, rj := range encoded {
+		r, err := decodeRule(rj)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i+1, err)
+		}
+		rules[i] = r
+	}
+	return rules, nil
+}
+
+// encodeRule converts r to its serialized form
+func encodeRule(r *rule.Rule) (ruleJSON, error) {
+	op, err := operatorName(r.Operator)
+	if err != nil {
+		return ruleJSON{}, err
+	}
+	weight := r.Weight
+	rj := ruleJSON{
+		Conditions:         encodeConditions(r.Conditions),
+		Outputs:            encodeConditions(r.Outputs()),
+		Operator:           op,
+		Weight:             &weight,
+		ConditionWeighting: r.ConditionWeighting,
+		Inhibitory:         r.Inhibitory,
+	}
+	if rj.Complement, err = complementName(r.Complement); err != nil {
+		return ruleJSON{}, err
+	}
+	if len(r.Groups) > 0 {
+		if rj.GroupOperator, err = operatorName(r.GroupOperator); err != nil {
+			return ruleJSON{}, err
+		}
+		for _, g := range r.Groups {
+			rj.Groups = append(rj.Groups, encodeConditions(g.Conditions))
+		}
+	}
+	return rj, nil
+}
+
+// decodeRule rebuilds a rule from its serialized form
+func decodeRule(rj ruleJSON) (*rule.Rule, error) {
+	if len(rj.Outputs) == 0 {
+		return nil, fmt.Errorf("rule must have at least one output")
+	}
+	op, err := operators.ByName(rj.Operator)
+	if err != nil {
+		return nil, err
+	}
+	first := rj.Outputs[0]
+	r, err := rule.NewRule(rule.RuleCondition{Variable: first.Variable, Set: first.Set, Negated: first.Negated}, op)
+	if err != nil {
+		return nil, err
+	}
+	for _, out := range rj.Outputs[1:] {
+		if err := r.AddOutput(out.Variable, out.Set); err != nil {
+			return nil, err
+		}
+	}
+	for _, c := range rj.Conditions {
+		if c.Weight != 0 {
+			err = r.AddWeightedCondition(c.Variable, c.Set, c.Negated, c.Weight)
+		} else {
+			err = r.AddConditionEx(c.Variable, c.Set, c.Negated)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(rj.Groups) > 0 {
+		if rj.GroupOperator != "" {
+			if r.GroupOperator, err = operators.ByName(rj.GroupOperator); err != nil {
+				return nil, err
+			}
+		}
+		for _, g := range rj.Groups {
+			if err := r.AddGroup(decodeConditions(g)...); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if rj.Complement != "" {
+		complement, err := operators.ByName(rj.Complement)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.SetComplement(complement); err != nil {
+			return nil, err
+		}
+	}
+	if rj.Weight != nil {
+		if err := r.SetWeight(*rj.Weight); err != nil {
+			return nil, err
+		}
+	}
+	r.ConditionWeighting = rj.ConditionWeighting
+	r.Inhibitory = rj.Inhibitory
+	return r, nil
+}
+
+// operatorName returns the name of op (see operators.NameOf), treating nil as the default AND
+func operatorName(op operators.Operator) (string, error) {
+	if op == nil {
+		op = operators.AND
+	}
+	name, ok := operators.NameOf(op)
+	if !ok {
+		return "", fmt.Errorf("operator %T is not registered", op)
+	}
+	return name, nil
+}
+
+// complementName returns the name of a rule's complement (see operators.NameOf), or
+// "" for nil and the standard NOT, which computes the same 1 - degree
+func complementName(op operators.Operator) (string, error) {
+	if _, standard := op.(*operators.NotOperator); op == nil || standard {
+		return "", nil
+	}
+	name, ok := operators.NameOf(op)
+	if !ok {
+		return "", fmt.Errorf("complement %T is not registered", op)
+	}
+	return name, nil
+}
+
+// encodeConditions converts conditions to their serialized form
+func encodeConditions(conds []rule.RuleCondition) []conditionJSON {
+	if len(conds) == 0 {
+		return nil
+	}
+	encoded := make([]conditionJSON, len(conds))
+	for i, c := range conds {
+		encoded[i] = conditionJSON{Variable: c.Variable, Set: c.Set, Negated: c.Negated, Weight: c.Weight}
+	}
+	return encoded
+}
+
+// decodeConditions converts serialized conditions back to rule conditions
+func decodeConditions(encoded []conditionJSON) []rule.RuleCondition {
+	conds := make([]rule.RuleCondition, len(encoded))
+	for i, c := range encoded {
+		conds[i] = rule.RuleCondition{Variable: c.Variable, Set: c.Set, Negated: c.Negated, Weight: c.Weight}
+	}
+	return conds
+}
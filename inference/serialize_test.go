@@ -0,0 +1,115 @@
+package inference
+
+import (
+	"errors"
+	"github.com/loian/fuzzylib/operators"
+	"github.com/loian/fuzzylib/rule"
+	"reflect"
+	"testing"
+)
+
+func TestMarshalRules_RoundTrip(t *testing.T) {
+	fis := newTempFanSystem(t)
+	_ = fis.SetDefuzzificationMethod(DefuzzCOG)
+
+	// A rule exercising negation with a Yager complement, condition weights, rule weight and a named operator
+	extra, _ := rule.NewRule(rule.RuleCondition{Variable: "FanSpeed", Set: "Medium"}, operators.PROBOR)
+	_ = extra.AddConditionEx("Temperature", "Cold", true)
+	_ = extra.AddWeightedCondition("Temperature", "Hot", false, 0.5)
+	_ = extra.SetWeight(0.4)
+	extra.ConditionWeighting = rule.ConditionWeightImportance
+	yager, _ := operators.NewYagerComplement(2)
+	_ = extra.SetComplement(yager)
+	if err := fis.AddRule(extra); err != nil {
+		t.Fatalf("AddRule failed: %v", err)
+	}
+
+	data, err := MarshalRules(fis.Rules)
+	if err != nil {
+		t.Fatalf("MarshalRules failed: %v", err)
+	}
+	rules, err := UnmarshalRules(data)
+	if err != nil {
+		t.Fatalf("UnmarshalRules failed: %v", err)
+	}
+	if len(rules) != len(fis.Rules) {
+		t.Fatalf("Expected %d rules, got %d", len(fis.Rules), len(rules))
+	}
+	for i, r := range rules {
+		want := *fis.Rules[i]
+		want.ID = 0
+		if !reflect.DeepEqual(*r, want) {
+			t.Errorf("Rule %d mismatch:\n got      %+v\n expected %+v", i+1, *r, want)
+		}
+	}
+
+	// Load the rules into a second system sharing the same variables
+	loaded := newTempFanSystem(t)
+	_ = loaded.SetDefuzzificationMethod(DefuzzCOG)
+	loaded.Rules = nil
+	for _, r := range rules {
+		if err := loaded.AddRule(r); err != nil {
+			t.Fatalf("AddRule failed: %v", err)
+		}
+	}
+	for _, temp := range []float64{5, 18, 27, 36, 48} {
+		inputs := map[string]float64{"Temperature": temp}
+		want, _ := fis.Infer(inputs)
+		got, err := loaded.Infer(inputs)
+		if err != nil {
+			t.Fatalf("Infer failed on loaded rules: %v", err)
+		}
+		if !floatEqual(got["FanSpeed"], want["FanSpeed"]) {
+			t.Errorf("Temperature %f: expected %f, got %f", temp, want["FanSpeed"], got["FanSpeed"])
+		}
+	}
+}
+
+func TestMarshalRules_Groups(t *testing.T) {
+	r, _ := rule.NewRule(rule.RuleCondition{Variable: "FanSpeed", Set: "High"}, operators.OR)
+	_ = r.AddGroup(rule.RuleCondition{Variable: "Temperature", Set: "Hot"}, rule.RuleCondition{Variable: "Humidity", Set: "Wet"})
+	_ = r.AddGroup(rule.RuleCondition{Variable: "Temperature", Set: "VeryHot"})
+	_ = r.AddOutput("Vent", "Open")
+	r.GroupOperator, _ = operators.NewHamacherAnd(0.5)
+
+	data, err := MarshalRules([]*rule.Rule{r})
+	if err != nil {
+		t.Fatalf("MarshalRules failed: %v", err)
+	}
+	rules, err := UnmarshalRules(data)
+	if err != nil {
+		t.Fatalf("UnmarshalRules failed: %v", err)
+	}
+	if !reflect.DeepEqual(rules[0], r) {
+		t.Errorf("Grouped rule mismatch:\n got      %+v\n expected %+v", rules[0], r)
+	}
+}
+
+func TestUnmarshalRules_Errors(t *testing.T) {
+	tests := map[string]string{
+		"invalid JSON":     `{`,
+		"no outputs":       `[{"operator":"min","conditions":[{"variable":"A","set":"B"}]}]`,
+		"unknown operator": `[{"operator":"xor","outputs":[{"variable":"Y","set":"High"}]}]`,
+		"weight too large": `[{"operator":"min","weight":2,"outputs":[{"variable":"Y","set":"High"}]}]`,
+	}
+	for name, data := range tests {
+		if _, err := UnmarshalRules([]byte(data)); err == nil {
+			t.Errorf("%s: expected error, got nil", name)
+		}
+	}
+	if _, err := UnmarshalRules([]byte(tests["unknown operator"])); !errors.Is(err, operators.ErrUnknownOperator) {
+		t.Errorf("Expected ErrUnknownOperator, got %v", err)
+	}
+
+	type unregistered struct{ operators.MinOperator }
+	r, _ := rule.NewRule(rule.RuleCondition{Variable: "Y", Set: "High"}, &unregistered{})
+	if _, err := MarshalRules([]*rule.Rule{r}); err == nil {
+		t.Error("Expected error marshalling an unregistered operator")
+	}
+
+	r, _ = rule.NewRule(rule.RuleCondition{Variable: "Y", Set: "High"}, operators.AND)
+	_ = r.SetComplement(&unregistered{})
+	if _, err := MarshalRules([]*rule.Rule{r}); err == nil {
+		t.Error("Expected error marshalling an unregistered complement")
+	}
+}
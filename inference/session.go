@@ -0,0 +1,90 @@
+package inference
+
+import (
+	"fmt"
+	"math"
+)
+
+// Session runs a system over a stream of inputs, carrying state between steps:
+// outputs are exponentially smoothed, and changes smaller than the deadband are
+// held back (hysteresis) so that a noisy input does not make the output chatter.
+// A Session is not safe for concurrent use.
+type Session struct {
+	fis       *MamdaniInferenceSystem
+	smoothing float64            // weight of the newest raw output, in (0, 1]
+	deadband  float64            // smallest output change that is passed on
+	state     map[string]float64 // last emitted outputs, nil before the first step
+}
+
+// NewSession creates a session over fis. smoothing is the weight of each new raw
+// output in the exponential moving average: 1 disables smoothing, smaller values
+// react more slowly.
+// Returns error if fis is nil or smoothing is not in (0, 1].
+func NewSession(fis *MamdaniInferenceSystem, smoothing float64) (*Session, error) {
+	if fis == nil {
+		return nil, fmt.Errorf("session requires an inference system")
+	}
+	if !(smoothing > 0 && smoothing <= 1) {
+		return nil, fmt.Errorf("smoothing must be in range (0, 1], got %v", smoothing)
+	}
+	return &Session{fis: fis, smoothing: smoothing}, nil
+}
+
+// SetDeadband sets the hysteresis band: a smoothed output that differs from the
+// previously emitted value by less than deadband keeps the previous value.
+// Returns error if deadband is negative or not finite.
+func (s *Session) SetDeadband(deadband float64) error {
+	if math.IsNaN(deadband) || math.IsInf(deadband, 0) || deadband < 0 {
+		return fmt.Errorf("deadband must be a finite number >= 0, got %v", deadband)
+	}
+	s.deadband = deadband
+	return nil
+}
+
+// Update runs one step: it infers the raw outputs for inputs, blends them into
+// the session state and returns a copy of the new state. The first step after
+// creation or Reset emits the raw outputs unchanged.
+// Returns error for any reason Infer would; the state is then left unchanged.
+func (s *Session) Update(inputs map[string]float64) (map[string]float64, error) {
+	raw, err := s.fis.Infer(inputs)
+	if err != nil {
+		return nil, err
+	}
+	if s.state == nil {
+		s.state = copyValues(raw)
+		return copyValues(s.state), nil
+	}
+	for name, value := range raw {
+		prev, ok := s.state[name]
+		if !ok {
+			s.state[name] = value
+			continue
+		}
+		next := prev + s.smoothing*(value-prev)
+		if math.Abs(next-prev) >= s.deadband {
+			s.state[name] = next
+		}
+	}
+	return copyValues(s.state), nil
+}
+
+// Run feeds series through Update in order and returns the output of every
+// step, the batch form of calling Update in a loop. State carries over from
+// earlier calls; call Reset first to start from scratch.
+// Returns error naming the failing step if any step fails.
+func (s *Session) Run(series []map[string]float64) ([]map[string]float64, error) {
+	outputs := make([]map[string]float64, len(series))
+	for step, inputs := range series {
+		out, err := s.Update(inputs)
+		if err != nil {
+			return nil, fmt.Errorf("step %d: %w", step, err)
+		}
+		outputs[step] = out
+	}
+	return outputs, nil
+}
+
+// Reset discards the session state, so the next step emits raw outputs
+func (s *Session) Reset() {
+	s.state = nil
+}
@@ -0,0 +1,98 @@
+package inference
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestSession_RunRamp(t *testing.T) {
+	fis := newTempFanSystem(t)
+	_ = fis.SetDefuzzificationMethod(DefuzzCOG)
+
+	// A rising temperature ramp: Cold->Low, Warm->Medium, Hot->High
+	var series []map[string]float64
+	for temp := 2.5; temp <= 50; temp += 2.5 {
+		series = append(series, map[string]float64{"Temperature": temp})
+	}
+
+	session, err := NewSession(fis, 0.5)
+	if err != nil {
+		t.Fatalf("NewSession failed: %v", err)
+	}
+	outputs, err := session.Run(series)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(outputs) != len(series) {
+		t.Fatalf("Expected %d outputs, got %d", len(series), len(outputs))
+	}
+	for i := 1; i < len(outputs); i++ {
+		if outputs[i]["FanSpeed"] < outputs[i-1]["FanSpeed"]-1e-9 {
+			t.Errorf("Step %d: output fell from %f to %f on a rising ramp", i, outputs[i-1]["FanSpeed"], outputs[i]["FanSpeed"])
+		}
+	}
+
+	// Smoothing lags behind the raw output at the top of the ramp
+	raw, _ := fis.Infer(series[len(series)-1])
+	if last := outputs[len(outputs)-1]["FanSpeed"]; last >= raw["FanSpeed"] {
+		t.Errorf("Expected smoothed output %f below raw output %f", last, raw["FanSpeed"])
+	}
+
+	// Run matches calling Update step by step
+	session.Reset()
+	for i, inputs := range series {
+		out, err := session.Update(inputs)
+		if err != nil {
+			t.Fatalf("Update failed: %v", err)
+		}
+		if !floatEqual(out["FanSpeed"], outputs[i]["FanSpeed"]) {
+			t.Errorf("Step %d: Update gave %f, Run gave %f", i, out["FanSpeed"], outputs[i]["FanSpeed"])
+		}
+	}
+}
+
+func TestSession_Deadband(t *testing.T) {
+	fis := newTempFanSystem(t)
+	session, _ := NewSession(fis, 1)
+	if err := session.SetDeadband(5); err != nil {
+		t.Fatalf("SetDeadband failed: %v", err)
+	}
+
+	outputs, err := session.Run([]map[string]float64{
+		{"Temperature": 25},
+		{"Temperature": 26}, // small change: held
+		{"Temperature": 45}, // large change: passed on
+	})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if outputs[1]["FanSpeed"] != outputs[0]["FanSpeed"] {
+		t.Errorf("Expected a small change to be held at %f, got %f", outputs[0]["FanSpeed"], outputs[1]["FanSpeed"])
+	}
+	raw, _ := fis.Infer(map[string]float64{"Temperature": 45})
+	if !floatEqual(outputs[2]["FanSpeed"], raw["FanSpeed"]) {
+		t.Errorf("Expected a large change to pass through as %f, got %f", raw["FanSpeed"], outputs[2]["FanSpeed"])
+	}
+}
+
+func TestSession_Errors(t *testing.T) {
+	fis := newTempFanSystem(t)
+	for _, smoothing := range []float64{0, -0.5, 1.5, math.NaN()} {
+		if _, err := NewSession(fis, smoothing); err == nil {
+			t.Errorf("Expected error for smoothing %v, got nil", smoothing)
+		}
+	}
+	if _, err := NewSession(nil, 1); err == nil {
+		t.Error("Expected error for nil system, got nil")
+	}
+
+	session, _ := NewSession(fis, 1)
+	if err := session.SetDeadband(-1); err == nil {
+		t.Error("Expected error for negative deadband, got nil")
+	}
+	_, err := session.Run([]map[string]float64{{"Temperature": 25}, {}})
+	if !errors.Is(err, ErrMissingInput) {
+		t.Errorf("Expected ErrMissingInput from the failing step, got %v", err)
+	}
+}
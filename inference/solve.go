@@ -0,0 +1,103 @@
+package inference
+
+import (
+	"fmt"
+	"math"
+)
+
+// SolveTolerance is the maximum normalized output error accepted by SolveInput.
+// The error of a candidate is the largest |output - target| across the target
+// variables, divided by that output variable's domain width.
+var SolveTolerance = 0.01
+
+// solveGridIntervals is the number of intervals in SolveInput's coarse scan
+const solveGridIntervals = 100
+
+// solveRefineIterations is the number of local zoom passes around the best candidate
+const solveRefineIterations = 20
+
+// SolveInput searches freeVar's domain for the value that makes the system's
+// outputs match target as closely as possible (inverse inference), e.g. "what
+// Temperature gives FanSpeed=80?". All other inputs are taken from fixed.
+//
+// The search scans the domain on a coarse grid and then refines around the best
+// candidate, so it finds the global best on the grid but may miss very narrow
+// optima. Candidates for which Infer fails (e.g. no rules fire) are skipped.
+//
+// Returns error if freeVar is not an input variable or is also listed in fixed,
+// if target is empty or names unknown output variables, or if no value gets
+// within SolveTolerance of the target.
+func (fis *MamdaniInferenceSystem) SolveInput(target map[string]float64, freeVar string, fixed map[string]float64) (float64, error) {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	inputVar, exists := fis.InputVariables[freeVar]
+	if !exists {
+		return 0, fmt.Errorf("free variable '%s' is not an input variable", freeVar)
+	}
+	if _, exists := fixed[freeVar]; exists {
+		return 0, fmt.Errorf("free variable '%s' must not be listed in fixed inputs", freeVar)
+	}
+	if len(target) == 0 {
+		return 0, fmt.Errorf("target must specify at least one output variable")
+	}
+	for name := range target {
+		if _, exists := fis.OutputVariables[name]; !exists {
+			return 0, fmt.Errorf("target references non-existent output variable '%s'", name)
+		}
+	}
+
+	inputs := make(map[string]float64, len(fixed)+1)
+	for name, value := range fixed {
+		inputs[name] = value
+	}
+
+	// cost returns the normalized output error at x, or +Inf if inference fails
+	cost := func(x float64) float64 {
+		inputs[freeVar] = x
+		outputs, err := fis.infer(inputs)
+		if err != nil {
+			return math.Inf(1)
+		}
+		worst := 0.0
+		for name, want := range target {
+			outputVar := fis.OutputVariables[name]
+			diff := math.Abs(outputs[name]-want) / (outputVar.MaxValue - outputVar.MinValue)
+			if diff > worst {
+				worst = diff
+			}
+		}
+		return worst
+	}
+
+	lo, hi := inputVar.MinValue, inputVar.MaxValue
+	step := (hi - lo) / solveGridIntervals
+	bestX, bestCost := lo, math.Inf(1)
+	for i := 0; i <= solveGridIntervals; i++ {
+		x := lo + float64(i)*step
+		if c := cost(x); c < bestCost {
+			bestX, bestCost = x, c
+		}
+	}
+
+	// Zoom in around the best grid point
+	for iter := 0; iter < solveRefineIterations && !math.IsInf(bestCost, 1); iter++ {
+		left := math.Max(lo, bestX-step)
+		right := math.Min(hi, bestX+step)
+		step = (right - left) / 10
+		for i := 0; i <= 10; i++ {
+			x := left + float64(i)*step
+			if c := cost(x); c < bestCost {
+				bestX, bestCost = x, c
+			}
+		}
+	}
+
+	if bestCost > SolveTolerance {
+		if math.IsInf(bestCost, 1) {
+			return 0, fmt.Errorf("no value of '%s' produced a valid inference", freeVar)
+		}
+		return 0, fmt.Errorf("no value of '%s' reaches the target within tolerance %.4f (best %.4f at %.4f)",
+			freeVar, SolveTolerance, bestCost, bestX)
+	}
+	return bestX, nil
+}
@@ -0,0 +1,59 @@
+package inference
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSolveInput(t *testing.T) {
+	fis := newTempFanSystem(t)
+	_ = fis.SetDefuzzificationMethod(DefuzzCOG)
+
+	want, err := fis.Infer(map[string]float64{"Temperature": 35})
+	if err != nil {
+		t.Fatalf("Infer failed: %v", err)
+	}
+
+	temp, err := fis.SolveInput(map[string]float64{"FanSpeed": want["FanSpeed"]}, "Temperature", nil)
+	if err != nil {
+		t.Fatalf("SolveInput failed: %v", err)
+	}
+
+	got, err := fis.Infer(map[string]float64{"Temperature": temp})
+	if err != nil {
+		t.Fatalf("Infer at solved temperature %f failed: %v", temp, err)
+	}
+	if math.Abs(got["FanSpeed"]-want["FanSpeed"]) > SolveTolerance*100 {
+		t.Errorf("Solved temperature %f yields FanSpeed %f, target %f", temp, got["FanSpeed"], want["FanSpeed"])
+	}
+	if math.Abs(temp-35) > 1 {
+		t.Errorf("Expected temperature near 35, got %f", temp)
+	}
+}
+
+func TestSolveInput_Unreachable(t *testing.T) {
+	fis := newTempFanSystem(t)
+	_ = fis.SetDefuzzificationMethod(DefuzzCOG)
+
+	// COG never reaches the domain edge
+	if _, err := fis.SolveInput(map[string]float64{"FanSpeed": 100}, "Temperature", nil); err == nil {
+		t.Error("Expected error for unreachable target, got nil")
+	}
+}
+
+func TestSolveInput_Validation(t *testing.T) {
+	fis := newTempFanSystem(t)
+
+	if _, err := fis.SolveInput(map[string]float64{"FanSpeed": 50}, "Unknown", nil); err == nil {
+		t.Error("Expected error for unknown free variable, got nil")
+	}
+	if _, err := fis.SolveInput(map[string]float64{"FanSpeed": 50}, "Temperature", map[string]float64{"Temperature": 1}); err == nil {
+		t.Error("Expected error when free variable is fixed, got nil")
+	}
+	if _, err := fis.SolveInput(nil, "Temperature", nil); err == nil {
+		t.Error("Expected error for empty target, got nil")
+	}
+	if _, err := fis.SolveInput(map[string]float64{"Unknown": 50}, "Temperature", nil); err == nil {
+		t.Error("Expected error for unknown target variable, got nil")
+	}
+}
@@ -0,0 +1,208 @@
+package inference
+
+import (
+	"fmt"
+	"github.com/loian/fuzzylib/membership"
+	"github.com/loian/fuzzylib/rule"
+	"github.com/loian/fuzzylib/variable"
+)
+
+// SugenoInferenceSystem is a Sugeno (TSK) FIS. A rule concludes either a zero-order
+// output set, a singleton standing for a constant c, e.g. membership.NewTriangular(c, c, c),
+// or a first-order set registered with AddLinearOutputSet, whose value is a linear
+// function of the crisp inputs. An output's value is the firing-strength-weighted
+// average (or, with SetAggregation, sum) of the values concluded by the fired rules.
+// No defuzzification sampling is involved. Inhibitory rules are not supported.
+type SugenoInferenceSystem struct {
+	fis *MamdaniInferenceSystem // holds variables and rules, and performs rule firing
+	// linear holds first-order consequents by output variable and set name
+	linear map[string]map[string]*LinearConsequent
+	// aggregation is SugenoWeightedAverage or SugenoWeightedSum
+	aggregation string
+}
+
+// Sugeno output aggregation methods, named as in MATLAB .fis files
+const (
+	SugenoWeightedAverage = "wtaver" // Divide the weighted sum by the total firing strength (default)
+	SugenoWeightedSum     = "wtsum"  // Use the weighted sum as is
+)
+
+// NewSugenoInferenceSystem creates a new Sugeno inference system
+func NewSugenoInferenceSystem() *SugenoInferenceSystem {
+	return &SugenoInferenceSystem{
+		fis:         NewMamdaniInferenceSystem(),
+		linear:      make(map[string]map[string]*LinearConsequent),
+		aggregation: SugenoWeightedAverage,
+	}
+}
+
+// SetAggregation sets how the firing-strength-weighted consequents are combined:
+// "wtaver" divides their sum by the total firing strength, "wtsum" does not, so
+// outputs shrink when the strengths sum to less than 1.
+// Returns error if method is not recognized.
+func (s *SugenoInferenceSystem) SetAggregation(method string) error {
+	s.fis.mu.Lock()
+	defer s.fis.mu.Unlock()
+	switch method {
+	case SugenoWeightedAverage, SugenoWeightedSum:
+		s.aggregation = method
+		return nil
+	}
+	return fmt.Errorf("invalid sugeno aggregation '%s': must be 'wtaver' or 'wtsum'", method)
+}
+
+// LinearConsequent is a first-order Sugeno consequent
+// z = Constant + Σ Coefficients[x] * x over the named input variables.
+// Build one with NewLinearConsequent and Coefficient:
+//
+//	flow := inference.NewLinearConsequent(2).Coefficient("Level", 0.5).Coefficient("Valve", -1)
+type LinearConsequent struct {
+	Constant     float64            // p0
+	Coefficients map[string]float64 // input variable name -> coefficient
+}
+
+// NewLinearConsequent creates a linear consequent with the given constant term and no coefficients
+func NewLinearConsequent(constant float64) *LinearConsequent {
+	return &LinearConsequent{Constant: constant, Coefficients: make(map[string]float64)}
+}
+
+// Coefficient sets the coefficient of the named input variable and returns c for chaining
+func (c *LinearConsequent) Coefficient(input string, p float64) *LinearConsequent {
+	c.Coefficients[input] = p
+	return c
+}
+
+// Evaluate returns the consequent's value for the given crisp inputs; inputs
+// missing from the map count as 0
+func (c *LinearConsequent) Evaluate(inputs map[string]float64) float64 {
+	z := c.Constant
+	for name, p := range c.Coefficients {
+		z += p * inputs[name]
+	}
+	return z
+}
+
+// AddLinearOutputSet registers a first-order output set named name on the output
+// variable output, so that rules can conclude "output IS name". The coefficients
+// must reference input variables already added to the system.
+// Returns error if c is nil, name is empty, the output variable does not exist, the
+// name is already used by a set of the variable, or a coefficient references an
+// undeclared input variable.
+func (s *SugenoInferenceSystem) AddLinearOutputSet(output, name string, c *LinearConsequent) error {
+	s.fis.mu.Lock()
+	defer s.fis.mu.Unlock()
+	if c == nil {
+		return fmt.Errorf("linear consequent cannot be nil")
+	}
+	if name == "" {
+		return fmt.Errorf("output set name cannot be empty")
+	}
+	outputVar, exists := s.fis.OutputVariables[output]
+	if !exists {
+		return fmt.Errorf("output variable '%s' does not exist", output)
+	}
+	if _, exists := outputVar.Sets[name]; exists {
+		return fmt.Errorf("output set '%s' already exists in variable '%s'", name, output)
+	}
+	if _, exists := s.linear[output][name]; exists {
+		return fmt.Errorf("output set '%s' already exists in variable '%s'", name, output)
+	}
+	for input := range c.Coefficients {
+		if _, exists := s.fis.InputVariables[input]; !exists {
+			return fmt.Errorf("linear consequent '%s.%s' references non-existent input variable '%s'", output, name, input)
+		}
+	}
+	if s.linear[output] == nil {
+		s.linear[output] = make(map[string]*LinearConsequent)
+	}
+	s.linear[output][name] = c
+	return nil
+}
+
+// AddInputVariable adds an input variable to the system.
+// Returns error if variable with same name already exists.
+func (s *SugenoInferenceSystem) AddInputVariable(v *variable.FuzzyVariable) error {
+	return s.fis.AddInputVariable(v)
+}
+
+// AddOutputVariable adds an output variable to the system. Its sets are checked
+// to be singletons when a rule references them.
+// Returns error if variable with same name already exists.
+func (s *SugenoInferenceSystem) AddOutputVariable(v *variable.FuzzyVariable) error {
+	return s.fis.AddOutputVariable(v)
+}
+
+// AddRule adds a rule to the system and assigns it the next rule ID.
+// Returns error for any reason MamdaniInferenceSystem.AddRule would, if the rule
+// is inhibitory, or if any of its output sets is neither a singleton nor a
+// registered linear set.
+func (s *SugenoInferenceSystem) AddRule(r *rule.Rule) error {
+	s.fis.mu.Lock()
+	defer s.fis.mu.Unlock()
+	if r.Inhibitory {
+		return fmt.Errorf("sugeno inference does not support inhibitory rules")
+	}
+	if err := s.fis.validateConditions(r); err != nil {
+		return err
+	}
+	for _, out := range r.Outputs() {
+		outputVar, err := s.fis.validateOutputVariable(out)
+		if err != nil {
+			return err
+		}
+		if _, linear := s.linear[out.Variable][out.Set]; linear {
+			continue
+		}
+		fs, exists := outputVar.Sets[out.Set]
+		if !exists {
+			return fmt.Errorf("rule references non-existent output set '%s' in variable '%s'", out.Set, out.Variable)
+		}
+		if _, ok := singletonValue(fs.MembershipFunc); !ok {
+			return fmt.Errorf("output set '%s.%s' is not a singleton: zero-order sugeno consequents must be constants", out.Variable, out.Set)
+		}
+	}
+	if err := s.fis.defaultOperator(r); err != nil {
+		return err
+	}
+	s.fis.appendRule(r)
+	return nil
+}
+
+// Rules returns the rules of the system in the order they were added
+func (s *SugenoInferenceSystem) Rules() []*rule.Rule {
+	return s.fis.Rules
+}
+
+// Infer performs Sugeno inference. Linear consequents are evaluated at the crisp inputs.
+// inputs: map[variableName]crispValue
+// returns: map[variableName]crispOutput, error
+// Returns error if the system is not configured, inputs are missing or out of
+// bounds, or no rule concluding some output fired (ErrNoRulesFired).
+func (s *SugenoInferenceSystem) Infer(inputs map[string]float64) (map[string]float64, error) {
+	s.fis.mu.RLock()
+	defer s.fis.mu.RUnlock()
+	ws := s.fis.newWorkspace()
+	if err := s.fis.fire(ws, inputs); err != nil {
+		return nil, err
+	}
+	return weightedRuleAverage(s.fis, ws, s.aggregation != SugenoWeightedSum, func(outputVar *variable.FuzzyVariable, setName string, strength float64) (float64, error) {
+		if linear, ok := s.linear[outputVar.Name][setName]; ok {
+			return linear.Evaluate(ws.scaledInputs), nil
+		}
+		c, ok := singletonValue(outputVar.Sets[setName].MembershipFunc)
+		if !ok {
+			return 0, fmt.Errorf("output set '%s.%s' is not a singleton", outputVar.Name, setName)
+		}
+		return c, nil
+	})
+}
+
+// singletonValue returns the single point at which mf is non-zero, or false if
+// its support is unbounded or wider than a point
+func singletonValue(mf membership.MembershipFunction) (float64, bool) {
+	left, right, bounded := mf.Support()
+	if !bounded || left != right {
+		return 0, false
+	}
+	return left, true
+}
@@ -0,0 +1,183 @@
+package inference
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/loian/fuzzylib/membership"
+	"github.com/loian/fuzzylib/set"
+	"github.com/loian/fuzzylib/variable"
+)
+
+// newSugenoSystem builds a Temperature -> FanSpeed system with singleton outputs
+// Low=10 and High=90
+func newSugenoSystem(t *testing.T) *SugenoInferenceSystem {
+	t.Helper()
+	sug := NewSugenoInferenceSystem()
+
+	tempVar, _ := variable.NewFuzzyVariable("Temperature", 0, 50)
+	tempVar.AddSet(set.NewFuzzySet("Cold", mustMF(membership.NewTriangular(-50, 0, 50))))
+	tempVar.AddSet(set.NewFuzzySet("Hot", mustMF(membership.NewTriangular(0, 50, 100))))
+
+	fanVar, _ := variable.NewFuzzyVariable("FanSpeed", 0, 100)
+	fanVar.AddSet(set.NewFuzzySet("Low", mustMF(membership.NewTriangular(10, 10, 10))))
+	fanVar.AddSet(set.NewFuzzySet("High", mustMF(membership.NewTrapezoidal(90, 90, 90, 90))))
+	fanVar.AddSet(set.NewFuzzySet("Medium", mustMF(membership.NewTriangular(20, 50, 80))))
+
+	if err := sug.AddInputVariable(tempVar); err != nil {
+		t.Fatalf("AddInputVariable failed: %v", err)
+	}
+	if err := sug.AddOutputVariable(fanVar); err != nil {
+		t.Fatalf("AddOutputVariable failed: %v", err)
+	}
+	for _, pair := range [][2]string{{"Cold", "Low"}, {"Hot", "High"}} {
+		r, _ := NewRuleBuilder("FanSpeed", pair[1])
+		built, _ := r.If("Temperature", pair[0]).Build()
+		if err := sug.AddRule(built); err != nil {
+			t.Fatalf("AddRule failed: %v", err)
+		}
+	}
+	return sug
+}
+
+func TestSugenoInfer(t *testing.T) {
+	sug := newSugenoSystem(t)
+
+	tests := []struct {
+		temp     float64
+		expected float64
+	}{
+		{25, 50},              // 0.5*10 + 0.5*90
+		{10, 0.8*10 + 0.2*90}, // weights sum to 1
+		{50, 90},              // only Hot fires
+	}
+	for _, tt := range tests {
+		results, err := sug.Infer(map[string]float64{"Temperature": tt.temp})
+		if err != nil {
+			t.Fatalf("Infer(%v) failed: %v", tt.temp, err)
+		}
+		if math.Abs(results["FanSpeed"]-tt.expected) > 1e-9 {
+			t.Errorf("Infer(%v) = %f, expected %f", tt.temp, results["FanSpeed"], tt.expected)
+		}
+	}
+}
+
+func TestSugenoInfer_NoRulesFired(t *testing.T) {
+	sug := newSugenoSystem(t)
+	// Without the Hot rule nothing fires at 50, where Cold is 0
+	_ = sug.fis.RemoveRuleByID(sug.Rules()[1].ID)
+	if _, err := sug.Infer(map[string]float64{"Temperature": 50}); !errors.Is(err, ErrNoRulesFired) {
+		t.Errorf("Expected ErrNoRulesFired, got %v", err)
+	}
+}
+
+func TestSugenoAddRule_RejectsNonSingleton(t *testing.T) {
+	sug := newSugenoSystem(t)
+	r, _ := NewRuleBuilder("FanSpeed", "Medium")
+	built, _ := r.If("Temperature", "Hot").Build()
+	if err := sug.AddRule(built); err == nil {
+		t.Error("Expected error for non-singleton output set, got nil")
+	}
+}
+
+func TestSugenoInfer_FirstOrder(t *testing.T) {
+	sug := NewSugenoInferenceSystem()
+	level, _ := variable.NewFuzzyVariable("Level", 0, 10)
+	level.AddSet(set.NewFuzzySet("Low", mustMF(membership.NewTriangular(-10, 0, 10))))
+	level.AddSet(set.NewFuzzySet("High", mustMF(membership.NewTriangular(0, 10, 20))))
+	valve, _ := variable.NewFuzzyVariable("Valve", 0, 1)
+	valve.AddSet(set.NewFuzzySet("Open", mustMF(membership.NewTriangular(-1, 1, 3))))
+	flow, _ := variable.NewFuzzyVariable("Flow", -100, 100)
+	_ = sug.AddInputVariable(level)
+	_ = sug.AddInputVariable(valve)
+	_ = sug.AddOutputVariable(flow)
+
+	// Plant model: Flow = 2 + 3*Level - 4*Valve, concluded by both rules
+	plant := NewLinearConsequent(2).Coefficient("Level", 3).Coefficient("Valve", -4)
+	if err := sug.AddLinearOutputSet("Flow", "Plant", plant); err != nil {
+		t.Fatalf("AddLinearOutputSet failed: %v", err)
+	}
+	// Low-level regime flows at a fixed rate instead
+	if err := sug.AddLinearOutputSet("Flow", "Trickle", NewLinearConsequent(1)); err != nil {
+		t.Fatalf("AddLinearOutputSet failed: %v", err)
+	}
+
+	for _, pair := range [][2]string{{"Low", "Plant"}, {"High", "Plant"}} {
+		r, _ := NewRuleBuilder("Flow", pair[1])
+		built, _ := r.If("Level", pair[0]).Build()
+		if err := sug.AddRule(built); err != nil {
+			t.Fatalf("AddRule failed: %v", err)
+		}
+	}
+	for _, in := range []map[string]float64{{"Level": 2, "Valve": 0.5}, {"Level": 7.5, "Valve": 1}} {
+		results, err := sug.Infer(in)
+		if err != nil {
+			t.Fatalf("Infer(%v) failed: %v", in, err)
+		}
+		want := 2 + 3*in["Level"] - 4*in["Valve"]
+		if math.Abs(results["Flow"]-want) > 1e-9 {
+			t.Errorf("Infer(%v) = %f, expected the plant value %f", in, results["Flow"], want)
+		}
+	}
+
+	// Blending two consequents weights them by firing strength
+	sug.fis.Rules[0].Output.Set = "Trickle"
+	results, _ := sug.Infer(map[string]float64{"Level": 2, "Valve": 0})
+	want := 0.8*1 + 0.2*(2+3*2.0)
+	if math.Abs(results["Flow"]-want) > 1e-9 {
+		t.Errorf("Expected blended flow %f, got %f", want, results["Flow"])
+	}
+}
+
+func TestAddLinearOutputSet_Validation(t *testing.T) {
+	sug := newSugenoSystem(t)
+	if err := sug.AddLinearOutputSet("FanSpeed", "Ramp", NewLinearConsequent(0).Coefficient("Humidity", 1)); err == nil {
+		t.Error("Expected error for coefficient on undeclared input, got nil")
+	}
+	if err := sug.AddLinearOutputSet("Unknown", "Ramp", NewLinearConsequent(0)); err == nil {
+		t.Error("Expected error for unknown output variable, got nil")
+	}
+	if err := sug.AddLinearOutputSet("FanSpeed", "Low", NewLinearConsequent(0)); err == nil {
+		t.Error("Expected error for name clashing with an existing set, got nil")
+	}
+	if err := sug.AddLinearOutputSet("FanSpeed", "Ramp", NewLinearConsequent(0).Coefficient("Temperature", 2)); err != nil {
+		t.Fatalf("AddLinearOutputSet failed: %v", err)
+	}
+	if err := sug.AddLinearOutputSet("FanSpeed", "Ramp", NewLinearConsequent(1)); err == nil {
+		t.Error("Expected error for duplicate linear set, got nil")
+	}
+}
+
+func TestSugenoSetAggregation(t *testing.T) {
+	sug := newSugenoSystem(t)
+	// Halve every rule so the strengths at 25 sum to 0.5
+	for _, r := range sug.Rules() {
+		_ = r.SetWeight(0.5)
+	}
+	inputs := map[string]float64{"Temperature": 25}
+
+	tests := []struct {
+		method   string
+		expected float64
+	}{
+		{SugenoWeightedAverage, 50},            // (0.25*10 + 0.25*90) / 0.5
+		{SugenoWeightedSum, 0.25*10 + 0.25*90}, // not normalized
+	}
+	for _, tt := range tests {
+		if err := sug.SetAggregation(tt.method); err != nil {
+			t.Fatalf("SetAggregation(%s) failed: %v", tt.method, err)
+		}
+		results, err := sug.Infer(inputs)
+		if err != nil {
+			t.Fatalf("Infer with %s failed: %v", tt.method, err)
+		}
+		if math.Abs(results["FanSpeed"]-tt.expected) > 1e-9 {
+			t.Errorf("%s: expected %f, got %f", tt.method, tt.expected, results["FanSpeed"])
+		}
+	}
+
+	if err := sug.SetAggregation("max"); err == nil {
+		t.Error("Expected error for invalid aggregation, got nil")
+	}
+}
@@ -0,0 +1,95 @@
+package inference
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// ControlSurface evaluates output over a grid spanning the domains of inputX and
+// inputY, each divided into steps intervals (steps+1 points including both
+// bounds). Any other input takes its value from InputDefaults. xs and ys hold the
+// grid coordinates and z[j][i] the output at (xs[i], ys[j]). Cells share a single
+// workspace.
+// Returns error if inputX or inputY is not an input variable, they are the same,
+// output is not an output variable, steps < 1, or inference fails for any cell.
+func (fis *MamdaniInferenceSystem) ControlSurface(inputX, inputY, output string, steps int) (xs, ys []float64, z [][]float64, err error) {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	xVar, exists := fis.InputVariables[inputX]
+	if !exists {
+		return nil, nil, nil, fmt.Errorf("input variable '%s' does not exist", inputX)
+	}
+	yVar, exists := fis.InputVariables[inputY]
+	if !exists {
+		return nil, nil, nil, fmt.Errorf("input variable '%s' does not exist", inputY)
+	}
+	if inputX == inputY {
+		return nil, nil, nil, fmt.Errorf("surface axes must be different inputs, got '%s' twice", inputX)
+	}
+	if _, exists := fis.OutputVariables[output]; !exists {
+		return nil, nil, nil, fmt.Errorf("output variable '%s' does not exist", output)
+	}
+	if steps < 1 {
+		return nil, nil, nil, fmt.Errorf("surface needs at least 1 step, got %d", steps)
+	}
+
+	xs = make([]float64, steps+1)
+	ys = make([]float64, steps+1)
+	for i := 0; i <= steps; i++ {
+		xs[i] = xVar.MinValue + float64(i)*(xVar.MaxValue-xVar.MinValue)/float64(steps)
+		ys[i] = yVar.MinValue + float64(i)*(yVar.MaxValue-yVar.MinValue)/float64(steps)
+	}
+
+	ws := fis.newWorkspace()
+	cell := make(map[string]float64, 2)
+	z = make([][]float64, steps+1)
+	for j, y := range ys {
+		z[j] = make([]float64, steps+1)
+		for i, x := range xs {
+			cell[inputX], cell[inputY] = x, y
+			results, err := fis.inferWith(ws, cell)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("cell %v: %w", cell, err)
+			}
+			z[j][i] = results[output]
+		}
+	}
+	return xs, ys, z, nil
+}
+
+// ControlSurfaceCSV writes the ControlSurface of output over inputX and inputY to
+// w as a CSV grid for heatmap tools: a header row with an empty first cell
+// followed by the X coordinates, then one row per Y coordinate holding Y
+// followed by the output at each X.
+// Returns error for any reason ControlSurface would, or if writing to w fails.
+func (fis *MamdaniInferenceSystem) ControlSurfaceCSV(w io.Writer, inputX, inputY, output string, steps int) error {
+	xs, ys, z, err := fis.ControlSurface(inputX, inputY, output, steps)
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	record := make([]string, len(xs)+1)
+	for i, x := range xs {
+		record[i+1] = strconv.FormatFloat(x, 'g', -1, 64)
+	}
+	if err := cw.Write(record); err != nil {
+		return fmt.Errorf("writing surface header: %w", err)
+	}
+	for j, y := range ys {
+		record[0] = strconv.FormatFloat(y, 'g', -1, 64)
+		for i, value := range z[j] {
+			record[i+1] = strconv.FormatFloat(value, 'g', -1, 64)
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("writing surface row %d: %w", j, err)
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("writing surface: %w", err)
+	}
+	return nil
+}
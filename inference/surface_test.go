@@ -0,0 +1,86 @@
+package inference
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"testing"
+)
+
+func TestControlSurface(t *testing.T) {
+	fis := newTwoInputSystem(t)
+
+	xs, ys, z, err := fis.ControlSurface("A", "B", "Y", 4)
+	if err != nil {
+		t.Fatalf("ControlSurface failed: %v", err)
+	}
+	if len(xs) != 5 || len(ys) != 5 || len(z) != 5 {
+		t.Fatalf("Expected 5 points per axis, got %d xs, %d ys and %d rows", len(xs), len(ys), len(z))
+	}
+	if xs[0] != 0 || xs[4] != 1 || !floatEqual(ys[1], 0.25) {
+		t.Errorf("Expected axes covering [0, 1] in steps of 0.25, got xs %v and ys %v", xs, ys)
+	}
+	for j, y := range ys {
+		for i, x := range xs {
+			want, _ := fis.Infer(map[string]float64{"A": x, "B": y})
+			if !floatEqual(z[j][i], want["Y"]) {
+				t.Errorf("Cell (%f, %f): expected %f, got %f", x, y, want["Y"], z[j][i])
+			}
+		}
+	}
+}
+
+func TestControlSurface_Validation(t *testing.T) {
+	fis := newTwoInputSystem(t)
+
+	tests := []struct {
+		name                   string
+		inputX, inputY, output string
+		steps                  int
+	}{
+		{"unknown X", "C", "B", "Y", 4},
+		{"unknown Y", "A", "C", "Y", 4},
+		{"same axis", "A", "A", "Y", 4},
+		{"unknown output", "A", "B", "Z", 4},
+		{"no steps", "A", "B", "Y", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, _, err := fis.ControlSurface(tt.inputX, tt.inputY, tt.output, tt.steps); err == nil {
+				t.Error("Expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestControlSurfaceCSV(t *testing.T) {
+	fis := newTwoInputSystem(t)
+	steps := 3
+
+	var buf bytes.Buffer
+	if err := fis.ControlSurfaceCSV(&buf, "A", "B", "Y", steps); err != nil {
+		t.Fatalf("ControlSurfaceCSV failed: %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Invalid CSV: %v", err)
+	}
+
+	// A header row plus steps+1 rows, each with a Y label plus steps+1 values
+	if len(records) != steps+2 {
+		t.Fatalf("Expected %d rows, got %d", steps+2, len(records))
+	}
+	for i, record := range records {
+		if len(record) != steps+2 {
+			t.Fatalf("Row %d: expected %d columns, got %d", i, steps+2, len(record))
+		}
+	}
+	if records[0][0] != "" || records[0][steps+1] != "1" || records[steps+1][0] != "1" {
+		t.Errorf("Expected X axis in the header and Y axis in the first column, got %v", records)
+	}
+
+	_, _, z, _ := fis.ControlSurface("A", "B", "Y", steps)
+	if got, _ := strconv.ParseFloat(records[2][3], 64); got != z[1][2] {
+		t.Errorf("Expected cell value %v, got %v", z[1][2], got)
+	}
+}
@@ -0,0 +1,71 @@
+package inference
+
+import (
+	"fmt"
+)
+
+// MaxSweepCells caps the number of input combinations FullSweep evaluates.
+var MaxSweepCells = 1000000
+
+// FullSweep evaluates every cell of a Cartesian grid over the input variables.
+// steps gives the number of evenly spaced points per input, covering its whole
+// domain including both bounds; every input variable needs an entry of at least 2.
+// Cells are enumerated in sorted input-name order with the last name varying
+// fastest, and share a single workspace. The returned slices hold the inputs of
+// each cell and the corresponding crisp outputs, index by index.
+// Returns error if an input is missing from steps or has fewer than 2 steps,
+// steps names an unknown variable, the grid has more than MaxSweepCells cells,
+// or inference fails for any cell.
+func (fis *MamdaniInferenceSystem) FullSweep(steps map[string]int) ([]map[string]float64, []map[string]float64, error) {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	for name := range steps {
+		if _, exists := fis.InputVariables[name]; !exists {
+			return nil, nil, fmt.Errorf("input variable '%s' does not exist", name)
+		}
+	}
+	names := sortedKeys(fis.InputVariables)
+	cells := 1
+	for _, name := range names {
+		n, ok := steps[name]
+		if !ok {
+			return nil, nil, fmt.Errorf("no step count for input variable '%s'", name)
+		}
+		if n < 2 {
+			return nil, nil, fmt.Errorf("input variable '%s' needs at least 2 steps, got %d", name, n)
+		}
+		if cells > MaxSweepCells/n {
+			return nil, nil, fmt.Errorf("sweep exceeds %d cells", MaxSweepCells)
+		}
+		cells *= n
+	}
+
+	ws := fis.newWorkspace()
+	inputs := make([]map[string]float64, 0, cells)
+	outputs := make([]map[string]float64, 0, cells)
+	index := make([]int, len(names))
+	for c := 0; c < cells; c++ {
+		cell := make(map[string]float64, len(names))
+		for i, name := range names {
+			v := fis.InputVariables[name]
+			n := steps[name]
+			cell[name] = v.MinValue + float64(index[i])*(v.MaxValue-v.MinValue)/float64(n-1)
+		}
+		results, err := fis.inferWith(ws, cell)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cell %v: %w", cell, err)
+		}
+		inputs = append(inputs, cell)
+		outputs = append(outputs, copyValues(results))
+
+		// Advance the odometer, last input fastest
+		for i := len(index) - 1; i >= 0; i-- {
+			index[i]++
+			if index[i] < steps[names[i]] {
+				break
+			}
+			index[i] = 0
+		}
+	}
+	return inputs, outputs, nil
+}
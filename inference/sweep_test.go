@@ -0,0 +1,86 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/loian/fuzzylib/membership"
+	"github.com/loian/fuzzylib/set"
+	"github.com/loian/fuzzylib/variable"
+)
+
+// newTwoInputSystem builds A, B -> Y with Low/High sets covering [0, 1] on every variable
+func newTwoInputSystem(t *testing.T) *MamdaniInferenceSystem {
+	t.Helper()
+	fis := NewMamdaniInferenceSystem()
+	for _, name := range []string{"A", "B", "Y"} {
+		v, _ := variable.NewFuzzyVariable(name, 0, 1)
+		v.AddSet(set.NewFuzzySet("Low", mustMF(membership.NewTriangular(-1, 0, 1))))
+		v.AddSet(set.NewFuzzySet("High", mustMF(membership.NewTriangular(0, 1, 2))))
+		add := fis.AddInputVariable
+		if name == "Y" {
+			add = fis.AddOutputVariable
+		}
+		if err := add(v); err != nil {
+			t.Fatalf("adding variable %s failed: %v", name, err)
+		}
+	}
+	for _, pair := range [][2]string{{"A", "Low"}, {"A", "High"}, {"B", "High"}} {
+		r, _ := NewRuleBuilder("Y", pair[1])
+		built, _ := r.If(pair[0], pair[1]).Build()
+		if err := fis.AddRule(built); err != nil {
+			t.Fatalf("AddRule failed: %v", err)
+		}
+	}
+	return fis
+}
+
+func TestFullSweep(t *testing.T) {
+	fis := newTwoInputSystem(t)
+
+	inputs, outputs, err := fis.FullSweep(map[string]int{"A": 3, "B": 4})
+	if err != nil {
+		t.Fatalf("FullSweep failed: %v", err)
+	}
+	if len(inputs) != 12 || len(outputs) != 12 {
+		t.Fatalf("Expected 3*4 = 12 cells, got %d inputs and %d outputs", len(inputs), len(outputs))
+	}
+
+	// The grid covers both bounds, with B varying fastest
+	if inputs[0]["A"] != 0 || inputs[0]["B"] != 0 || inputs[11]["A"] != 1 || inputs[11]["B"] != 1 {
+		t.Errorf("Expected the sweep to run from (0, 0) to (1, 1), got %v to %v", inputs[0], inputs[11])
+	}
+	if !floatEqual(inputs[1]["B"], 1.0/3) || inputs[1]["A"] != 0 {
+		t.Errorf("Expected second cell (0, 1/3), got %v", inputs[1])
+	}
+
+	for i, in := range inputs {
+		want, _ := fis.Infer(in)
+		if !floatEqual(outputs[i]["Y"], want["Y"]) {
+			t.Errorf("Cell %v: expected %f, got %f", in, want["Y"], outputs[i]["Y"])
+		}
+	}
+}
+
+func TestFullSweep_Validation(t *testing.T) {
+	fis := newTwoInputSystem(t)
+
+	if _, _, err := fis.FullSweep(map[string]int{"A": 3}); err == nil {
+		t.Error("Expected error for input without step count, got nil")
+	}
+	if _, _, err := fis.FullSweep(map[string]int{"A": 3, "B": 1}); err == nil {
+		t.Error("Expected error for fewer than 2 steps, got nil")
+	}
+	if _, _, err := fis.FullSweep(map[string]int{"A": 3, "B": 3, "C": 3}); err == nil {
+		t.Error("Expected error for unknown input, got nil")
+	}
+
+	saved := MaxSweepCells
+	MaxSweepCells = 100
+	defer func() { MaxSweepCells = saved }()
+	if _, _, err := fis.FullSweep(map[string]int{"A": 10, "B": 11}); err == nil {
+		t.Error("Expected error for sweep over the cell cap, got nil")
+	}
+	if _, _, err := fis.FullSweep(map[string]int{"A": 10, "B": 10}); err != nil {
+		t.Errorf("Expected sweep at the cell cap to succeed, got %v", err)
+	}
+}
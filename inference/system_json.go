@@ -0,0 +1,287 @@
+package inference
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/loian/fuzzylib/membership"
+	"github.com/loian/fuzzylib/operators"
+	"github.com/loian/fuzzylib/set"
+	"github.com/loian/fuzzylib/variable"
+)
+
+// systemJSON is the serialized form of a system used by MarshalSystem and UnmarshalSystem.
+// Variables and sets are arrays rather than maps so the encoding is byte-stable.
+type systemJSON struct {
+	Inputs              []variableJSON             `json:"inputs"`
+	Outputs             []variableJSON             `json:"outputs"`
+	Rules               []ruleJSON                 `json:"rules"`
+	Resolution          int                        `json:"resolution"`
+	DefuzzMethod        string                     `json:"defuzz_method"`
+	OutputDefuzzMethods map[string]string          `json:"output_defuzz_methods,omitempty"`
+	ImplicationMethod   string                     `json:"implication_method,omitempty"`
+	AggregationMethod   string                     `json:"aggregation_method,omitempty"`
+	AndOperator         string                     `json:"and_operator,omitempty"`
+	OrOperator          string                     `json:"or_operator,omitempty"`
+	InputGains          map[string]float64         `json:"input_gains,omitempty"`
+	InputDefaults       map[string]float64         `json:"input_defaults,omitempty"`
+	DefaultRules        map[string]defaultRuleJSON `json:"default_rules,omitempty"`
+	ClampMembership     *bool                      `json:"clamp_membership,omitempty"` // absent means true
+	MinCOGMass          float64                    `json:"min_cog_mass,omitempty"`
+	COGFallback         *float64                   `json:"cog_fallback,omitempty"` // absent means no fallback
+	InterpolateMaxima   bool                       `json:"interpolate_maxima,omitempty"`
+	ExtendOutputSupport bool                       `json:"extend_output_support,omitempty"`
+	ClassifyTieBreak    string                     `json:"classify_tie_break,omitempty"`
+	Strict              bool                       `json:"strict,omitempty"`
+}
+
+// defaultRuleJSON is the serialized form of a DefaultRule
+type defaultRuleJSON struct {
+	Set      string  `json:"set"`
+	Strength float64 `json:"strength"`
+}
+
+// variableJSON is the serialized form of a fuzzy variable
+type variableJSON struct {
+	Name        string    `json:"name"`
+	Min         float64   `json:"min"`
+	Max         float64   `json:"max"`
+	Unit        string    `json:"unit,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Sets        []setJSON `json:"sets"`
+}
+
+// setJSON is the serialized form of a fuzzy set, its membership function stored
+// as a membership.New kind and parameters
+type setJSON struct {
+	Name   string    `json:"name"`
+	Kind   string    `json:"kind"`
+	Params []float64 `json:"params"`
+}
+
+// MarshalSystem encodes the variables, rules, AND/OR overrides, input gains and
+// defaults, default rules and defuzzification settings of fis as indented JSON,
+// everything that affects inference results. The logger and metrics sink are not
+// stored. Variables and their sets are written as arrays
+// in the order they were added (see variable.FuzzyVariable.SetNames), and rules in
+// rule order, so encoding the same system always produces the same bytes and
+// UnmarshalSystem restores the original order.
+// Returns error if a set's membership function is not membership.Parameterized or
+// a rule uses an operator that is not registered.
+func MarshalSystem(fis *MamdaniInferenceSystem) ([]byte, error) {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	inputs, err := encodeVariables(fis.InputVariables, fis.inputOrder)
+	if err != nil {
+		return nil, err
+	}
+	outputs, err := encodeVariables(fis.OutputVariables, fis.outputOrder)
+	if err != nil {
+		return nil, err
+	}
+	clamp := fis.ClampMembership
+	sj := systemJSON{
+		Inputs:              inputs,
+		Outputs:             outputs,
+		Rules:               make([]ruleJSON, len(fis.Rules)),
+		Resolution:          fis.Resolution,
+		DefuzzMethod:        fis.DefuzzMethod,
+		ImplicationMethod:   fis.ImplicationMethod,
+		AggregationMethod:   fis.AggregationMethod,
+		ClampMembership:     &clamp,
+		MinCOGMass:          fis.MinCOGMass,
+		InterpolateMaxima:   fis.InterpolateMaxima,
+		ExtendOutputSupport: fis.ExtendOutputSupport,
+		ClassifyTieBreak:    fis.ClassifyTieBreak,
+		Strict:              fis.Strict,
+	}
+	if len(fis.InputGains) > 0 {
+		sj.InputGains = fis.InputGains
+	}
+	if len(fis.InputDefaults) > 0 {
+		sj.InputDefaults = fis.InputDefaults
+	}
+	for output, d := range fis.DefaultRules {
+		if sj.DefaultRules == nil {
+			sj.DefaultRules = make(map[string]defaultRuleJSON, len(fis.DefaultRules))
+		}
+		sj.DefaultRules[output] = defaultRuleJSON{Set: d.Set, Strength: d.Strength}
+	}
+	if fis.hasCOGFallback {
+		fallback := fis.cogFallback
+		sj.COGFallback = &fallback
+	}
+	if sj.AndOperator, err = overrideName(fis.AndOperator); err != nil {
+		return nil, err
+	}
+	if sj.OrOperator, err = overrideName(fis.OrOperator); err != nil {
+		return nil, err
+	}
+	if len(fis.OutputDefuzzMethods) > 0 {
+		sj.OutputDefuzzMethods = fis.OutputDefuzzMethods
+	}
+	for i, r := range fis.Rules {
+		if sj.Rules[i], err = encodeRule(r); err != nil {
+			return nil, fmt.Errorf("rule %d: %w", r.ID, err)
+		}
+	}
+	return json.MarshalIndent(sj, "", "  ")
+}
+
+// UnmarshalSystem rebuilds a system written by MarshalSystem. Rules are validated
+// by AddRule and receive fresh IDs.
+// Returns error if data is not valid JSON, a variable, set or rule is rejected, or
+// a setting is invalid.
+func UnmarshalSystem(data []byte) (*MamdaniInferenceSystem, error) {
+	var sj systemJSON
+	if err := json.Unmarshal(data, &sj); err != nil {
+		return nil, fmt.Errorf("invalid system JSON: %w", err)
+	}
+	fis := NewMamdaniInferenceSystem()
+	for _, vj := range sj.Inputs {
+		v, err := decodeVariable(vj)
+		if err != nil {
+			return nil, err
+		}
+		if err := fis.AddInputVariable(v); err != nil {
+			return nil, err
+		}
+	}
+	for _, vj := range sj.Outputs {
+		v, err := decodeVariable(vj)
+		if err != nil {
+			return nil, err
+		}
+		if err := fis.AddOutputVariable(v); err != nil {
+			return nil, err
+		}
+	}
+	for i, rj := range sj.Rules {
+		r, err := decodeRule(rj)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i+1, err)
+		}
+		if err := fis.AddRule(r); err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i+1, err)
+		}
+	}
+
+	if err := fis.SetResolution(sj.Resolution); err != nil {
+		return nil, err
+	}
+	if err := fis.SetDefuzzificationMethod(sj.DefuzzMethod); err != nil {
+		return nil, err
+	}
+	for output, method := range sj.OutputDefuzzMethods {
+		if err := fis.SetOutputDefuzzificationMethod(output, method); err != nil {
+			return nil, err
+		}
+	}
+	if sj.ImplicationMethod != "" {
+		if err := fis.SetImplicationMethod(sj.ImplicationMethod); err != nil {
+			return nil, err
+		}
+	}
+	if sj.AggregationMethod != "" {
+		if err := fis.SetAggregationMethod(sj.AggregationMethod); err != nil {
+			return nil, err
+		}
+	}
+	if sj.AndOperator != "" {
+		op, err := operators.ByName(sj.AndOperator)
+		if err != nil {
+			return nil, err
+		}
+		if err := fis.SetAndOperator(op); err != nil {
+			return nil, err
+		}
+	}
+	if sj.OrOperator != "" {
+		op, err := operators.ByName(sj.OrOperator)
+		if err != nil {
+			return nil, err
+		}
+		if err := fis.SetOrOperator(op); err != nil {
+			return nil, err
+		}
+	}
+	for input, gain := range sj.InputGains {
+		if err := fis.SetInputGain(input, gain); err != nil {
+			return nil, err
+		}
+	}
+	for input, value := range sj.InputDefaults {
+		if err := fis.SetInputDefault(input, value); err != nil {
+			return nil, err
+		}
+	}
+	for output, d := range sj.DefaultRules {
+		if err := fis.SetDefaultRule(&variable.SetRef{Variable: output, Set: d.Set}, d.Strength); err != nil {
+			return nil, err
+		}
+	}
+	var fallback []float64
+	if sj.COGFallback != nil {
+		fallback = append(fallback, *sj.COGFallback)
+	}
+	if err := fis.SetMinCOGMass(sj.MinCOGMass, fallback...); err != nil {
+		return nil, err
+	}
+	if sj.ClassifyTieBreak != "" {
+		if err := fis.SetClassifyTieBreak(sj.ClassifyTieBreak); err != nil {
+			return nil, err
+		}
+	}
+	if sj.ClampMembership != nil {
+		fis.ClampMembership = *sj.ClampMembership
+	}
+	fis.InterpolateMaxima = sj.InterpolateMaxima
+	fis.ExtendOutputSupport = sj.ExtendOutputSupport
+	// Set last so that rules accepted by the original system load
+	fis.Strict = sj.Strict
+	return fis, nil
+}
+
+// encodeVariables converts vars to their serialized form, in the given order
+func encodeVariables(vars map[string]*variable.FuzzyVariable, order []string) ([]variableJSON, error) {
+	encoded := make([]variableJSON, 0, len(vars))
+	for _, name := range orderedKeys(vars, order) {
+		v := vars[name]
+		vj := variableJSON{
+			Name:        v.Name,
+			Min:         v.MinValue,
+			Max:         v.MaxValue,
+			Unit:        v.Unit,
+			Description: v.Description,
+			Sets:        make([]setJSON, 0, len(v.Sets)),
+		}
+		for _, setName := range v.SetNames() {
+			mf, ok := v.Sets[setName].MembershipFunc.(membership.Parameterized)
+			if !ok {
+				return nil, fmt.Errorf("set '%s.%s': membership function %T cannot be serialized", name, setName, v.Sets[setName].MembershipFunc)
+			}
+			vj.Sets = append(vj.Sets, setJSON{Name: setName, Kind: mf.Kind(), Params: mf.Params()})
+		}
+		encoded = append(encoded, vj)
+	}
+	return encoded, nil
+}
+
+// decodeVariable rebuilds a fuzzy variable from its serialized form
+func decodeVariable(vj variableJSON) (*variable.FuzzyVariable, error) {
+	v, err := variable.NewFuzzyVariable(vj.Name, vj.Min, vj.Max)
+	if err != nil {
+		return nil, err
+	}
+	v.Unit = vj.Unit
+	v.Description = vj.Description
+	for _, sj := range vj.Sets {
+		mf, err := membership.New(sj.Kind, sj.Params)
+		if err != nil {
+			return nil, fmt.Errorf("set '%s.%s': %w", vj.Name, sj.Name, err)
+		}
+		if _, err := v.AddSet(set.NewFuzzySet(sj.Name, mf)); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
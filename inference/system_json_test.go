@@ -0,0 +1,185 @@
+package inference
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/loian/fuzzylib/membership"
+	"github.com/loian/fuzzylib/operators"
+	"github.com/loian/fuzzylib/set"
+	"github.com/loian/fuzzylib/variable"
+	"os"
+	"testing"
+)
+
+func TestMarshalSystem_Golden(t *testing.T) {
+	golden, err := os.ReadFile("../testdata/temp_fan_system.json")
+	if err != nil {
+		t.Fatalf("Failed to read golden file: %v", err)
+	}
+
+	// Map iteration order varies between calls; the encoding must not
+	for i := 0; i < 20; i++ {
+		data, err := MarshalSystem(newTempFanSystem(t))
+		if err != nil {
+			t.Fatalf("MarshalSystem failed: %v", err)
+		}
+		if !bytes.Equal(data, golden) {
+			t.Fatalf("MarshalSystem output differs from golden file on run %d:\n%s", i+1, data)
+		}
+	}
+}
+
+func TestMarshalSystem_RoundTrip(t *testing.T) {
+	fis := newTempFanSystem(t)
+	_ = fis.SetDefuzzificationMethod(DefuzzCOG)
+	_ = fis.SetOutputDefuzzificationMethod("FanSpeed", DefuzzBIS)
+	_ = fis.SetImplicationMethod(ImplicationMin)
+	_ = fis.SetAggregationMethod(AggregationProbOr)
+	_ = fis.SetAndOperator(operators.PROD)
+	hamacher, _ := operators.NewHamacherOr(0.5)
+	_ = fis.SetOrOperator(hamacher)
+	fis.InputVariables["Temperature"].Unit = "°C"
+
+	data, err := MarshalSystem(fis)
+	if err != nil {
+		t.Fatalf("MarshalSystem failed: %v", err)
+	}
+	decoded, err := UnmarshalSystem(data)
+	if err != nil {
+		t.Fatalf("UnmarshalSystem failed: %v", err)
+	}
+	again, err := MarshalSystem(decoded)
+	if err != nil {
+		t.Fatalf("MarshalSystem of decoded system failed: %v", err)
+	}
+	if !bytes.Equal(data, again) {
+		t.Errorf("Round trip changed the encoding:\n%s\nvs\n%s", data, again)
+	}
+
+	for _, temp := range []float64{5, 25, 35, 45} {
+		inputs := map[string]float64{"Temperature": temp}
+		want, err := fis.Infer(inputs)
+		if err != nil {
+			t.Fatalf("Infer failed: %v", err)
+		}
+		got, err := decoded.Infer(inputs)
+		if err != nil {
+			t.Fatalf("Infer on decoded system failed: %v", err)
+		}
+		if !floatEqual(got["FanSpeed"], want["FanSpeed"]) {
+			t.Errorf("Temperature %f: expected %f, got %f", temp, want["FanSpeed"], got["FanSpeed"])
+		}
+	}
+}
+
+func TestMarshalSystem_RoundTripSettings(t *testing.T) {
+	fis := newTempFanSystem(t)
+	_ = fis.SetDefuzzificationMethod(DefuzzCOG)
+	_ = fis.SetOutputDefuzzificationMethod("FanSpeed", DefuzzFOM)
+	_ = fis.SetInputGain("Temperature", 0.9)
+	_ = fis.SetInputDefault("Temperature", 33)
+	_ = fis.SetDefaultRule(&variable.SetRef{Variable: "FanSpeed", Set: "Medium"}, 0.5)
+	_ = fis.SetMinCOGMass(0.5, 42)
+	_ = fis.SetClassifyTieBreak(ClassifyTieName)
+	fis.ClampMembership = false
+	fis.InterpolateMaxima = true
+	fis.ExtendOutputSupport = true
+	fis.Strict = true
+
+	data, err := MarshalSystem(fis)
+	if err != nil {
+		t.Fatalf("MarshalSystem failed: %v", err)
+	}
+	decoded, err := UnmarshalSystem(data)
+	if err != nil {
+		t.Fatalf("UnmarshalSystem failed: %v", err)
+	}
+
+	// Temperature 0 only fires the default rule; the empty map uses the input default
+	for _, inputs := range []map[string]float64{{"Temperature": 0}, {"Temperature": 20}, {"Temperature": 40}, {}} {
+		want, err := fis.Infer(inputs)
+		if err != nil {
+			t.Fatalf("Infer(%v) failed: %v", inputs, err)
+		}
+		got, err := decoded.Infer(inputs)
+		if err != nil {
+			t.Fatalf("Infer(%v) on decoded system failed: %v", inputs, err)
+		}
+		if got["FanSpeed"] != want["FanSpeed"] {
+			t.Errorf("Inputs %v: expected %f, got %f", inputs, want["FanSpeed"], got["FanSpeed"])
+		}
+	}
+
+	if decoded.ClampMembership || !decoded.InterpolateMaxima || !decoded.ExtendOutputSupport || !decoded.Strict ||
+		decoded.ClassifyTieBreak != ClassifyTieName || decoded.MinCOGMass != 0.5 ||
+		!decoded.hasCOGFallback || decoded.cogFallback != 42 {
+		t.Errorf("Settings were not restored: %+v", decoded)
+	}
+
+	// Older encodings without clamp_membership keep the default
+	legacy, err := UnmarshalSystem(bytes.Replace(data, []byte(`"clamp_membership": false,`), nil, 1))
+	if err != nil {
+		t.Fatalf("UnmarshalSystem without clamp_membership failed: %v", err)
+	}
+	if !legacy.ClampMembership {
+		t.Error("Expected ClampMembership to default to true when absent")
+	}
+}
+
+func TestMarshalSystem_InsertionOrder(t *testing.T) {
+	fis := newTempFanSystem(t)
+	// Humidity sorts before Temperature but was added after it
+	humidity, _ := variable.NewFuzzyVariable("Humidity", 0, 100)
+	humidity.AddSet(set.NewFuzzySet("Wet", mustMF(membership.NewTriangular(50, 100, 100))))
+	humidity.AddSet(set.NewFuzzySet("Dry", mustMF(membership.NewTriangular(0, 0, 50))))
+	if err := fis.AddInputVariable(humidity); err != nil {
+		t.Fatalf("AddInputVariable failed: %v", err)
+	}
+
+	data, err := MarshalSystem(fis)
+	if err != nil {
+		t.Fatalf("MarshalSystem failed: %v", err)
+	}
+	var sj systemJSON
+	if err := json.Unmarshal(data, &sj); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if sj.Inputs[0].Name != "Temperature" || sj.Inputs[1].Name != "Humidity" {
+		t.Errorf("Expected inputs in insertion order Temperature, Humidity, got %s, %s", sj.Inputs[0].Name, sj.Inputs[1].Name)
+	}
+	if sj.Inputs[1].Sets[0].Name != "Wet" || sj.Inputs[1].Sets[1].Name != "Dry" {
+		t.Errorf("Expected sets in insertion order Wet, Dry, got %v", sj.Inputs[1].Sets)
+	}
+
+	// Decoding keeps the order, so the encoding survives a round trip
+	decoded, err := UnmarshalSystem(data)
+	if err != nil {
+		t.Fatalf("UnmarshalSystem failed: %v", err)
+	}
+	again, _ := MarshalSystem(decoded)
+	if !bytes.Equal(data, again) {
+		t.Errorf("Round trip changed the order:\n%s\nvs\n%s", data, again)
+	}
+}
+
+func TestMarshalSystem_Errors(t *testing.T) {
+	fis := newTempFanSystem(t)
+	custom, _ := membership.NewCustom(func(x float64) float64 { return 1 })
+	fis.OutputVariables["FanSpeed"].AddSet(set.NewFuzzySet("Custom", custom))
+	if _, err := MarshalSystem(fis); err == nil {
+		t.Error("Expected error for a custom membership function, got nil")
+	}
+
+	invalid := map[string]string{
+		"malformed JSON":  `{`,
+		"unknown kind":    `{"inputs":[{"name":"X","min":0,"max":1,"sets":[{"name":"A","kind":"spline","params":[0]}]}]}`,
+		"unknown set":     `{"inputs":[{"name":"X","min":0,"max":1,"sets":[]}],"outputs":[{"name":"Y","min":0,"max":1,"sets":[]}],"rules":[{"conditions":[{"variable":"X","set":"A"}],"outputs":[{"variable":"Y","set":"B"}],"operator":"min"}]}`,
+		"bad resolution":  `{"resolution":0,"defuzz_method":"centroid"}`,
+		"bad defuzz name": `{"resolution":100,"defuzz_method":"median"}`,
+	}
+	for name, data := range invalid {
+		if _, err := UnmarshalSystem([]byte(data)); err == nil {
+			t.Errorf("%s: expected error, got nil", name)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package inference
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// InferenceTrace records the intermediate results of one inference run
+type InferenceTrace struct {
+	Fuzzification     map[string]map[string]float64 // input variable -> set -> degree
+	RuleStrengths     []float64                     // weighted firing strength of each rule, by index into Rules
+	OutputMemberships map[string]map[string]float64 // output variable -> set -> aggregated firing strength
+}
+
+// InferWithTrace performs Mamdani inference like Infer and also returns the
+// fuzzified inputs, every rule's firing strength and the aggregated output set
+// strengths, for explaining a decision. Both follow the same evaluation path, so
+// the outputs always equal Infer's.
+// Returns error for any reason Infer would. If the inputs were accepted but
+// defuzzification failed (e.g. no rule fired) the trace is still returned.
+func (fis *MamdaniInferenceSystem) InferWithTrace(inputs map[string]float64) (map[string]float64, *InferenceTrace, error) {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	ws := fis.newWorkspace()
+	ws.sink = fis.metrics
+	if err := fis.fire(ws, inputs); err != nil {
+		return nil, nil, err
+	}
+	trace := &InferenceTrace{
+		Fuzzification:     make(map[string]map[string]float64, len(ws.membershipMap)),
+		RuleStrengths:     append([]float64(nil), ws.ruleStrengths...),
+		OutputMemberships: make(map[string]map[string]float64, len(ws.outputMemberships)),
+	}
+	for name, degrees := range ws.membershipMap {
+		trace.Fuzzification[name] = copyValues(degrees)
+	}
+	for name, strengths := range ws.outputMemberships {
+		trace.OutputMemberships[name] = copyValues(strengths)
+	}
+	results, err := fis.defuzzifyAll(ws)
+	if err != nil {
+		return nil, trace, err
+	}
+	return results, trace, nil
+}
+
+// InferBatchTrace runs inference for each input row with a single workspace and
+// writes the firing strength of every rule to w as CSV: a header naming each
+// rule by ID ("rule_<ID>"), then one line per input row with the rules' weighted
+// firing strengths in rule order. The crisp outputs are returned in row order.
+// Returns error if any row fails inference, naming its index, or if writing to w fails.
+func (fis *MamdaniInferenceSystem) InferBatchTrace(inputs []map[string]float64, w io.Writer) ([]map[string]float64, error) {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	cw := csv.NewWriter(w)
+	header := make([]string, len(fis.Rules))
+	for i, r := range fis.Rules {
+		header[i] = "rule_" + strconv.Itoa(r.ID)
+	}
+	if err := cw.Write(header); err != nil {
+		return nil, fmt.Errorf("writing trace header: %w", err)
+	}
+
+	ws := fis.newWorkspace()
+	ws.sink = fis.metrics
+	outputs := make([]map[string]float64, len(inputs))
+	record := make([]string, len(fis.Rules))
+	for row, in := range inputs {
+		if err := fis.fire(ws, in); err != nil {
+			return nil, fmt.Errorf("row %d: %w", row, err)
+		}
+		for i, strength := range ws.ruleStrengths {
+			record[i] = strconv.FormatFloat(strength, 'g', -1, 64)
+		}
+		results, err := fis.defuzzifyAll(ws)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", row, err)
+		}
+		outputs[row] = copyValues(results)
+		if err := cw.Write(record); err != nil {
+			return nil, fmt.Errorf("writing trace row %d: %w", row, err)
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return nil, fmt.Errorf("writing trace: %w", err)
+	}
+	return outputs, nil
+}
@@ -0,0 +1,116 @@
+package inference
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestInferBatchTrace(t *testing.T) {
+	fis := newTempFanSystem(t)
+	inputs := []map[string]float64{
+		{"Temperature": 5},
+		{"Temperature": 25},
+		{"Temperature": 45},
+		{"Temperature": 33},
+	}
+
+	var buf bytes.Buffer
+	outputs, err := fis.InferBatchTrace(inputs, &buf)
+	if err != nil {
+		t.Fatalf("InferBatchTrace failed: %v", err)
+	}
+	if len(outputs) != len(inputs) {
+		t.Fatalf("Expected %d outputs, got %d", len(inputs), len(outputs))
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Trace is not valid CSV: %v", err)
+	}
+	if len(records) != len(inputs)+1 {
+		t.Fatalf("Expected a header and %d rows, got %d lines", len(inputs), len(records))
+	}
+	for i, rec := range records {
+		if len(rec) != len(fis.Rules) {
+			t.Fatalf("Line %d: expected %d columns (one per rule), got %d", i, len(fis.Rules), len(rec))
+		}
+	}
+	if records[0][0] != "rule_"+strconv.Itoa(fis.Rules[0].ID) {
+		t.Errorf("Expected header to name rules by ID, got %v", records[0])
+	}
+
+	// Each row matches a single Infer call
+	for row, in := range inputs {
+		want, _ := fis.Infer(in)
+		if !floatEqual(outputs[row]["FanSpeed"], want["FanSpeed"]) {
+			t.Errorf("Row %d: expected output %f, got %f", row, want["FanSpeed"], outputs[row]["FanSpeed"])
+		}
+	}
+}
+
+func TestInferBatchTrace_RowError(t *testing.T) {
+	fis := newTempFanSystem(t)
+	inputs := []map[string]float64{{"Temperature": 25}, {}}
+
+	var buf bytes.Buffer
+	if _, err := fis.InferBatchTrace(inputs, &buf); !errors.Is(err, ErrMissingInput) {
+		t.Errorf("Expected ErrMissingInput for the second row, got %v", err)
+	}
+}
+
+func TestInferWithTrace(t *testing.T) {
+	fis := newTempFanSystem(t)
+	inputs := map[string]float64{"Temperature": 35} // Warm 1/3, Hot 1/4
+
+	results, trace, err := fis.InferWithTrace(inputs)
+	if err != nil {
+		t.Fatalf("InferWithTrace failed: %v", err)
+	}
+	expected, _ := fis.Infer(inputs)
+	if !floatEqual(results["FanSpeed"], expected["FanSpeed"]) {
+		t.Errorf("Expected outputs to match Infer (%f), got %f", expected["FanSpeed"], results["FanSpeed"])
+	}
+
+	warm, hot := 1.0/3, 0.25
+	if !floatEqual(trace.Fuzzification["Temperature"]["Warm"], warm) || !floatEqual(trace.Fuzzification["Temperature"]["Hot"], hot) {
+		t.Errorf("Unexpected fuzzification: %v", trace.Fuzzification)
+	}
+	wantStrengths := []float64{0, warm, hot} // Cold->Low, Warm->Medium, Hot->High
+	if len(trace.RuleStrengths) != len(wantStrengths) {
+		t.Fatalf("Expected %d rule strengths, got %v", len(wantStrengths), trace.RuleStrengths)
+	}
+	for i, want := range wantStrengths {
+		if !floatEqual(trace.RuleStrengths[i], want) {
+			t.Errorf("Rule %d: expected strength %f, got %f", i+1, want, trace.RuleStrengths[i])
+		}
+	}
+	if !floatEqual(trace.OutputMemberships["FanSpeed"]["Medium"], warm) || !floatEqual(trace.OutputMemberships["FanSpeed"]["High"], hot) {
+		t.Errorf("Unexpected output memberships: %v", trace.OutputMemberships)
+	}
+
+	// The trace belongs to the caller and is not overwritten by later runs
+	_, _, _ = fis.InferWithTrace(map[string]float64{"Temperature": 5})
+	if !floatEqual(trace.RuleStrengths[1], warm) {
+		t.Errorf("Expected the trace to be unaffected by later calls, got %v", trace.RuleStrengths)
+	}
+}
+
+func TestInferWithTrace_Errors(t *testing.T) {
+	fis := newTempFanSystem(t)
+	if _, trace, err := fis.InferWithTrace(map[string]float64{}); !errors.Is(err, ErrMissingInput) || trace != nil {
+		t.Errorf("Expected ErrMissingInput and no trace, got %v, %v", err, trace)
+	}
+
+	// Nothing covers 25 without the Warm rule: the trace still explains why
+	_ = fis.RemoveRuleByID(2)
+	_, trace, err := fis.InferWithTrace(map[string]float64{"Temperature": 25})
+	if err == nil {
+		t.Fatal("Expected an error when no rule fires, got nil")
+	}
+	if trace == nil || len(trace.RuleStrengths) != 2 || trace.RuleStrengths[0] != 0 || trace.RuleStrengths[1] != 0 {
+		t.Errorf("Expected a trace with two silent rules, got %+v", trace)
+	}
+}
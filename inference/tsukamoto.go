@@ -0,0 +1,152 @@
+package inference
+
+import (
+	"fmt"
+	"github.com/loian/fuzzylib/membership"
+	"github.com/loian/fuzzylib/rule"
+	"github.com/loian/fuzzylib/variable"
+	"math"
+)
+
+// TsukamotoInferenceSystem is a Tsukamoto FIS. Every output set must be monotonic:
+// each fired rule yields the crisp x at which its consequent reaches the rule's
+// firing strength, and an output's value is the firing-strength-weighted average
+// of those x values. No defuzzification sampling is involved.
+//
+// Monotonic output sets are membership.Invertible functions (such as the halves of
+// a Gaussian) and one-sided triangles or trapezoids, i.e. shoulders with a
+// vertical edge. Inhibitory rules are not supported.
+type TsukamotoInferenceSystem struct {
+	fis *MamdaniInferenceSystem // holds variables and rules, and performs rule firing
+}
+
+// NewTsukamotoInferenceSystem creates a new Tsukamoto inference system
+func NewTsukamotoInferenceSystem() *TsukamotoInferenceSystem {
+	return &TsukamotoInferenceSystem{fis: NewMamdaniInferenceSystem()}
+}
+
+// AddInputVariable adds an input variable to the system.
+// Returns error if variable with same name already exists.
+func (t *TsukamotoInferenceSystem) AddInputVariable(v *variable.FuzzyVariable) error {
+	return t.fis.AddInputVariable(v)
+}
+
+// AddOutputVariable adds an output variable to the system. Its sets are checked
+// for monotonicity when a rule references them.
+// Returns error if variable with same name already exists.
+func (t *TsukamotoInferenceSystem) AddOutputVariable(v *variable.FuzzyVariable) error {
+	return t.fis.AddOutputVariable(v)
+}
+
+// AddRule adds a rule to the system and assigns it the next rule ID.
+// Returns error for any reason MamdaniInferenceSystem.AddRule would, if the rule
+// is inhibitory, or if any of its output sets is not monotonic.
+func (t *TsukamotoInferenceSystem) AddRule(r *rule.Rule) error {
+	t.fis.mu.Lock()
+	defer t.fis.mu.Unlock()
+	if r.Inhibitory {
+		return fmt.Errorf("tsukamoto inference does not support inhibitory rules")
+	}
+	if err := t.fis.validateRule(r); err != nil {
+		return err
+	}
+	for _, out := range r.Outputs() {
+		mf := t.fis.OutputVariables[out.Variable].Sets[out.Set].MembershipFunc
+		if _, ok := monotonicInverse(mf); !ok {
+			return fmt.Errorf("output set '%s.%s' is not monotonic: tsukamoto consequents need an invertible or one-sided membership function", out.Variable, out.Set)
+		}
+	}
+	return t.fis.addRule(r)
+}
+
+// Rules returns the rules of the system in the order they were added
+func (t *TsukamotoInferenceSystem) Rules() []*rule.Rule {
+	return t.fis.Rules
+}
+
+// Infer performs Tsukamoto inference
+// inputs: map[variableName]crispValue
+// returns: map[variableName]crispOutput, error
+// Each per-rule x is clamped to its output variable's domain.
+// Returns error if the system is not configured, inputs are missing or out of
+// bounds, or no rule concluding some output fired.
+func (t *TsukamotoInferenceSystem) Infer(inputs map[string]float64) (map[string]float64, error) {
+	t.fis.mu.RLock()
+	defer t.fis.mu.RUnlock()
+	ws := t.fis.newWorkspace()
+	if err := t.fis.fire(ws, inputs); err != nil {
+		return nil, err
+	}
+
+	return weightedRuleAverage(t.fis, ws, true, func(outputVar *variable.FuzzyVariable, setName string, strength float64) (float64, error) {
+		inverse, ok := monotonicInverse(outputVar.Sets[setName].MembershipFunc)
+		if !ok {
+			return 0, fmt.Errorf("output set '%s.%s' is not monotonic", outputVar.Name, setName)
+		}
+		return math.Max(outputVar.MinValue, math.Min(outputVar.MaxValue, inverse(strength))), nil
+	})
+}
+
+// weightedRuleAverage computes each output of fis as the firing-strength-weighted
+// average of the per-rule crisp values returned by value, over the rules fired in ws.
+// Strengths are capped at 1. If normalize is false the weighted sum is returned
+// without dividing by the total strength. Returns error if value fails or no rule
+// concluding some output fired.
+func weightedRuleAverage(fis *MamdaniInferenceSystem, ws *InferenceWorkspace, normalize bool, value func(outputVar *variable.FuzzyVariable, setName string, strength float64) (float64, error)) (map[string]float64, error) {
+	weighted := make(map[string]float64, len(fis.OutputVariables))
+	totals := make(map[string]float64, len(fis.OutputVariables))
+	for i, r := range fis.Rules {
+		strength := math.Min(ws.ruleStrengths[i], 1)
+		if strength <= 0 {
+			continue
+		}
+		for _, out := range r.Outputs() {
+			x, err := value(fis.OutputVariables[out.Variable], out.Set, strength)
+			if err != nil {
+				return nil, err
+			}
+			weighted[out.Variable] += strength * x
+			totals[out.Variable] += strength
+		}
+	}
+
+	results := make(map[string]float64, len(fis.OutputVariables))
+	for _, name := range ws.outputNames {
+		if totals[name] == 0 {
+			return nil, fmt.Errorf("%w for output '%s'", ErrNoRulesFired, name)
+		}
+		results[name] = weighted[name]
+		if normalize {
+			results[name] /= totals[name]
+		}
+	}
+	return results, nil
+}
+
+// monotonicInverse returns the inverse of mf on its sloped edge, or false if mf
+// is not monotonic. Besides membership.Invertible functions it accepts triangles
+// and trapezoids with a single sloped edge.
+func monotonicInverse(mf membership.MembershipFunction) (func(degree float64) float64, bool) {
+	switch m := mf.(type) {
+	case membership.Invertible:
+		return m.Inverse, true
+	case *membership.Triangular:
+		return shoulderInverse(m.A, m.B, m.B, m.C)
+	case *membership.Trapezoidal:
+		return shoulderInverse(m.A, m.B, m.C, m.D)
+	}
+	return nil, false
+}
+
+// shoulderInverse inverts a trapezoid a <= b <= c <= d with exactly one sloped
+// edge: rising when c == d, falling when a == b
+func shoulderInverse(a, b, c, d float64) (func(degree float64) float64, bool) {
+	rising, falling := b > a, d > c
+	switch {
+	case rising && !falling:
+		return func(degree float64) float64 { return a + degree*(b-a) }, true
+	case falling && !rising:
+		return func(degree float64) float64 { return d - degree*(d-c) }, true
+	}
+	return nil, false
+}
@@ -0,0 +1,106 @@
+package inference
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/loian/fuzzylib/membership"
+	"github.com/loian/fuzzylib/set"
+	"github.com/loian/fuzzylib/variable"
+)
+
+// newTsukamotoSystem builds a Temperature -> FanSpeed system whose output sets
+// are a falling shoulder (Low) and a rising shoulder (High)
+func newTsukamotoSystem(t *testing.T) *TsukamotoInferenceSystem {
+	t.Helper()
+	tsk := NewTsukamotoInferenceSystem()
+
+	tempVar, _ := variable.NewFuzzyVariable("Temperature", 0, 50)
+	tempVar.AddSet(set.NewFuzzySet("Cold", mustMF(membership.NewTriangular(-50, 0, 50))))
+	tempVar.AddSet(set.NewFuzzySet("Hot", mustMF(membership.NewTriangular(0, 50, 100))))
+
+	fanVar, _ := variable.NewFuzzyVariable("FanSpeed", 0, 100)
+	fanVar.AddSet(set.NewFuzzySet("Low", mustMF(membership.NewTrapezoidal(0, 0, 20, 80))))
+	fanVar.AddSet(set.NewFuzzySet("High", mustMF(membership.NewTriangular(20, 80, 80))))
+	fanVar.AddSet(set.NewFuzzySet("Medium", mustMF(membership.NewTriangular(20, 50, 80))))
+
+	if err := tsk.AddInputVariable(tempVar); err != nil {
+		t.Fatalf("AddInputVariable failed: %v", err)
+	}
+	if err := tsk.AddOutputVariable(fanVar); err != nil {
+		t.Fatalf("AddOutputVariable failed: %v", err)
+	}
+	for _, pair := range [][2]string{{"Cold", "Low"}, {"Hot", "High"}} {
+		r, _ := NewRuleBuilder("FanSpeed", pair[1])
+		built, _ := r.If("Temperature", pair[0]).Build()
+		if err := tsk.AddRule(built); err != nil {
+			t.Fatalf("AddRule failed: %v", err)
+		}
+	}
+	return tsk
+}
+
+func TestTsukamotoInfer(t *testing.T) {
+	tsk := newTsukamotoSystem(t)
+
+	tests := []struct {
+		temp     float64
+		expected float64
+	}{
+		// Cold 0.5 -> Low at 50, Hot 0.5 -> High at 50
+		{25, 50},
+		// Cold 0.8 -> Low at 32, Hot 0.2 -> High at 32
+		{10, 32},
+		// Only Hot fires, fully -> High at 80
+		{50, 80},
+	}
+	for _, tt := range tests {
+		results, err := tsk.Infer(map[string]float64{"Temperature": tt.temp})
+		if err != nil {
+			t.Fatalf("Infer(%v) failed: %v", tt.temp, err)
+		}
+		if math.Abs(results["FanSpeed"]-tt.expected) > 1e-9 {
+			t.Errorf("Infer(%v) = %f, expected %f", tt.temp, results["FanSpeed"], tt.expected)
+		}
+	}
+
+	if _, err := tsk.Infer(map[string]float64{}); !errors.Is(err, ErrMissingInput) {
+		t.Errorf("Expected ErrMissingInput, got %v", err)
+	}
+}
+
+func TestTsukamotoInfer_GaussianHalf(t *testing.T) {
+	tsk := newTsukamotoSystem(t)
+	g, _ := membership.NewGaussian(100, 20)
+	tsk.fis.OutputVariables["FanSpeed"].AddSet(set.NewFuzzySet("Max", g.RisingHalf()))
+
+	r, _ := NewRuleBuilder("FanSpeed", "Max")
+	built, _ := r.If("Temperature", "Hot").Build()
+	if err := tsk.AddRule(built); err != nil {
+		t.Fatalf("AddRule with GaussianHalf consequent failed: %v", err)
+	}
+	if _, err := tsk.Infer(map[string]float64{"Temperature": 40}); err != nil {
+		t.Errorf("Infer failed: %v", err)
+	}
+}
+
+func TestTsukamotoAddRule_RejectsNonMonotonic(t *testing.T) {
+	tsk := newTsukamotoSystem(t)
+
+	r, _ := NewRuleBuilder("FanSpeed", "Medium")
+	built, _ := r.If("Temperature", "Hot").Build()
+	if err := tsk.AddRule(built); err == nil {
+		t.Error("Expected error for non-monotonic output set, got nil")
+	}
+
+	inhibit, _ := NewRuleBuilder("FanSpeed", "High")
+	built, _ = inhibit.If("Temperature", "Cold").Build()
+	built.Inhibitory = true
+	if err := tsk.AddRule(built); err == nil {
+		t.Error("Expected error for inhibitory rule, got nil")
+	}
+	if len(tsk.Rules()) != 2 {
+		t.Errorf("Expected rejected rules not to be added, got %d rules", len(tsk.Rules()))
+	}
+}
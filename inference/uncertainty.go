@@ -0,0 +1,77 @@
+package inference
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// OutputStats summarises the distribution of a crisp output
+type OutputStats struct {
+	Mean float64
+	Std  float64 // Population standard deviation
+}
+
+// PropagateUncertainty estimates the distribution of every crisp output by Monte
+// Carlo sampling. Each input in means is drawn from a normal distribution with
+// the given mean and the standard deviation in stds (0 if absent), clamped to the
+// variable's domain; inputs without a mean are omitted, so input defaults apply.
+// Variables are sampled in name order, so the same seeded source always yields
+// the same statistics. If r is nil, the global math/rand source is used.
+// Returns error if samples <= 0, means or stds name an unknown input variable,
+// a std is negative or NaN, or inference fails for any sample.
+func (fis *MamdaniInferenceSystem) PropagateUncertainty(means, stds map[string]float64, samples int, r *rand.Rand) (map[string]OutputStats, error) {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	if samples <= 0 {
+		return nil, fmt.Errorf("samples must be > 0, got %d", samples)
+	}
+	for name := range means {
+		if _, exists := fis.InputVariables[name]; !exists {
+			return nil, fmt.Errorf("input variable '%s' does not exist", name)
+		}
+	}
+	for name, std := range stds {
+		if _, exists := fis.InputVariables[name]; !exists {
+			return nil, fmt.Errorf("input variable '%s' does not exist", name)
+		}
+		if std < 0 || math.IsNaN(std) {
+			return nil, fmt.Errorf("standard deviation for '%s' must be >= 0, got %v", name, std)
+		}
+	}
+	normal := rand.NormFloat64
+	if r != nil {
+		normal = r.NormFloat64
+	}
+
+	ws := fis.newWorkspace()
+	inputs := make(map[string]float64, len(means))
+	mean := make(map[string]float64, len(fis.OutputVariables))
+	m2 := make(map[string]float64, len(fis.OutputVariables))
+	for n := 1; n <= samples; n++ {
+		for _, name := range sortedKeys(fis.InputVariables) {
+			mu, ok := means[name]
+			if !ok {
+				continue
+			}
+			v := fis.InputVariables[name]
+			inputs[name] = math.Max(v.MinValue, math.Min(v.MaxValue, mu+stds[name]*normal()))
+		}
+		results, err := fis.inferWith(ws, inputs)
+		if err != nil {
+			return nil, fmt.Errorf("sample %d: %w", n, err)
+		}
+		// Welford's online update
+		for name, x := range results {
+			delta := x - mean[name]
+			mean[name] += delta / float64(n)
+			m2[name] += delta * (x - mean[name])
+		}
+	}
+
+	stats := make(map[string]OutputStats, len(mean))
+	for name, mu := range mean {
+		stats[name] = OutputStats{Mean: mu, Std: math.Sqrt(m2[name] / float64(samples))}
+	}
+	return stats, nil
+}
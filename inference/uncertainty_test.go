@@ -0,0 +1,62 @@
+package inference
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestPropagateUncertainty_ZeroStd(t *testing.T) {
+	fis := newTempFanSystem(t)
+	_ = fis.SetDefuzzificationMethod(DefuzzCOG)
+	inputs := map[string]float64{"Temperature": 28}
+	want, err := fis.Infer(inputs)
+	if err != nil {
+		t.Fatalf("Infer failed: %v", err)
+	}
+
+	stats, err := fis.PropagateUncertainty(inputs, nil, 50, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("PropagateUncertainty failed: %v", err)
+	}
+	got := stats["FanSpeed"]
+	if !floatEqual(got.Mean, want["FanSpeed"]) {
+		t.Errorf("Expected mean %f, got %f", want["FanSpeed"], got.Mean)
+	}
+	if got.Std > epsilon {
+		t.Errorf("Expected zero std, got %f", got.Std)
+	}
+}
+
+func TestPropagateUncertainty_Spread(t *testing.T) {
+	fis := newTempFanSystem(t)
+	_ = fis.SetDefuzzificationMethod(DefuzzCOG)
+	means := map[string]float64{"Temperature": 25}
+
+	narrow, err := fis.PropagateUncertainty(means, map[string]float64{"Temperature": 1}, 500, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("PropagateUncertainty failed: %v", err)
+	}
+	wide, err := fis.PropagateUncertainty(means, map[string]float64{"Temperature": 10}, 500, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("PropagateUncertainty failed: %v", err)
+	}
+	if narrow["FanSpeed"].Std <= 0 || wide["FanSpeed"].Std <= narrow["FanSpeed"].Std {
+		t.Errorf("Expected wider input spread to widen the output, got std %f (narrow) vs %f (wide)",
+			narrow["FanSpeed"].Std, wide["FanSpeed"].Std)
+	}
+
+}
+
+func TestPropagateUncertainty_Validation(t *testing.T) {
+	fis := newTempFanSystem(t)
+	means := map[string]float64{"Temperature": 25}
+	if _, err := fis.PropagateUncertainty(means, nil, 0, nil); err == nil {
+		t.Error("Expected error for zero samples")
+	}
+	if _, err := fis.PropagateUncertainty(map[string]float64{"Unknown": 1}, nil, 10, nil); err == nil {
+		t.Error("Expected error for unknown mean variable")
+	}
+	if _, err := fis.PropagateUncertainty(means, map[string]float64{"Temperature": -1}, 10, nil); err == nil {
+		t.Error("Expected error for negative std")
+	}
+}
@@ -0,0 +1,395 @@
+package inference
+
+import (
+	"fmt"
+	"github.com/loian/fuzzylib/rule"
+	"github.com/loian/fuzzylib/variable"
+	"math"
+)
+
+// InferenceWorkspace holds preallocated buffers reused across inference calls,
+// so that repeated InferWith calls on the same system do not allocate maps.
+// Degrees stay keyed by variable and set name because rules look them up that
+// way; the maps are built once and overwritten in place.
+//
+// A workspace belongs to the system that created it and is not safe for
+// concurrent use; give each goroutine its own workspace. It is rebuilt
+// automatically after the system's mutators run or the number of variables or
+// rules changes; after other structural edits (e.g. adding sets to a variable)
+// call InvalidateCaches on the system.
+type InferenceWorkspace struct {
+	fis         *MamdaniInferenceSystem
+	inputNames  []string // sorted input variable names
+	outputNames []string // sorted output variable names
+	numRules    int
+	generation  uint64 // system generation the buffers were built for
+
+	scaledInputs      map[string]float64            // inputs after gain
+	membershipMap     map[string]map[string]float64 // input variable -> set -> degree
+	outputMemberships map[string]map[string]float64 // output variable -> set -> firing strength
+	results           map[string]float64            // output variable -> crisp value
+	ruleStrengths     []float64                     // firing strength of each rule, by index
+	scratch           []float64                     // per-rule condition and group degrees
+	sink              MetricsSink                   // stage timing sink for the current call, nil if uninstrumented
+}
+
+// NewWorkspace creates a workspace sized for the system's current configuration.
+func (fis *MamdaniInferenceSystem) NewWorkspace() *InferenceWorkspace {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	return fis.newWorkspace()
+}
+
+// newWorkspace is NewWorkspace for callers already holding the lock
+func (fis *MamdaniInferenceSystem) newWorkspace() *InferenceWorkspace {
+	ws := &InferenceWorkspace{fis: fis}
+	ws.rebuild()
+	return ws
+}
+
+// rebuild (re)allocates every buffer for the owning system's current configuration
+func (ws *InferenceWorkspace) rebuild() {
+	fis := ws.fis
+	ws.inputNames = sortedKeys(fis.InputVariables)
+	ws.outputNames = sortedKeys(fis.OutputVariables)
+	ws.numRules = len(fis.Rules)
+	ws.generation = fis.generation
+
+	ws.scaledInputs = make(map[string]float64, len(fis.InputVariables))
+	ws.membershipMap = make(map[string]map[string]float64, len(fis.InputVariables))
+	for name, v := range fis.InputVariables {
+		ws.membershipMap[name] = make(map[string]float64, len(v.Sets))
+	}
+	ws.outputMemberships = make(map[string]map[string]float64, len(fis.OutputVariables))
+	for name, v := range fis.OutputVariables {
+		ws.outputMemberships[name] = make(map[string]float64, len(v.Sets))
+	}
+	ws.results = make(map[string]float64, len(fis.OutputVariables))
+	ws.ruleStrengths = make([]float64, len(fis.Rules))
+
+	scratchLen := 0
+	for _, r := range fis.Rules {
+		scratchLen = max(scratchLen, r.ScratchLen())
+	}
+	ws.scratch = make([]float64, scratchLen)
+}
+
+// stale reports whether the system changed shape since the workspace was built
+func (ws *InferenceWorkspace) stale() bool {
+	return ws.generation != ws.fis.generation ||
+		len(ws.inputNames) != len(ws.fis.InputVariables) ||
+		len(ws.outputNames) != len(ws.fis.OutputVariables) ||
+		ws.numRules != len(ws.fis.Rules)
+}
+
+// InferWith performs Mamdani inference like Infer, writing all intermediate
+// state into ws. The returned map is a view owned by the workspace: it is
+// overwritten by the next InferWith call with the same workspace, so copy it
+// if the values must be retained.
+// Returns error if ws was created by a different system, or for any reason Infer would.
+func (fis *MamdaniInferenceSystem) InferWith(ws *InferenceWorkspace, inputs map[string]float64) (map[string]float64, error) {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	return fis.inferWith(ws, inputs)
+}
+
+// inferWith is InferWith for callers already holding the read lock
+func (fis *MamdaniInferenceSystem) inferWith(ws *InferenceWorkspace, inputs map[string]float64) (map[string]float64, error) {
+	if ws != nil {
+		ws.sink = fis.metrics
+	}
+	if err := fis.fire(ws, inputs); err != nil {
+		return nil, err
+	}
+	return fis.defuzzifyAll(ws)
+}
+
+// InferFromMemberships runs rule evaluation and defuzzification on pre-fuzzified
+// data, skipping fuzzification. membershipMap is map[inputVariable][setName]degree;
+// conditions whose variable or set is missing from the map evaluate to 0.
+// Useful for deterministic rule tests and for tools that fuzzify elsewhere.
+// Returns error if the system is not configured or defuzzification fails.
+func (fis *MamdaniInferenceSystem) InferFromMemberships(membershipMap map[string]map[string]float64) (map[string]float64, error) {
+	fis.mu.RLock()
+	defer fis.mu.RUnlock()
+	ws := fis.newWorkspace()
+	if err := fis.checkConfigured(ws); err != nil {
+		return nil, err
+	}
+	if err := fis.evaluateRules(ws, membershipMap); err != nil {
+		return nil, err
+	}
+	return fis.defuzzifyAll(ws)
+}
+
+// checkConfigured validates that ws belongs to fis and that the system has inputs,
+// outputs and rules, rebuilding ws if the system changed shape
+func (fis *MamdaniInferenceSystem) checkConfigured(ws *InferenceWorkspace) error {
+	if ws == nil || ws.fis != fis {
+		return fmt.Errorf("workspace was not created by this inference system")
+	}
+	// Validate system is configured
+	if len(fis.InputVariables) == 0 {
+		return fmt.Errorf("inference system has no input variables")
+	}
+	if len(fis.OutputVariables) == 0 {
+		return fmt.Errorf("inference system has no output variables")
+	}
+	if len(fis.Rules) == 0 {
+		return fmt.Errorf("inference system has no rules")
+	}
+	if ws.stale() {
+		ws.rebuild()
+	}
+	return nil
+}
+
+// fire validates the inputs and runs fuzzification and rule evaluation (steps 1
+// and 2 of Infer), leaving map[outputVariable][outputSet]firingStrength in
+// ws.outputMemberships, aggregated with MAX across rules.
+func (fis *MamdaniInferenceSystem) fire(ws *InferenceWorkspace, inputs map[string]float64) error {
+	return fis.fireMixed(ws, inputs, nil)
+}
+
+// fireMixed is like fire, but input variables present in fuzzyInputs take their
+// membership degrees from it instead of fuzzifying a crisp value
+func (fis *MamdaniInferenceSystem) fireMixed(ws *InferenceWorkspace, inputs map[string]float64, fuzzyInputs map[string]map[string]float64) error {
+	if err := fis.checkConfigured(ws); err != nil {
+		return err
+	}
+	for varName := range fuzzyInputs {
+		if _, exists := fis.InputVariables[varName]; !exists {
+			return fmt.Errorf("fuzzy input '%s' is not an input variable", varName)
+		}
+	}
+
+	// Validate that all required inputs are provided, falling back to defaults
+	for _, varName := range ws.inputNames {
+		if _, fuzzy := fuzzyInputs[varName]; fuzzy {
+			continue
+		}
+		inputVar := fis.InputVariables[varName]
+		value, exists := inputs[varName]
+		if !exists {
+			if value, exists = fis.InputDefaults[varName]; !exists {
+				return fmt.Errorf("%w: %s", ErrMissingInput, varName)
+			}
+		}
+		// Apply input gain before bounds checking
+		if gain, ok := fis.InputGains[varName]; ok {
+			value *= gain
+		}
+		ws.scaledInputs[varName] = value
+		// Validate bounds; NaN fails every comparison, so reject it explicitly
+		if math.IsNaN(value) || value < inputVar.MinValue || value > inputVar.MaxValue {
+			return &OutOfBoundsError{Variable: varName, Value: value, Min: inputVar.MinValue, Max: inputVar.MaxValue}
+		}
+	}
+
+	// Step 1: Fuzzification - convert crisp inputs to membership degrees
+	start := ws.stageStart()
+	for _, varName := range ws.inputNames {
+		if degrees, fuzzy := fuzzyInputs[varName]; fuzzy {
+			dst := ws.membershipMap[varName]
+			clear(dst)
+			for setName, degree := range degrees {
+				dst[setName] = degree
+			}
+			continue
+		}
+		fis.InputVariables[varName].FuzzifyInto(ws.scaledInputs[varName], ws.membershipMap[varName])
+	}
+	start = ws.stageDone(StageFuzzification, start)
+
+	if err := fis.evaluateRules(ws, ws.membershipMap); err != nil {
+		return err
+	}
+	ws.stageDone(StageRuleEvaluation, start)
+	return nil
+}
+
+// evaluateRules runs step 2 of Infer: every rule is evaluated against membershipMap
+// and its firing strength is accumulated into ws.outputMemberships
+func (fis *MamdaniInferenceSystem) evaluateRules(ws *InferenceWorkspace, membershipMap map[string]map[string]float64) error {
+	// Step 2: Rule evaluation - fire rules and collect outputs
+	for _, setMap := range ws.outputMemberships {
+		clear(setMap)
+	}
+
+	connectives := fis.connectives()
+	for i, r := range fis.Rules {
+		firingStrength, err := r.EvaluateWithConnectives(membershipMap, ws.scratch, connectives)
+		if err != nil {
+			return fmt.Errorf("error evaluating rule: %w", err)
+		}
+		ws.ruleStrengths[i] = firingStrength
+		if r.Inhibitory {
+			continue
+		}
+		// Each rule contributes to every output set it names
+		ws.accumulate(r.Output, firingStrength)
+		for _, out := range r.AdditionalOutputs {
+			ws.accumulate(out, firingStrength)
+		}
+	}
+
+	// Default rules fill in outputs that no excitatory rule fired for
+	for varName, def := range fis.DefaultRules {
+		setMap, ok := ws.outputMemberships[varName]
+		if !ok || anyFired(setMap) {
+			continue
+		}
+		setMap[def.Set] = def.Strength
+	}
+
+	// Inhibitory rules are applied after every excitatory rule has been aggregated,
+	// so the result does not depend on rule order
+	for i, r := range fis.Rules {
+		if !r.Inhibitory {
+			continue
+		}
+		ws.inhibit(r.Output, ws.ruleStrengths[i])
+		for _, out := range r.AdditionalOutputs {
+			ws.inhibit(out, ws.ruleStrengths[i])
+		}
+	}
+
+	return nil
+}
+
+// defuzzifyAll runs step 3 of Infer on ws.outputMemberships, writing crisp values into ws.results
+func (fis *MamdaniInferenceSystem) defuzzifyAll(ws *InferenceWorkspace) (map[string]float64, error) {
+	// Step 3: Defuzzification - convert fuzzy outputs to crisp values
+	start := ws.stageStart()
+	opts := fis.defuzzOptions()
+	clear(ws.results)
+	for _, varName := range ws.outputNames {
+		outputVar := fis.OutputVariables[varName]
+		memberships := ws.outputMemberships[varName]
+		result, err := defuzzifyOutput(fis.defuzzMethodFor(varName), outputVar, memberships, opts)
+		if err != nil {
+			return nil, fmt.Errorf("defuzzification failed for variable '%s': %w", varName, err)
+		}
+		// Guard against NaN from misbehaving membership functions propagating silently
+		if math.IsNaN(result) || math.IsInf(result, 0) {
+			return nil, fmt.Errorf("%w: output variable '%s' defuzzified to %v", ErrNonFiniteOutput, varName, result)
+		}
+		ws.results[varName] = result
+	}
+	ws.stageDone(StageDefuzzification, start)
+
+	return ws.results, nil
+}
+
+// defuzzifyOutput converts the fired output sets of one variable to a crisp value using method.
+// A variable whose domain is too narrow to sample (see narrowDomain) defuzzifies
+// to its MinValue whenever any of its sets fired; Lint warns about such variables.
+func defuzzifyOutput(method string, outputVar *variable.FuzzyVariable, memberships map[string]float64, opts defuzzOptions) (float64, error) {
+	if narrowDomain(outputVar) {
+		if !anyFired(memberships) {
+			return 0, ErrNoRulesFired
+		}
+		return outputVar.MinValue, nil
+	}
+	if opts.extend {
+		if wide, res, ok := extendedOutputDomain(outputVar, memberships, opts.resolution); ok {
+			opts.extend = false
+			opts.resolution = res
+			result, err := defuzzifyOutput(method, wide, memberships, opts)
+			if err != nil {
+				return result, err
+			}
+			return math.Max(outputVar.MinValue, math.Min(outputVar.MaxValue, result)), nil
+		}
+	}
+	switch method {
+	case DefuzzCOG:
+		return defuzzifyCOGWithOptions(outputVar, memberships, opts)
+	case DefuzzMOM:
+		return defuzzifyMOMWithOptions(outputVar, memberships, opts)
+	case DefuzzFOM:
+		return defuzzifyFOMWithOptions(outputVar, memberships, opts)
+	case DefuzzSOM:
+		return defuzzifySOMWithOptions(outputVar, memberships, opts)
+	case DefuzzLOM:
+		return defuzzifyLOMWithOptions(outputVar, memberships, opts)
+	case DefuzzBIS:
+		return defuzzifyBisectorWithOptions(outputVar, memberships, opts)
+	default:
+		// Default to MOM if unknown method
+		return defuzzifyMOMWithOptions(outputVar, memberships, opts)
+	}
+}
+
+// narrowDomain reports whether v's domain is effectively a single point: its span
+// is within epsilon relative to the magnitude of its bounds, so evenly spaced
+// samples would collapse onto the same few floating point values
+func narrowDomain(v *variable.FuzzyVariable) bool {
+	scale := math.Max(1, math.Max(math.Abs(v.MinValue), math.Abs(v.MaxValue)))
+	return v.MaxValue-v.MinValue <= epsilon*scale
+}
+
+// extendedOutputDomain returns a copy of outputVar whose domain also covers the
+// bounded supports of the fired sets in memberships, with the resolution scaled
+// to keep the sampling step. ok is false if no fired set leaks past the domain.
+func extendedOutputDomain(outputVar *variable.FuzzyVariable, memberships map[string]float64, resolution int) (wide *variable.FuzzyVariable, res int, ok bool) {
+	lo, hi := outputVar.MinValue, outputVar.MaxValue
+	for setName, strength := range memberships {
+		s, exists := outputVar.Sets[setName]
+		if !exists || strength <= 0 {
+			continue
+		}
+		if left, right, bounded := s.MembershipFunc.Support(); bounded {
+			lo = math.Min(lo, left)
+			hi = math.Max(hi, right)
+		}
+	}
+	if lo == outputVar.MinValue && hi == outputVar.MaxValue {
+		return nil, 0, false
+	}
+	if resolution <= 0 {
+		resolution = DefaultResolution
+	}
+	cp := *outputVar
+	cp.MinValue, cp.MaxValue = lo, hi
+	res = int(math.Ceil(float64(resolution) * (hi - lo) / (outputVar.MaxValue - outputVar.MinValue)))
+	return &cp, res, true
+}
+
+// inhibit subtracts an inhibitory rule's firing strength from the aggregated
+// strength of its output set, flooring the result at 0
+func (ws *InferenceWorkspace) inhibit(out rule.RuleCondition, firingStrength float64) {
+	setMap, ok := ws.outputMemberships[out.Variable]
+	if !ok {
+		return
+	}
+	if current, exists := setMap[out.Set]; exists {
+		setMap[out.Set] = math.Max(0, current-firingStrength)
+	}
+}
+
+// anyFired reports whether any output set in setMap has a positive firing strength
+func anyFired(setMap map[string]float64) bool {
+	for _, strength := range setMap {
+		if strength > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// accumulate records a rule's firing strength for one consequent
+func (ws *InferenceWorkspace) accumulate(out rule.RuleCondition, firingStrength float64) {
+	setMap, ok := ws.outputMemberships[out.Variable]
+	if !ok {
+		return
+	}
+	// Use MAX aggregation for multiple rules firing to same set
+	if current, exists := setMap[out.Set]; exists {
+		if firingStrength > current {
+			setMap[out.Set] = firingStrength
+		}
+	} else {
+		setMap[out.Set] = firingStrength
+	}
+}
@@ -0,0 +1,186 @@
+package inference
+
+import (
+	"errors"
+	"github.com/loian/fuzzylib/operators"
+	"github.com/loian/fuzzylib/rule"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestInferWith_MatchesInfer(t *testing.T) {
+	fis := newTempFanSystem(t)
+	ws := fis.NewWorkspace()
+	r := rand.New(rand.NewSource(3))
+
+	for _, method := range []string{DefuzzCOG, DefuzzMOM, DefuzzFOM} {
+		_ = fis.SetDefuzzificationMethod(method)
+		for i := 0; i < 100; i++ {
+			inputs := fis.RandomInputs(r)
+			want, wantErr := fis.Infer(inputs)
+			got, gotErr := fis.InferWith(ws, inputs)
+			if (wantErr == nil) != (gotErr == nil) {
+				t.Fatalf("%s: error mismatch for %v: Infer=%v InferWith=%v", method, inputs, wantErr, gotErr)
+			}
+			if wantErr != nil {
+				continue
+			}
+			if got["FanSpeed"] != want["FanSpeed"] {
+				t.Errorf("%s: InferWith %f != Infer %f for %v", method, got["FanSpeed"], want["FanSpeed"], inputs)
+			}
+		}
+	}
+}
+
+func TestInferWith_RebuildsAfterRuleAdded(t *testing.T) {
+	fis := newTempFanSystem(t)
+	ws := fis.NewWorkspace()
+
+	r, _ := NewRuleBuilder("FanSpeed", "High")
+	extra, _ := r.If("Temperature", "Warm").Build()
+	_ = fis.AddRule(extra)
+
+	want, _ := fis.Infer(map[string]float64{"Temperature": 25})
+	got, err := fis.InferWith(ws, map[string]float64{"Temperature": 25})
+	if err != nil {
+		t.Fatalf("InferWith failed: %v", err)
+	}
+	if got["FanSpeed"] != want["FanSpeed"] {
+		t.Errorf("Expected stale workspace to be rebuilt: got %f, want %f", got["FanSpeed"], want["FanSpeed"])
+	}
+}
+
+func TestInvalidateCaches(t *testing.T) {
+	fis := newTempFanSystem(t)
+	ws := fis.NewWorkspace()
+	if _, err := fis.InferWith(ws, map[string]float64{"Temperature": 25}); err != nil {
+		t.Fatalf("InferWith failed: %v", err)
+	}
+
+	// Rename the input directly: the variable and rule counts are unchanged,
+	// so only an explicit invalidation tells the workspace its names are stale
+	temp := fis.InputVariables["Temperature"]
+	delete(fis.InputVariables, "Temperature")
+	temp.Name = "Temp"
+	fis.InputVariables["Temp"] = temp
+	for _, r := range fis.Rules {
+		r.Conditions[0].Variable = "Temp"
+	}
+	fis.InvalidateCaches()
+
+	inputs := map[string]float64{"Temp": 25}
+	want, err := fis.Infer(inputs)
+	if err != nil {
+		t.Fatalf("Infer failed: %v", err)
+	}
+	got, err := fis.InferWith(ws, inputs)
+	if err != nil {
+		t.Fatalf("InferWith after InvalidateCaches failed: %v", err)
+	}
+	if got["FanSpeed"] != want["FanSpeed"] {
+		t.Errorf("Expected rebuilt workspace result %f, got %f", want["FanSpeed"], got["FanSpeed"])
+	}
+
+	// Configuration survives invalidation
+	if len(fis.Rules) != 3 || len(fis.InputVariables) != 1 {
+		t.Errorf("Expected configuration to be kept, got %d rules and %d inputs", len(fis.Rules), len(fis.InputVariables))
+	}
+}
+
+func TestInferWith_ForeignWorkspace(t *testing.T) {
+	a := newTempFanSystem(t)
+	b := newTempFanSystem(t)
+	if _, err := a.InferWith(b.NewWorkspace(), map[string]float64{"Temperature": 25}); err == nil {
+		t.Error("Expected error for workspace from another system, got nil")
+	}
+}
+
+func TestInferFromMemberships(t *testing.T) {
+	fis := newTempFanSystem(t)
+	_ = fis.SetDefuzzificationMethod(DefuzzMOM)
+
+	// Only Hot fires, fully: MOM lands on the High peak
+	results, err := fis.InferFromMemberships(map[string]map[string]float64{
+		"Temperature": {"Cold": 0, "Warm": 0, "Hot": 1},
+	})
+	if err != nil {
+		t.Fatalf("InferFromMemberships failed: %v", err)
+	}
+	if math.Abs(results["FanSpeed"]-99.95) > 0.1 {
+		t.Errorf("Expected FanSpeed near the High peak, got %f", results["FanSpeed"])
+	}
+
+	// Matches crisp inference for the equivalent fuzzified input
+	want, _ := fis.Infer(map[string]float64{"Temperature": 35})
+	got, err := fis.InferFromMemberships(map[string]map[string]float64{
+		"Temperature": fis.InputVariables["Temperature"].Fuzzify(35),
+	})
+	if err != nil {
+		t.Fatalf("InferFromMemberships failed: %v", err)
+	}
+	if got["FanSpeed"] != want["FanSpeed"] {
+		t.Errorf("Expected %f, got %f", want["FanSpeed"], got["FanSpeed"])
+	}
+
+	if _, err := fis.InferFromMemberships(nil); !errors.Is(err, ErrNoRulesFired) {
+		t.Errorf("Expected ErrNoRulesFired for empty membership map, got %v", err)
+	}
+}
+
+// newGroupedSystem returns the temperature/fan system plus a grouped rule:
+// IF (Temperature IS Warm AND Temperature IS Hot) OR Temperature IS Cold THEN FanSpeed IS Medium
+func newGroupedSystem(tb testing.TB) *MamdaniInferenceSystem {
+	tb.Helper()
+	fis := newTempFanSystem(tb)
+	r, _ := rule.NewRule(rule.RuleCondition{Variable: "FanSpeed", Set: "Medium"}, operators.OR)
+	_ = r.AddGroup(rule.RuleCondition{Variable: "Temperature", Set: "Warm"}, rule.RuleCondition{Variable: "Temperature", Set: "Hot"})
+	_ = r.AddGroup(rule.RuleCondition{Variable: "Temperature", Set: "Cold"})
+	if err := fis.AddRule(r); err != nil {
+		tb.Fatalf("AddRule failed: %v", err)
+	}
+	return fis
+}
+
+func TestInferWith_GroupedRulesAllocationFree(t *testing.T) {
+	fis := newGroupedSystem(t)
+	ws := fis.NewWorkspace()
+	inputs := map[string]float64{"Temperature": 35}
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = fis.InferWith(ws, inputs)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected InferWith with grouped rules to be allocation-free, got %v allocs per run", allocs)
+	}
+}
+
+func BenchmarkInfer(b *testing.B) {
+	fis := newTempFanSystem(b)
+	inputs := map[string]float64{"Temperature": 35}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = fis.Infer(inputs)
+	}
+}
+
+func BenchmarkInferWith(b *testing.B) {
+	fis := newTempFanSystem(b)
+	ws := fis.NewWorkspace()
+	inputs := map[string]float64{"Temperature": 35}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = fis.InferWith(ws, inputs)
+	}
+}
+
+func BenchmarkInferWith_Grouped(b *testing.B) {
+	fis := newGroupedSystem(b)
+	ws := fis.NewWorkspace()
+	inputs := map[string]float64{"Temperature": 35}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = fis.InferWith(ws, inputs)
+	}
+}
@@ -0,0 +1,25 @@
+// Package polyline integrates piecewise-linear curves for the membership, set and
+// inference packages, so the area and moment formulas live in one place.
+package polyline
+
+// CentroidArea computes the centroid and area under the piecewise-linear curve
+// through the points (xs[i], ys[i]). xs must be non-decreasing; zero-width
+// segments contribute nothing.
+// Returns (0, 0) if the area is zero.
+func CentroidArea(xs, ys []float64) (centroid, area float64) {
+	moment := 0.0
+	for i := 1; i < len(xs); i++ {
+		x0, x1 := xs[i-1], xs[i]
+		y0, y1 := ys[i-1], ys[i]
+		width := x1 - x0
+		if width <= 0 {
+			continue
+		}
+		area += width * (y0 + y1) / 2
+		moment += width * (x0*(2*y0+y1) + x1*(y0+2*y1)) / 6
+	}
+	if area == 0 {
+		return 0, 0
+	}
+	return moment / area, area
+}
@@ -0,0 +1,29 @@
+package polyline
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCentroidArea(t *testing.T) {
+	tests := []struct {
+		name     string
+		xs, ys   []float64
+		centroid float64
+		area     float64
+	}{
+		{"triangle", []float64{0, 1, 2}, []float64{0, 1, 0}, 1, 1},
+		{"right triangle", []float64{0, 3}, []float64{0, 1}, 2, 1.5},
+		{"trapezoid", []float64{0, 1, 3, 4}, []float64{0, 0.5, 0.5, 0}, 2, 1.5},
+		{"zero-width step", []float64{0, 2, 2, 4}, []float64{1, 1, 0, 0}, 1, 2},
+		{"empty", []float64{0, 1}, []float64{0, 0}, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			centroid, area := CentroidArea(tt.xs, tt.ys)
+			if math.Abs(centroid-tt.centroid) > 1e-9 || math.Abs(area-tt.area) > 1e-9 {
+				t.Errorf("Expected centroid %f and area %f, got %f and %f", tt.centroid, tt.area, centroid, area)
+			}
+		})
+	}
+}
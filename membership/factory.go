@@ -0,0 +1,87 @@
+package membership
+
+import (
+	"fmt"
+)
+
+// Membership function kinds accepted by New
+const (
+	KindTriangular  = "triangular"  // params: a, b, c
+	KindTrapezoidal = "trapezoidal" // params: a, b, c, d
+	KindGaussian    = "gaussian"    // params: center, width
+	KindRectangular = "rectangular" // params: lo, hi
+)
+
+// kindParams lists the parameter names of each kind, in order
+var kindParams = map[string][]string{
+	KindTriangular:  {"a", "b", "c"},
+	KindTrapezoidal: {"a", "b", "c", "d"},
+	KindGaussian:    {"center", "width"},
+	KindRectangular: {"lo", "hi"},
+}
+
+// New creates a built-in membership function from its kind and parameters.
+// Parameters are in the same order as the kind's constructor and its Params method,
+// so New(kind, mf.Params()) rebuilds an equivalent function.
+// Returns error if the kind is unknown, the parameter count is wrong, or the
+// constructor rejects the parameters.
+func New(kind string, params []float64) (MembershipFunction, error) {
+	names, ok := kindParams[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown membership function kind '%s' (supported: %s, %s, %s, %s)",
+			kind, KindTriangular, KindTrapezoidal, KindGaussian, KindRectangular)
+	}
+	if len(params) != len(names) {
+		return nil, fmt.Errorf("%s requires %d parameters %v, got %d: %v", kind, len(names), names, len(params), params)
+	}
+
+	switch kind {
+	case KindTriangular:
+		return NewTriangular(params[0], params[1], params[2])
+	case KindTrapezoidal:
+		return NewTrapezoidal(params[0], params[1], params[2], params[3])
+	case KindGaussian:
+		return NewGaussian(params[0], params[1])
+	default:
+		return NewRectangular(params[0], params[1])
+	}
+}
+
+// Parameterized is a membership function that can be rebuilt with New(mf.Kind(), mf.Params())
+type Parameterized interface {
+	MembershipFunction
+	Kind() string
+	Params() []float64
+}
+
+// Kind returns KindTriangular
+func (t *Triangular) Kind() string { return KindTriangular }
+
+// Kind returns KindTrapezoidal
+func (t *Trapezoidal) Kind() string { return KindTrapezoidal }
+
+// Kind returns KindGaussian
+func (g *Gaussian) Kind() string { return KindGaussian }
+
+// Kind returns KindRectangular
+func (r *Rectangular) Kind() string { return KindRectangular }
+
+// Params returns the parameters [a, b, c] in the order accepted by New
+func (t *Triangular) Params() []float64 {
+	return []float64{t.A, t.B, t.C}
+}
+
+// Params returns the parameters [a, b, c, d] in the order accepted by New
+func (t *Trapezoidal) Params() []float64 {
+	return []float64{t.A, t.B, t.C, t.D}
+}
+
+// Params returns the parameters [center, width] in the order accepted by New
+func (g *Gaussian) Params() []float64 {
+	return []float64{g.Center, g.Width}
+}
+
+// Params returns the parameters [lo, hi] in the order accepted by New
+func (r *Rectangular) Params() []float64 {
+	return []float64{r.Lo, r.Hi}
+}
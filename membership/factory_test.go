@@ -0,0 +1,64 @@
+package membership
+
+import (
+	"testing"
+)
+
+func TestNew_AllKinds(t *testing.T) {
+	cases := []struct {
+		kind   string
+		params []float64
+		x      float64
+		want   float64
+	}{
+		{KindTriangular, []float64{0, 5, 10}, 2.5, 0.5},
+		{KindTrapezoidal, []float64{0, 2, 8, 10}, 9, 0.5},
+		{KindGaussian, []float64{5, 2}, 5, 1},
+		{KindRectangular, []float64{2, 8}, 3, 1},
+	}
+	for _, tc := range cases {
+		t.Run(tc.kind, func(t *testing.T) {
+			mf, err := New(tc.kind, tc.params)
+			if err != nil {
+				t.Fatalf("New(%s, %v) failed: %v", tc.kind, tc.params, err)
+			}
+			if got := mf.Evaluate(tc.x); !floatEqual(got, tc.want) {
+				t.Errorf("Evaluate(%f) = %f, expected %f", tc.x, got, tc.want)
+			}
+
+			// Kind and Params round-trip through New
+			p, ok := mf.(Parameterized)
+			if !ok {
+				t.Fatalf("%T does not implement Parameterized", mf)
+			}
+			if p.Kind() != tc.kind {
+				t.Errorf("Kind() = %s, expected %s", p.Kind(), tc.kind)
+			}
+			params := p.Params()
+			if len(params) != len(tc.params) {
+				t.Fatalf("Params() = %v, expected %v", params, tc.params)
+			}
+			for i := range params {
+				if params[i] != tc.params[i] {
+					t.Errorf("Params() = %v, expected %v", params, tc.params)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestNew_Validation(t *testing.T) {
+	if _, err := New("sigmoid", []float64{1, 2}); err == nil {
+		t.Error("Expected error for unknown kind, got nil")
+	}
+	if _, err := New(KindTriangular, []float64{0, 5}); err == nil {
+		t.Error("Expected error for wrong parameter count, got nil")
+	}
+	if _, err := New(KindTriangular, []float64{10, 5, 0}); err == nil {
+		t.Error("Expected error for invalid triangular parameters, got nil")
+	}
+	if _, err := New(KindGaussian, []float64{0, -1}); err == nil {
+		t.Error("Expected error for non-positive gaussian width, got nil")
+	}
+}
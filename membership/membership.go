@@ -2,12 +2,41 @@ package membership
 
 import (
 	"fmt"
+	"github.com/loian/fuzzylib/internal/polyline"
 	"math"
+	"sort"
 )
 
 // MembershipFunction is the interface all membership functions must implement
 type MembershipFunction interface {
 	Evaluate(x float64) float64 // Returns degree of membership [0, 1]
+	// Support returns the interval [left, right] outside which the degree is 0.
+	// bounded is false if the function may be non-zero arbitrarily far out, in
+	// which case left and right are -Inf and +Inf.
+	Support() (left, right float64, bounded bool)
+}
+
+// Invertible is a monotonic membership function whose degree can be mapped back
+// to the input that produces it, as required for Tsukamoto-style consequents.
+type Invertible interface {
+	MembershipFunction
+	Inverse(degree float64) float64 // Returns x such that Evaluate(x) == degree
+}
+
+// Peaked is a membership function with a single representative location, such
+// as the crisp value weighted-average defuzzifiers use for a fired set.
+type Peaked interface {
+	MembershipFunction
+	Peak() float64 // Returns the representative x, usually where the degree is highest
+}
+
+// AnalyticShape is a membership function that is piecewise linear between known
+// vertices and 0 outside them, so integrals over it can be computed exactly
+// instead of by sampling.
+type AnalyticShape interface {
+	MembershipFunction
+	Vertices() (xs, ys []float64)                               // Returns the vertices in increasing x order
+	ClippedCentroidArea(level float64) (centroid, area float64) // Returns the centroid and area when clipped at level
 }
 
 // Triangular membership function: a (left foot), b (peak), c (right foot)
@@ -62,6 +91,21 @@ func (t *Triangular) Evaluate(x float64) float64 {
 	return (t.C - x) / (t.C - t.B)
 }
 
+// Support returns [A, C]
+func (t *Triangular) Support() (left, right float64, bounded bool) {
+	return t.A, t.C, true
+}
+
+// Peak returns B
+func (t *Triangular) Peak() float64 {
+	return t.B
+}
+
+// Vertices returns (A, 0), (B, 1) and (C, 0)
+func (t *Triangular) Vertices() (xs, ys []float64) {
+	return []float64{t.A, t.B, t.C}, []float64{0, 1, 0}
+}
+
 // Trapezoidal membership function: a, b (left plateau), c, d (right plateau)
 type Trapezoidal struct {
 	A float64
@@ -115,10 +159,31 @@ func (t *Trapezoidal) Evaluate(x float64) float64 {
 	return (t.D - x) / (t.D - t.C)
 }
 
+// Support returns [A, D]
+func (t *Trapezoidal) Support() (left, right float64, bounded bool) {
+	return t.A, t.D, true
+}
+
+// Peak returns the midpoint of the plateau [B, C], which for a singleton
+// (A == B == C == D) is the point itself
+func (t *Trapezoidal) Peak() float64 {
+	return (t.B + t.C) / 2
+}
+
+// Vertices returns (A, 0), (B, 1), (C, 1) and (D, 0)
+func (t *Trapezoidal) Vertices() (xs, ys []float64) {
+	return []float64{t.A, t.B, t.C, t.D}, []float64{0, 1, 1, 0}
+}
+
 // Gaussian membership function: center (μ) and width (σ)
 type Gaussian struct {
 	Center float64 // μ
 	Width  float64 // σ
+
+	// coeff caches -1/(2σ²) for coeffWidth. Evaluate falls back to computing it
+	// when Width differs, e.g. for struct literals or after Width is changed.
+	coeff      float64
+	coeffWidth float64
 }
 
 // NewGaussian creates a new Gaussian membership function.
@@ -128,11 +193,275 @@ func NewGaussian(center, width float64) (*Gaussian, error) {
 	if width <= 0 {
 		return nil, fmt.Errorf("gaussian width must be > 0, got %.2f", width)
 	}
-	return &Gaussian{Center: center, Width: width}, nil
+	return &Gaussian{Center: center, Width: width, coeff: -1 / (2 * width * width), coeffWidth: width}, nil
 }
 
 // Evaluate returns the membership degree for value x
 func (g *Gaussian) Evaluate(x float64) float64 {
-	exponent := -((x - g.Center) * (x - g.Center)) / (2 * g.Width * g.Width)
+	d := x - g.Center
+	if g.coeffWidth == g.Width {
+		return math.Exp(d * d * g.coeff)
+	}
+	return math.Exp(-(d * d) / (2 * g.Width * g.Width))
+}
+
+// Support is unbounded: a Gaussian is positive everywhere
+func (g *Gaussian) Support() (left, right float64, bounded bool) {
+	return math.Inf(-1), math.Inf(1), false
+}
+
+// Peak returns Center
+func (g *Gaussian) Peak() float64 {
+	return g.Center
+}
+
+// RisingHalf returns the monotonically non-decreasing left half of the Gaussian:
+// it follows the curve up to Center and stays at 1.0 beyond it.
+func (g *Gaussian) RisingHalf() *GaussianHalf {
+	return &GaussianHalf{Center: g.Center, Width: g.Width, Rising: true}
+}
+
+// FallingHalf returns the monotonically non-increasing right half of the Gaussian:
+// it is 1.0 up to Center and follows the curve beyond it.
+func (g *Gaussian) FallingHalf() *GaussianHalf {
+	return &GaussianHalf{Center: g.Center, Width: g.Width, Rising: false}
+}
+
+// GaussianHalf is one monotonic half of a Gaussian, saturating at 1.0 on the
+// other side of Center. Unlike the full Gaussian it is Invertible.
+type GaussianHalf struct {
+	Center float64 // μ
+	Width  float64 // σ
+	Rising bool    // true for the left (rising) half, false for the right (falling) half
+}
+
+// Evaluate returns the membership degree for value x
+func (h *GaussianHalf) Evaluate(x float64) float64 {
+	if (h.Rising && x >= h.Center) || (!h.Rising && x <= h.Center) {
+		return 1.0
+	}
+	exponent := -((x - h.Center) * (x - h.Center)) / (2 * h.Width * h.Width)
 	return math.Exp(exponent)
 }
+
+// Support is unbounded: the slope is positive everywhere and the other side saturates at 1
+func (h *GaussianHalf) Support() (left, right float64, bounded bool) {
+	return math.Inf(-1), math.Inf(1), false
+}
+
+// Peak returns Center, where the half reaches 1.0
+func (h *GaussianHalf) Peak() float64 {
+	return h.Center
+}
+
+// Inverse returns the x on the half's slope with the given membership degree.
+// Degrees are clamped to [0, 1]: 1 maps to Center and 0 maps to -Inf for the
+// rising half or +Inf for the falling half.
+func (h *GaussianHalf) Inverse(degree float64) float64 {
+	degree = math.Max(0, math.Min(1, degree))
+	offset := h.Width * math.Sqrt(-2*math.Log(degree))
+	if h.Rising {
+		return h.Center - offset
+	}
+	return h.Center + offset
+}
+
+// Rectangular membership function: a crisp interval [Lo, Hi]
+type Rectangular struct {
+	Lo float64
+	Hi float64
+}
+
+// NewRectangular creates a new rectangular (crisp interval) membership function.
+// Parameters must satisfy: lo < hi
+// Returns error if parameters are invalid.
+func NewRectangular(lo, hi float64) (*Rectangular, error) {
+	if lo >= hi {
+		return nil, fmt.Errorf("rectangular parameters must satisfy lo < hi, got lo=%.2f, hi=%.2f", lo, hi)
+	}
+	return &Rectangular{Lo: lo, Hi: hi}, nil
+}
+
+// Evaluate returns 1.0 for x in [Lo, Hi] (boundaries included) and 0.0 otherwise
+func (r *Rectangular) Evaluate(x float64) float64 {
+	if x >= r.Lo && x <= r.Hi {
+		return 1.0
+	}
+	return 0.0
+}
+
+// Support returns [Lo, Hi]
+func (r *Rectangular) Support() (left, right float64, bounded bool) {
+	return r.Lo, r.Hi, true
+}
+
+// Peak returns the midpoint of [Lo, Hi]
+func (r *Rectangular) Peak() float64 {
+	return (r.Lo + r.Hi) / 2
+}
+
+// PiecewiseLinear membership function: linear interpolation between breakpoints
+// (X[i], Y[i]), for shapes that do not fit the triangular or trapezoidal templates
+type PiecewiseLinear struct {
+	X []float64 // breakpoint x-values, strictly increasing
+	Y []float64 // membership degree at each breakpoint, in [0, 1]
+}
+
+// NewPiecewiseLinear creates a piecewise-linear membership function from (x, degree) points.
+// Points must be sorted with strictly increasing x-values.
+// Returns error if there are fewer than two points, x-values are not strictly
+// increasing, or any degree is outside [0, 1].
+func NewPiecewiseLinear(points [][2]float64) (*PiecewiseLinear, error) {
+	if len(points) < 2 {
+		return nil, fmt.Errorf("piecewise-linear function requires at least 2 points, got %d", len(points))
+	}
+	pl := &PiecewiseLinear{X: make([]float64, len(points)), Y: make([]float64, len(points))}
+	for i, p := range points {
+		if math.IsNaN(p[0]) || math.IsInf(p[0], 0) {
+			return nil, fmt.Errorf("point %d x-value must be finite, got %v", i+1, p[0])
+		}
+		if i > 0 && p[0] <= points[i-1][0] {
+			return nil, fmt.Errorf("point x-values must be strictly increasing, got %.2f after %.2f", p[0], points[i-1][0])
+		}
+		if !(p[1] >= 0 && p[1] <= 1) {
+			return nil, fmt.Errorf("point %d degree must be in range [0, 1], got %v", i+1, p[1])
+		}
+		pl.X[i] = p[0]
+		pl.Y[i] = p[1]
+	}
+	return pl, nil
+}
+
+// Evaluate returns the degree interpolated between the breakpoints around x, and
+// 0.0 outside [X[0], X[len-1]] or for NaN. Breakpoints are located by binary search.
+func (p *PiecewiseLinear) Evaluate(x float64) float64 {
+	n := len(p.X)
+	if n == 0 || math.IsNaN(x) || x < p.X[0] || x > p.X[n-1] {
+		return 0.0
+	}
+	i := sort.SearchFloat64s(p.X, x) // first breakpoint with X[i] >= x
+	if p.X[i] == x {
+		return p.Y[i]
+	}
+	x0, x1 := p.X[i-1], p.X[i]
+	return p.Y[i-1] + (p.Y[i]-p.Y[i-1])*(x-x0)/(x1-x0)
+}
+
+// Support returns [X[0], X[len-1]]
+func (p *PiecewiseLinear) Support() (left, right float64, bounded bool) {
+	if len(p.X) == 0 {
+		return 0, 0, true
+	}
+	return p.X[0], p.X[len(p.X)-1], true
+}
+
+// Peak returns the midpoint of the first run of consecutive breakpoints at the
+// highest degree. Returns 0 if there are no breakpoints.
+func (p *PiecewiseLinear) Peak() float64 {
+	if len(p.X) == 0 {
+		return 0
+	}
+	first, last := 0, 0
+	for i := 1; i < len(p.Y); i++ {
+		switch {
+		case p.Y[i] > p.Y[first]:
+			first, last = i, i
+		case p.Y[i] == p.Y[first] && last == i-1:
+			last = i
+		}
+	}
+	return (p.X[first] + p.X[last]) / 2
+}
+
+// Custom membership function backed by an arbitrary Go function, for one-off
+// shapes that do not warrant a dedicated type
+type Custom struct {
+	Fn func(float64) float64
+}
+
+// NewCustom creates a membership function that evaluates fn.
+// Returns error if fn is nil.
+func NewCustom(fn func(float64) float64) (*Custom, error) {
+	if fn == nil {
+		return nil, fmt.Errorf("custom membership function cannot be nil")
+	}
+	return &Custom{Fn: fn}, nil
+}
+
+// Evaluate returns Fn(x) clamped to [0, 1], with NaN mapped to 0, so operators
+// and defuzzification never see an out-of-range degree whatever Fn returns
+func (c *Custom) Evaluate(x float64) float64 {
+	y := c.Fn(x)
+	if math.IsNaN(y) {
+		return 0.0
+	}
+	return math.Max(0, math.Min(1, y))
+}
+
+// Support is unbounded: nothing is known about Fn
+func (c *Custom) Support() (left, right float64, bounded bool) {
+	return math.Inf(-1), math.Inf(1), false
+}
+
+// Scaled wraps another membership function and multiplies its degrees by Factor,
+// clamping the result to [0, 1]. Used to normalize non-normal sets.
+type Scaled struct {
+	MF     MembershipFunction
+	Factor float64
+}
+
+// Evaluate returns Factor * MF(x), clamped to [0, 1]
+func (s *Scaled) Evaluate(x float64) float64 {
+	return math.Max(0, math.Min(1, s.Factor*s.MF.Evaluate(x)))
+}
+
+// Support returns the support of the wrapped function
+func (s *Scaled) Support() (left, right float64, bounded bool) {
+	return s.MF.Support()
+}
+
+// Peak returns the peak of the wrapped function, or NaN if it is not Peaked
+func (s *Scaled) Peak() float64 {
+	if p, ok := s.MF.(Peaked); ok {
+		return p.Peak()
+	}
+	return math.NaN()
+}
+
+// ClippedCentroidArea returns the centroid and area of the triangle truncated at
+// the given level, as produced by min-implication (the clipped shape is a
+// trapezoid). Levels above 1 are treated as 1 (no truncation).
+// Returns (0, 0) if level <= 0 or the triangle has zero width.
+func (t *Triangular) ClippedCentroidArea(level float64) (centroid, area float64) {
+	if level <= 0 || t.A == t.C {
+		return 0, 0
+	}
+	if level > 1 {
+		level = 1
+	}
+	left := t.A + level*(t.B-t.A)
+	right := t.C - level*(t.C-t.B)
+	return polyline.CentroidArea(
+		[]float64{t.A, left, right, t.C},
+		[]float64{0, level, level, 0},
+	)
+}
+
+// ClippedCentroidArea returns the centroid and area of the trapezoid truncated at
+// the given level, as produced by min-implication. Levels above 1 are treated
+// as 1 (no truncation).
+// Returns (0, 0) if level <= 0 or the trapezoid has zero width.
+func (t *Trapezoidal) ClippedCentroidArea(level float64) (centroid, area float64) {
+	if level <= 0 || t.A == t.D {
+		return 0, 0
+	}
+	if level > 1 {
+		level = 1
+	}
+	left := t.A + level*(t.B-t.A)
+	right := t.D - level*(t.D-t.C)
+	return polyline.CentroidArea(
+		[]float64{t.A, left, right, t.D},
+		[]float64{0, level, level, 0},
+	)
+}
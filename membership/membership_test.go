@@ -163,6 +163,47 @@ func TestGaussian_DecaysWithDistance(t *testing.T) {
 	}
 }
 
+func TestGaussian_PrecomputedCoefficient(t *testing.T) {
+	fast, _ := NewGaussian(5, 2)
+	literal := &Gaussian{Center: 5, Width: 2}
+
+	for x := -5.0; x <= 15; x += 0.25 {
+		want := math.Exp(-((x - 5) * (x - 5)) / (2 * 2 * 2))
+		if got := fast.Evaluate(x); math.Abs(got-want) > 1e-15 {
+			t.Errorf("NewGaussian Evaluate(%f) = %g, expected %g", x, got, want)
+		}
+		if got := literal.Evaluate(x); math.Abs(got-want) > 1e-15 {
+			t.Errorf("literal Gaussian Evaluate(%f) = %g, expected %g", x, got, want)
+		}
+	}
+
+	// Changing Width after construction must not use the stale coefficient
+	fast.Width = 4
+	want := math.Exp(-(3.0 * 3.0) / (2 * 4 * 4))
+	if got := fast.Evaluate(8); math.Abs(got-want) > 1e-15 {
+		t.Errorf("Evaluate after Width change = %g, expected %g", got, want)
+	}
+}
+
+func BenchmarkGaussianEvaluate(b *testing.B) {
+	b.Run("precomputed", func(b *testing.B) {
+		g, _ := NewGaussian(50, 10)
+		benchmarkEvaluate(b, g)
+	})
+	b.Run("literal", func(b *testing.B) {
+		benchmarkEvaluate(b, &Gaussian{Center: 50, Width: 10})
+	})
+}
+
+// benchmarkEvaluate sweeps mf across [0, 100] the way a defuzzification pass does
+func benchmarkEvaluate(b *testing.B, mf MembershipFunction) {
+	var sink float64
+	for i := 0; i < b.N; i++ {
+		sink += mf.Evaluate(float64(i%1000) * 0.1)
+	}
+	_ = sink
+}
+
 // ===== Integration Tests =====
 
 func TestConstructors(t *testing.T) {
@@ -197,3 +238,321 @@ func TestMembershipFunctionInterface(t *testing.T) {
 		_ = f.Evaluate(5)
 	}
 }
+
+// ===== Rectangular Tests =====
+
+func TestRectangular_InsideOutside(t *testing.T) {
+	rect, _ := NewRectangular(2, 8)
+
+	tests := []struct {
+		input    float64
+		expected float64
+	}{
+		{0, 0.0},
+		{1.999, 0.0},
+		{2, 1.0}, // lower boundary included
+		{5, 1.0},
+		{8, 1.0}, // upper boundary included
+		{8.001, 0.0},
+		{10, 0.0},
+	}
+	for _, tt := range tests {
+		if got := rect.Evaluate(tt.input); got != tt.expected {
+			t.Errorf("Evaluate(%f) = %f, expected %f", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestNewRectangular_Validation(t *testing.T) {
+	if _, err := NewRectangular(5, 5); err == nil {
+		t.Error("Expected error for lo == hi, got nil")
+	}
+	if _, err := NewRectangular(8, 2); err == nil {
+		t.Error("Expected error for lo > hi, got nil")
+	}
+}
+
+// ===== Clipped Centroid Tests =====
+
+// numericClippedCentroidArea integrates min(mf(x), level) over [lo, hi] with the midpoint rule
+func numericClippedCentroidArea(mf MembershipFunction, level, lo, hi float64) (float64, float64) {
+	const n = 200000
+	step := (hi - lo) / n
+	moment, area := 0.0, 0.0
+	for i := 0; i < n; i++ {
+		x := lo + (float64(i)+0.5)*step
+		y := math.Min(mf.Evaluate(x), level)
+		moment += x * y * step
+		area += y * step
+	}
+	return moment / area, area
+}
+
+func TestTriangular_ClippedCentroidArea(t *testing.T) {
+	tri, _ := NewTriangular(0, 2, 10)
+	for _, level := range []float64{0.1, 0.25, 0.5, 0.75, 0.9, 1.0} {
+		centroid, area := tri.ClippedCentroidArea(level)
+		wantCentroid, wantArea := numericClippedCentroidArea(tri, level, 0, 10)
+		if math.Abs(centroid-wantCentroid) > 1e-6 || math.Abs(area-wantArea) > 1e-6 {
+			t.Errorf("level %.2f: analytic (%f, %f), numeric (%f, %f)", level, centroid, area, wantCentroid, wantArea)
+		}
+	}
+
+	// Unclipped triangle: centroid (a+b+c)/3, area base/2
+	centroid, area := tri.ClippedCentroidArea(1.0)
+	if !floatEqual(centroid, 4.0) || !floatEqual(area, 5.0) {
+		t.Errorf("Expected unclipped centroid 4 and area 5, got %f and %f", centroid, area)
+	}
+}
+
+func TestTrapezoidal_ClippedCentroidArea(t *testing.T) {
+	trap, _ := NewTrapezoidal(0, 1, 4, 10)
+	for _, level := range []float64{0.1, 0.5, 0.9, 1.0} {
+		centroid, area := trap.ClippedCentroidArea(level)
+		wantCentroid, wantArea := numericClippedCentroidArea(trap, level, 0, 10)
+		if math.Abs(centroid-wantCentroid) > 1e-6 || math.Abs(area-wantArea) > 1e-6 {
+			t.Errorf("level %.2f: analytic (%f, %f), numeric (%f, %f)", level, centroid, area, wantCentroid, wantArea)
+		}
+	}
+}
+
+func TestClippedCentroidArea_ZeroLevel(t *testing.T) {
+	tri, _ := NewTriangular(0, 5, 10)
+	if centroid, area := tri.ClippedCentroidArea(0); centroid != 0 || area != 0 {
+		t.Errorf("Expected (0, 0) for zero level, got (%f, %f)", centroid, area)
+	}
+}
+
+func TestGaussianHalves_Inverse(t *testing.T) {
+	g, _ := NewGaussian(50, 10)
+	halves := map[string]Invertible{"rising": g.RisingHalf(), "falling": g.FallingHalf()}
+	for name, half := range halves {
+		for _, degree := range []float64{0.01, 0.25, 0.5, 0.9, 1} {
+			x := half.Inverse(degree)
+			if got := half.Evaluate(x); math.Abs(got-degree) > 1e-9 {
+				t.Errorf("%s: Evaluate(Inverse(%f)) = %f", name, degree, got)
+			}
+			if got := g.Evaluate(x); math.Abs(got-degree) > 1e-9 {
+				t.Errorf("%s: Inverse(%f) = %f is not on the original gaussian (%f)", name, degree, x, got)
+			}
+		}
+	}
+
+	rising, falling := g.RisingHalf(), g.FallingHalf()
+	if x := rising.Inverse(0.5); x >= 50 {
+		t.Errorf("Expected rising half inverse left of center, got %f", x)
+	}
+	if x := falling.Inverse(0.5); x <= 50 {
+		t.Errorf("Expected falling half inverse right of center, got %f", x)
+	}
+	if x := rising.Inverse(0); !math.IsInf(x, -1) {
+		t.Errorf("Expected rising half Inverse(0) = -Inf, got %f", x)
+	}
+	if x := falling.Inverse(0); !math.IsInf(x, 1) {
+		t.Errorf("Expected falling half Inverse(0) = +Inf, got %f", x)
+	}
+}
+
+func TestGaussianHalves_Monotonic(t *testing.T) {
+	g, _ := NewGaussian(0, 3)
+	rising, falling := g.RisingHalf(), g.FallingHalf()
+	prevRising, prevFalling := rising.Evaluate(-20), falling.Evaluate(-20)
+	for x := -20.0; x <= 20.0; x += 0.1 {
+		r, f := rising.Evaluate(x), falling.Evaluate(x)
+		if r < prevRising {
+			t.Fatalf("Rising half decreased at x=%f: %f < %f", x, r, prevRising)
+		}
+		if f > prevFalling {
+			t.Fatalf("Falling half increased at x=%f: %f > %f", x, f, prevFalling)
+		}
+		prevRising, prevFalling = r, f
+	}
+}
+
+func TestPiecewiseLinear_WShape(t *testing.T) {
+	w, err := NewPiecewiseLinear([][2]float64{{0, 1}, {2, 0}, {5, 0.8}, {8, 0}, {10, 1}})
+	if err != nil {
+		t.Fatalf("NewPiecewiseLinear failed: %v", err)
+	}
+
+	tests := []struct {
+		x, expected float64
+	}{
+		{-1, 0}, {0, 1}, {1, 0.5}, {2, 0}, {3.5, 0.4}, {5, 0.8},
+		{6.5, 0.4}, {8, 0}, {9, 0.5}, {10, 1}, {11, 0}, {math.NaN(), 0},
+	}
+	for _, tt := range tests {
+		if got := w.Evaluate(tt.x); !floatEqual(got, tt.expected) {
+			t.Errorf("Evaluate(%f) = %f, expected %f", tt.x, got, tt.expected)
+		}
+	}
+
+	// Non-monotonic: falls, rises, falls and rises again
+	if !(w.Evaluate(1) > w.Evaluate(2) && w.Evaluate(2) < w.Evaluate(5) &&
+		w.Evaluate(5) > w.Evaluate(8) && w.Evaluate(8) < w.Evaluate(10)) {
+		t.Error("Expected W-shaped curve to alternate direction")
+	}
+}
+
+func TestNewPiecewiseLinear_Validation(t *testing.T) {
+	tests := []struct {
+		name   string
+		points [][2]float64
+	}{
+		{"too few points", [][2]float64{{0, 1}}},
+		{"unsorted x", [][2]float64{{0, 0}, {5, 1}, {3, 0}}},
+		{"duplicate x", [][2]float64{{0, 0}, {5, 1}, {5, 0}}},
+		{"degree above 1", [][2]float64{{0, 0}, {5, 1.2}}},
+		{"negative degree", [][2]float64{{0, -0.1}, {5, 1}}},
+		{"NaN degree", [][2]float64{{0, 0}, {5, math.NaN()}}},
+		{"infinite x", [][2]float64{{0, 0}, {math.Inf(1), 1}}},
+	}
+	for _, tt := range tests {
+		if _, err := NewPiecewiseLinear(tt.points); err == nil {
+			t.Errorf("%s: expected error, got nil", tt.name)
+		}
+	}
+}
+
+func TestCustom(t *testing.T) {
+	bump, err := NewCustom(func(x float64) float64 { return 1 - (x-5)*(x-5)/25 })
+	if err != nil {
+		t.Fatalf("NewCustom failed: %v", err)
+	}
+	if got := bump.Evaluate(5); got != 1 {
+		t.Errorf("Evaluate(5) = %f, expected 1", got)
+	}
+	if got := bump.Evaluate(2.5); !floatEqual(got, 0.75) {
+		t.Errorf("Evaluate(2.5) = %f, expected 0.75", got)
+	}
+	// Out-of-range function values are clamped
+	if got := bump.Evaluate(20); got != 0 {
+		t.Errorf("Evaluate(20) = %f, expected clamp to 0", got)
+	}
+	over, _ := NewCustom(func(x float64) float64 { return 2 })
+	if got := over.Evaluate(0); got != 1 {
+		t.Errorf("Evaluate = %f, expected clamp to 1", got)
+	}
+	nan, _ := NewCustom(func(x float64) float64 { return math.NaN() })
+	if got := nan.Evaluate(0); got != 0 {
+		t.Errorf("Evaluate = %f, expected NaN mapped to 0", got)
+	}
+
+	if _, err := NewCustom(nil); err == nil {
+		t.Error("Expected error for nil function, got nil")
+	}
+}
+
+// ===== Conformance Tests =====
+
+// conformanceCase describes the contract a membership function must satisfy
+type conformanceCase struct {
+	name    string
+	mf      MembershipFunction
+	bounded bool         // true for finite-support types
+	left    float64      // support lower bound (bounded only)
+	right   float64      // support upper bound (bounded only)
+	points  [][2]float64 // declared degenerate cases: {x, expected}
+}
+
+func mustConform(mf MembershipFunction, err error) MembershipFunction {
+	if err != nil {
+		panic(err)
+	}
+	return mf
+}
+
+func conformanceCases() []conformanceCase {
+	return []conformanceCase{
+		{name: "triangular", mf: mustConform(NewTriangular(0, 5, 10)), bounded: true, left: 0, right: 10,
+			points: [][2]float64{{5, 1}}},
+		{name: "triangular left shoulder", mf: mustConform(NewTriangular(0, 0, 10)), bounded: true, left: 0, right: 10,
+			points: [][2]float64{{0, 0}}},
+		{name: "triangular right shoulder", mf: mustConform(NewTriangular(0, 10, 10)), bounded: true, left: 0, right: 10,
+			points: [][2]float64{{10, 0}}},
+		{name: "triangular impulse", mf: mustConform(NewTriangular(5, 5, 5)), bounded: true, left: 5, right: 5,
+			points: [][2]float64{{5, 1}}},
+		{name: "trapezoidal", mf: mustConform(NewTrapezoidal(0, 2, 8, 10)), bounded: true, left: 0, right: 10,
+			points: [][2]float64{{2, 1}, {5, 1}, {8, 1}}},
+		{name: "trapezoidal left shoulder", mf: mustConform(NewTrapezoidal(0, 0, 5, 10)), bounded: true, left: 0, right: 10,
+			points: [][2]float64{{0, 0}, {2.5, 1}}},
+		{name: "trapezoidal right shoulder", mf: mustConform(NewTrapezoidal(0, 5, 10, 10)), bounded: true, left: 0, right: 10,
+			points: [][2]float64{{10, 0}, {7.5, 1}}},
+		{name: "trapezoidal impulse", mf: mustConform(NewTrapezoidal(5, 5, 5, 5)), bounded: true, left: 5, right: 5,
+			points: [][2]float64{{5, 1}}},
+		{name: "gaussian", mf: mustConform(NewGaussian(5, 2)), bounded: false,
+			points: [][2]float64{{5, 1}}},
+		{name: "gaussian rising half", mf: mustConform(NewGaussian(5, 2)).(*Gaussian).RisingHalf(), bounded: false,
+			points: [][2]float64{{5, 1}, {20, 1}}},
+		{name: "gaussian falling half", mf: mustConform(NewGaussian(5, 2)).(*Gaussian).FallingHalf(), bounded: false,
+			points: [][2]float64{{5, 1}, {-10, 1}}},
+		{name: "rectangular", mf: mustConform(NewRectangular(2, 8)), bounded: true, left: 2, right: 8,
+			points: [][2]float64{{2, 1}, {5, 1}, {8, 1}}},
+		{name: "piecewise linear", mf: mustConform(NewPiecewiseLinear([][2]float64{{0, 1}, {2.5, 0}, {5, 1}, {7.5, 0}, {10, 1}})), bounded: true, left: 0, right: 10,
+			points: [][2]float64{{0, 1}, {5, 1}, {10, 1}}},
+		{name: "custom", mf: &Custom{Fn: func(x float64) float64 { return math.Sin(x) * 2 }}, bounded: false,
+			points: [][2]float64{{0, 0}, {math.Pi / 2, 1}}},
+		{name: "scaled", mf: &Scaled{MF: mustConform(NewTriangular(0, 5, 10)), Factor: 2}, bounded: true, left: 0, right: 10,
+			points: [][2]float64{{2.5, 1}, {1, 0.4}}},
+	}
+}
+
+func TestMembershipConformance(t *testing.T) {
+	for _, tc := range conformanceCases() {
+		t.Run(tc.name, func(t *testing.T) {
+			left, right, bounded := tc.mf.Support()
+			if bounded != tc.bounded || (bounded && (left != tc.left || right != tc.right)) {
+				t.Fatalf("Support() = [%f, %f] bounded=%v, expected [%f, %f] bounded=%v",
+					left, right, bounded, tc.left, tc.right, tc.bounded)
+			}
+			if !bounded && (!math.IsInf(left, -1) || !math.IsInf(right, 1)) {
+				t.Errorf("Unbounded Support() = [%f, %f], expected [-Inf, +Inf]", left, right)
+			}
+			for x := -20.0; x <= 30.0; x += 0.01 {
+				y := tc.mf.Evaluate(x)
+				if math.IsNaN(y) || y < 0 || y > 1 {
+					t.Fatalf("Evaluate(%f) = %f, expected value in [0, 1]", x, y)
+				}
+				if tc.bounded && (x < tc.left || x > tc.right) && y != 0 {
+					t.Fatalf("Evaluate(%f) = %f outside support [%f, %f], expected 0", x, y, tc.left, tc.right)
+				}
+			}
+			for _, p := range tc.points {
+				if got := tc.mf.Evaluate(p[0]); !floatEqual(got, p[1]) {
+					t.Errorf("Evaluate(%f) = %f, expected %f", p[0], got, p[1])
+				}
+			}
+		})
+	}
+}
+
+func TestPeak(t *testing.T) {
+	tests := []struct {
+		name     string
+		mf       Peaked
+		expected float64
+	}{
+		{"triangular", &Triangular{A: 0, B: 3, C: 10}, 3},
+		{"triangular left shoulder", &Triangular{A: 0, B: 0, C: 10}, 0},
+		{"trapezoidal", &Trapezoidal{A: 0, B: 2, C: 6, D: 10}, 4},
+		{"singleton", &Trapezoidal{A: 7, B: 7, C: 7, D: 7}, 7},
+		{"gaussian", &Gaussian{Center: 5, Width: 2}, 5},
+		{"gaussian rising half", &GaussianHalf{Center: 5, Width: 2, Rising: true}, 5},
+		{"rectangular", &Rectangular{Lo: 2, Hi: 8}, 5},
+		{"piecewise linear plateau", mustConform(NewPiecewiseLinear([][2]float64{{0, 0}, {2, 0.8}, {4, 0.8}, {6, 0}})).(Peaked), 3},
+		{"piecewise linear first maximum", mustConform(NewPiecewiseLinear([][2]float64{{0, 1}, {2.5, 0}, {5, 1}})).(Peaked), 0},
+		{"scaled", &Scaled{MF: &Triangular{A: 0, B: 3, C: 10}, Factor: 2}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.mf.Peak(); !floatEqual(got, tt.expected) {
+				t.Errorf("Peak() = %f, expected %f", got, tt.expected)
+			}
+		})
+	}
+
+	custom := &Scaled{MF: &Custom{Fn: math.Sin}, Factor: 1}
+	if got := custom.Peak(); !math.IsNaN(got) {
+		t.Errorf("Expected NaN peak for scaled custom function, got %f", got)
+	}
+}
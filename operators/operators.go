@@ -3,6 +3,12 @@ package operators
 import (
 	"errors"
 	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 )
 
 // Operator defines the interface for fuzzy logic operators
@@ -153,6 +159,310 @@ func (n *NotOperator) Apply(values ...float64) (float64, error) {
 	return result, nil
 }
 
+// SugenoComplement implements the Sugeno NOT operator (1 - x) / (1 + lambda*x).
+// Lambda 0 gives the standard complement 1 - x.
+type SugenoComplement struct {
+	Lambda float64
+}
+
+// NewSugenoComplement creates a Sugeno complement with the given lambda.
+// Returns error if lambda is not greater than -1.
+func NewSugenoComplement(lambda float64) (*SugenoComplement, error) {
+	if !(lambda > -1) || math.IsInf(lambda, 1) {
+		return nil, fmt.Errorf("sugeno lambda must be > -1, got %.2f", lambda)
+	}
+	return &SugenoComplement{Lambda: lambda}, nil
+}
+
+// Apply returns the Sugeno complement of a single value.
+// Input value should be in range [0, 1] for valid membership degree.
+func (s *SugenoComplement) Apply(values ...float64) (float64, error) {
+	if len(values) == 0 {
+		return 1.0, nil
+	}
+	v, invalidErr := clampDegree(values[0])
+	result := (1 - v) / (1 + s.Lambda*v)
+	if invalidErr != nil {
+		return result, invalidErr
+	}
+	return result, nil
+}
+
+// YagerComplement implements the Yager NOT operator (1 - x^w)^(1/w).
+// W 1 gives the standard complement 1 - x.
+type YagerComplement struct {
+	W float64
+}
+
+// NewYagerComplement creates a Yager complement with the given w.
+// Returns error if w is not positive.
+func NewYagerComplement(w float64) (*YagerComplement, error) {
+	if !(w > 0) || math.IsInf(w, 1) {
+		return nil, fmt.Errorf("yager w must be > 0, got %.2f", w)
+	}
+	return &YagerComplement{W: w}, nil
+}
+
+// Apply returns the Yager complement of a single value.
+// Input value should be in range [0, 1] for valid membership degree.
+func (y *YagerComplement) Apply(values ...float64) (float64, error) {
+	if len(values) == 0 {
+		return 1.0, nil
+	}
+	v, invalidErr := clampDegree(values[0])
+	result := math.Pow(1-math.Pow(v, y.W), 1/y.W)
+	if invalidErr != nil {
+		return result, invalidErr
+	}
+	return result, nil
+}
+
+// ProductOperator implements the AND operator using the algebraic product
+type ProductOperator struct{}
+
+// Apply returns the product of all input values.
+// All values should be in range [0, 1] for valid membership degrees.
+func (p *ProductOperator) Apply(values ...float64) (float64, error) {
+	if len(values) == 0 {
+		return 0.0, nil
+	}
+	result := 1.0
+	var invalidErr error
+	for _, raw := range values {
+		v, err := clampDegree(raw)
+		if err != nil && invalidErr == nil {
+			invalidErr = err
+		}
+		result *= v
+	}
+	if invalidErr != nil {
+		return result, invalidErr
+	}
+	return result, nil
+}
+
+// ProbOrOperator implements the OR operator using the probabilistic sum
+// (a + b - a*b, applied pairwise)
+type ProbOrOperator struct{}
+
+// Apply returns the probabilistic sum of all input values.
+// All values should be in range [0, 1] for valid membership degrees.
+func (p *ProbOrOperator) Apply(values ...float64) (float64, error) {
+	if len(values) == 0 {
+		return 0.0, nil
+	}
+	result := 0.0
+	var invalidErr error
+	for _, raw := range values {
+		v, err := clampDegree(raw)
+		if err != nil && invalidErr == nil {
+			invalidErr = err
+		}
+		result = result + v - result*v
+	}
+	if invalidErr != nil {
+		return result, invalidErr
+	}
+	return result, nil
+}
+
+// LukasiewiczAndOperator implements the AND operator using the bounded difference
+// max(0, a + b - 1), applied pairwise
+type LukasiewiczAndOperator struct{}
+
+// Apply returns the bounded difference of all input values.
+// All values should be in range [0, 1] for valid membership degrees.
+func (l *LukasiewiczAndOperator) Apply(values ...float64) (float64, error) {
+	if len(values) == 0 {
+		return 0.0, nil
+	}
+	result := 1.0
+	var invalidErr error
+	for _, raw := range values {
+		v, err := clampDegree(raw)
+		if err != nil && invalidErr == nil {
+			invalidErr = err
+		}
+		result = math.Max(0, result+v-1)
+	}
+	if invalidErr != nil {
+		return result, invalidErr
+	}
+	return result, nil
+}
+
+// LukasiewiczOrOperator implements the OR operator using the bounded sum
+// min(1, a + b), applied pairwise
+type LukasiewiczOrOperator struct{}
+
+// Apply returns the bounded sum of all input values.
+// All values should be in range [0, 1] for valid membership degrees.
+func (l *LukasiewiczOrOperator) Apply(values ...float64) (float64, error) {
+	if len(values) == 0 {
+		return 0.0, nil
+	}
+	result := 0.0
+	var invalidErr error
+	for _, raw := range values {
+		v, err := clampDegree(raw)
+		if err != nil && invalidErr == nil {
+			invalidErr = err
+		}
+		result = math.Min(1, result+v)
+	}
+	if invalidErr != nil {
+		return result, invalidErr
+	}
+	return result, nil
+}
+
+// EinsteinProductOperator implements the AND operator using the Einstein product
+// a*b / (2 - (a + b - a*b)), applied pairwise
+type EinsteinProductOperator struct{}
+
+// Apply returns the Einstein product of all input values.
+// All values should be in range [0, 1] for valid membership degrees.
+func (e *EinsteinProductOperator) Apply(values ...float64) (float64, error) {
+	if len(values) == 0 {
+		return 0.0, nil
+	}
+	result := 1.0
+	var invalidErr error
+	for _, raw := range values {
+		v, err := clampDegree(raw)
+		if err != nil && invalidErr == nil {
+			invalidErr = err
+		}
+		result = result * v / (2 - (result + v - result*v))
+	}
+	if invalidErr != nil {
+		return result, invalidErr
+	}
+	return result, nil
+}
+
+// EinsteinSumOperator implements the OR operator using the Einstein sum
+// (a + b) / (1 + a*b), applied pairwise
+type EinsteinSumOperator struct{}
+
+// Apply returns the Einstein sum of all input values.
+// All values should be in range [0, 1] for valid membership degrees.
+func (e *EinsteinSumOperator) Apply(values ...float64) (float64, error) {
+	if len(values) == 0 {
+		return 0.0, nil
+	}
+	result := 0.0
+	var invalidErr error
+	for _, raw := range values {
+		v, err := clampDegree(raw)
+		if err != nil && invalidErr == nil {
+			invalidErr = err
+		}
+		result = (result + v) / (1 + result*v)
+	}
+	if invalidErr != nil {
+		return result, invalidErr
+	}
+	return result, nil
+}
+
+// HamacherAndOperator implements the Hamacher t-norm
+// a*b / (gamma + (1 - gamma)(a + b - a*b)), applied pairwise.
+// Gamma 1 gives the algebraic product and gamma 2 the Einstein product.
+type HamacherAndOperator struct {
+	Gamma float64
+}
+
+// NewHamacherAnd creates a Hamacher AND operator with the given gamma.
+// Returns error if gamma is negative.
+func NewHamacherAnd(gamma float64) (*HamacherAndOperator, error) {
+	if gamma < 0 || math.IsNaN(gamma) {
+		return nil, fmt.Errorf("hamacher gamma must be >= 0, got %.2f", gamma)
+	}
+	return &HamacherAndOperator{Gamma: gamma}, nil
+}
+
+// Apply returns the Hamacher t-norm of all input values.
+// All values should be in range [0, 1] for valid membership degrees.
+func (h *HamacherAndOperator) Apply(values ...float64) (float64, error) {
+	if len(values) == 0 {
+		return 0.0, nil
+	}
+	result := 1.0
+	var invalidErr error
+	for _, raw := range values {
+		v, err := clampDegree(raw)
+		if err != nil && invalidErr == nil {
+			invalidErr = err
+		}
+		denom := h.Gamma + (1-h.Gamma)*(result+v-result*v)
+		if denom == 0 {
+			// Only reachable with gamma 0 and both degrees 0
+			result = 0
+			continue
+		}
+		result = result * v / denom
+	}
+	if invalidErr != nil {
+		return result, invalidErr
+	}
+	return result, nil
+}
+
+// HamacherOrOperator implements the Hamacher t-conorm
+// (a + b + (gamma - 2)*a*b) / (1 + (gamma - 1)*a*b), applied pairwise.
+// Gamma 1 gives the probabilistic sum and gamma 2 the Einstein sum.
+type HamacherOrOperator struct {
+	Gamma float64
+}
+
+// NewHamacherOr creates a Hamacher OR operator with the given gamma.
+// Returns error if gamma is negative.
+func NewHamacherOr(gamma float64) (*HamacherOrOperator, error) {
+	if gamma < 0 || math.IsNaN(gamma) {
+		return nil, fmt.Errorf("hamacher gamma must be >= 0, got %.2f", gamma)
+	}
+	return &HamacherOrOperator{Gamma: gamma}, nil
+}
+
+// Apply returns the Hamacher t-conorm of all input values.
+// All values should be in range [0, 1] for valid membership degrees.
+func (h *HamacherOrOperator) Apply(values ...float64) (float64, error) {
+	if len(values) == 0 {
+		return 0.0, nil
+	}
+	result := 0.0
+	var invalidErr error
+	for _, raw := range values {
+		v, err := clampDegree(raw)
+		if err != nil && invalidErr == nil {
+			invalidErr = err
+		}
+		denom := 1 + (h.Gamma-1)*result*v
+		if denom == 0 {
+			// Only reachable with gamma 0 and both degrees 1
+			result = 1
+			continue
+		}
+		result = (result + v + (h.Gamma-2)*result*v) / denom
+	}
+	if invalidErr != nil {
+		return result, invalidErr
+	}
+	return result, nil
+}
+
+// clampDegree clamps v to [0, 1], reporting an InvalidMembershipError if it was outside.
+func clampDegree(v float64) (float64, error) {
+	if v < 0 {
+		return 0, &InvalidMembershipError{Value: v}
+	}
+	if v > 1 {
+		return 1, &InvalidMembershipError{Value: v}
+	}
+	return v, nil
+}
+
 // Zadeh operators (most common)
 
 // AND is the Zadeh AND operator (MIN)
@@ -163,3 +473,143 @@ var OR = &MaxOperator{}
 
 // NOT is the Zadeh NOT operator (complement)
 var NOT = &NotOperator{}
+
+// Algebraic operators
+
+// PROD is the algebraic product AND operator
+var PROD = &ProductOperator{}
+
+// PROBOR is the probabilistic sum OR operator
+var PROBOR = &ProbOrOperator{}
+
+// Lukasiewicz operators
+
+// LUKAND is the Lukasiewicz bounded-difference AND operator
+var LUKAND = &LukasiewiczAndOperator{}
+
+// LUKOR is the Lukasiewicz bounded-sum OR operator
+var LUKOR = &LukasiewiczOrOperator{}
+
+// Einstein operators
+
+// EINSTEIN_AND is the Einstein product AND operator
+var EINSTEIN_AND = &EinsteinProductOperator{}
+
+// EINSTEIN_OR is the Einstein sum OR operator
+var EINSTEIN_OR = &EinsteinSumOperator{}
+
+// ErrUnknownOperator indicates that no operator is registered under a name.
+var ErrUnknownOperator = errors.New("unknown operator")
+
+// registryMu guards registry, which Register may change while other goroutines resolve names
+var registryMu sync.RWMutex
+
+// registry maps operator names (as used in MATLAB FIS files) to operators.
+var registry = map[string]Operator{
+	"min":    AND,
+	"max":    OR,
+	"prod":   PROD,
+	"probor": PROBOR,
+	"lukand": LUKAND,
+	"lukor":  LUKOR,
+
+	"einstein_and": EINSTEIN_AND,
+	"einstein_or":  EINSTEIN_OR,
+}
+
+// parameterized maps the names of built-in operator families that take a parameter
+// to their constructors. Their names carry the parameter, e.g. "hamacher_and:0.5".
+var parameterized = map[string]func(float64) (Operator, error){
+	"hamacher_and": func(gamma float64) (Operator, error) { return NewHamacherAnd(gamma) },
+	"hamacher_or":  func(gamma float64) (Operator, error) { return NewHamacherOr(gamma) },
+	"sugeno_not":   func(lambda float64) (Operator, error) { return NewSugenoComplement(lambda) },
+	"yager_not":    func(w float64) (Operator, error) { return NewYagerComplement(w) },
+}
+
+// Register adds an operator to the registry under name so it can be resolved by ByName.
+// op must be a pointer, like the built-in operators, so NameOf can find it by identity.
+// Returns error if name is empty or contains ':', op is nil or not a pointer, or name
+// is already registered.
+func Register(name string, op Operator) error {
+	if name == "" {
+		return fmt.Errorf("operator name cannot be empty")
+	}
+	if strings.Contains(name, ":") {
+		return fmt.Errorf("operator name '%s' cannot contain ':'", name)
+	}
+	if op == nil {
+		return fmt.Errorf("operator '%s' cannot be nil", name)
+	}
+	if reflect.ValueOf(op).Kind() != reflect.Pointer {
+		return fmt.Errorf("operator '%s' must be a pointer, got %T", name, op)
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, exists := registry[name]; exists {
+		return fmt.Errorf("operator '%s' is already registered", name)
+	}
+	registry[name] = op
+	return nil
+}
+
+// ByName resolves an operator by its registered name ("min", "max", "prod", "probor", "lukand", "lukor", ...),
+// or builds a parameterized one from a name like "hamacher_and:0.5", "hamacher_or:2",
+// "sugeno_not:0.5" or "yager_not:2".
+// Returns error wrapping ErrUnknownOperator if no operator is registered under name,
+// or error if the parameter is not a number or is rejected by the operator's constructor.
+func ByName(name string) (Operator, error) {
+	registryMu.RLock()
+	op, ok := registry[name]
+	registryMu.RUnlock()
+	if ok {
+		return op, nil
+	}
+	family, param, hasParam := strings.Cut(name, ":")
+	build, ok := parameterized[family]
+	if !hasParam || !ok {
+		return nil, fmt.Errorf("%w: '%s'", ErrUnknownOperator, name)
+	}
+	value, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameter for operator '%s': %w", family, err)
+	}
+	return build(value)
+}
+
+// NameOf returns the registered name of op, the reverse of ByName. If op is
+// registered under several names, the first in sorted order is returned.
+// Since only pointers are registered, ops of non-comparable types never match.
+// Unregistered Hamacher operators and Sugeno and Yager complements are named with
+// their parameter, e.g. "hamacher_and:0.5" or "yager_not:2".
+// Returns false if op is neither registered nor parameterized.
+func NameOf(op Operator) (string, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name, registered := range registry {
+		if registered == op {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return parameterizedName(op)
+	}
+	sort.Strings(names)
+	return names[0], true
+}
+
+// parameterizedName returns the name of a parameterized built-in operator, which
+// ByName parses back into an equal operator
+func parameterizedName(op Operator) (string, bool) {
+	switch o := op.(type) {
+	case *HamacherAndOperator:
+		return "hamacher_and:" + strconv.FormatFloat(o.Gamma, 'g', -1, 64), true
+	case *HamacherOrOperator:
+		return "hamacher_or:" + strconv.FormatFloat(o.Gamma, 'g', -1, 64), true
+	case *SugenoComplement:
+		return "sugeno_not:" + strconv.FormatFloat(o.Lambda, 'g', -1, 64), true
+	case *YagerComplement:
+		return "yager_not:" + strconv.FormatFloat(o.W, 'g', -1, 64), true
+	}
+	return "", false
+}
@@ -2,7 +2,10 @@ package operators
 
 import (
 	"errors"
+	"fmt"
 	"math"
+	"reflect"
+	"sync"
 	"testing"
 )
 
@@ -88,6 +91,64 @@ func TestNotOperator(t *testing.T) {
 	}
 }
 
+func TestParameterizedComplements(t *testing.T) {
+	sugeno, err := NewSugenoComplement(1)
+	if err != nil {
+		t.Fatalf("NewSugenoComplement(1) failed: %v", err)
+	}
+	yager, err := NewYagerComplement(2)
+	if err != nil {
+		t.Fatalf("NewYagerComplement(2) failed: %v", err)
+	}
+	tests := []struct {
+		name     string
+		op       Operator
+		value    float64
+		expected float64
+	}{
+		{"sugeno mid", sugeno, 0.5, 0.5 / 1.5},
+		{"sugeno zero", sugeno, 0, 1},
+		{"sugeno one", sugeno, 1, 0},
+		{"yager mid", yager, 0.6, 0.8},
+		{"yager zero", yager, 0, 1},
+		{"yager one", yager, 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.op.Apply(tt.value)
+			if err != nil || !floatEqual(result, tt.expected) {
+				t.Errorf("Apply(%f) = %f, %v; expected %f", tt.value, result, err, tt.expected)
+			}
+		})
+	}
+
+	// Lambda 0 and w 1 reduce to the standard complement
+	standardSugeno, _ := NewSugenoComplement(0)
+	standardYager, _ := NewYagerComplement(1)
+	for _, v := range []float64{0, 0.3, 0.7, 1} {
+		want, _ := NOT.Apply(v)
+		if got, _ := standardSugeno.Apply(v); !floatEqual(got, want) {
+			t.Errorf("Sugeno(0)(%f) = %f, expected %f", v, got, want)
+		}
+		if got, _ := standardYager.Apply(v); !floatEqual(got, want) {
+			t.Errorf("Yager(1)(%f) = %f, expected %f", v, got, want)
+		}
+	}
+
+	if _, err := NewSugenoComplement(-1); err == nil {
+		t.Error("Expected error for lambda -1, got nil")
+	}
+	if _, err := NewYagerComplement(0); err == nil {
+		t.Error("Expected error for w 0, got nil")
+	}
+	if _, err := sugeno.Apply(1.5); !errors.Is(err, ErrInvalidMembership) {
+		t.Errorf("expected ErrInvalidMembership from Sugeno complement, got %v", err)
+	}
+	if _, err := yager.Apply(-0.5); !errors.Is(err, ErrInvalidMembership) {
+		t.Errorf("expected ErrInvalidMembership from Yager complement, got %v", err)
+	}
+}
+
 func TestOperatorInterface(t *testing.T) {
 	var op Operator
 
@@ -118,3 +179,316 @@ func TestOperatorInvalidInputs(t *testing.T) {
 		t.Fatalf("expected ErrInvalidMembership from NOT, got %v", err)
 	}
 }
+
+func TestProductOperator_AND(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []float64
+		expected float64
+	}{
+		{"both high", []float64{0.8, 0.9}, 0.72},
+		{"one zero", []float64{0, 0.9}, 0},
+		{"three values", []float64{0.5, 0.4, 0.5}, 0.1},
+		{"single value", []float64{0.3}, 0.3},
+		{"empty", []float64{}, 0.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := PROD.Apply(tt.values...)
+			if err != nil {
+				t.Fatalf("PROD.Apply returned error: %v", err)
+			}
+			if !floatEqual(result, tt.expected) {
+				t.Errorf("PROD.Apply(%v) = %f, expected %f", tt.values, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestProbOrOperator_OR(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []float64
+		expected float64
+	}{
+		{"two values", []float64{0.5, 0.4}, 0.7},
+		{"one full", []float64{1, 0.3}, 1},
+		{"three values", []float64{0.5, 0.5, 0.5}, 0.875},
+		{"empty", []float64{}, 0.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := PROBOR.Apply(tt.values...)
+			if err != nil {
+				t.Fatalf("PROBOR.Apply returned error: %v", err)
+			}
+			if !floatEqual(result, tt.expected) {
+				t.Errorf("PROBOR.Apply(%v) = %f, expected %f", tt.values, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLukasiewiczOperators(t *testing.T) {
+	tests := []struct {
+		name     string
+		op       Operator
+		values   []float64
+		expected float64
+	}{
+		{"AND overlap", LUKAND, []float64{0.6, 0.6}, 0.2},
+		{"AND no overlap", LUKAND, []float64{0.4, 0.5}, 0},
+		{"AND three values", LUKAND, []float64{0.9, 0.9, 0.9}, 0.7},
+		{"AND with one", LUKAND, []float64{1, 0.3}, 0.3},
+		{"OR saturates", LUKOR, []float64{0.6, 0.6}, 1.0},
+		{"OR below one", LUKOR, []float64{0.2, 0.3}, 0.5},
+		{"OR three values", LUKOR, []float64{0.1, 0.2, 0.3}, 0.6},
+		{"AND empty", LUKAND, []float64{}, 0},
+		{"OR empty", LUKOR, []float64{}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.op.Apply(tt.values...)
+			if err != nil {
+				t.Fatalf("Apply returned error: %v", err)
+			}
+			if !floatEqual(result, tt.expected) {
+				t.Errorf("Apply(%v) = %f, expected %f", tt.values, result, tt.expected)
+			}
+		})
+	}
+
+	if _, err := LUKAND.Apply(0.5, 1.2); !errors.Is(err, ErrInvalidMembership) {
+		t.Errorf("expected ErrInvalidMembership from LUKAND, got %v", err)
+	}
+	if _, err := LUKOR.Apply(-0.5, 0.2); !errors.Is(err, ErrInvalidMembership) {
+		t.Errorf("expected ErrInvalidMembership from LUKOR, got %v", err)
+	}
+}
+
+func TestEinsteinOperators(t *testing.T) {
+	if result, err := EINSTEIN_AND.Apply(0.5, 0.5); err != nil || !floatEqual(result, 0.25/1.25) {
+		t.Errorf("EINSTEIN_AND.Apply(0.5, 0.5) = %f, %v; expected 0.2", result, err)
+	}
+	if result, err := EINSTEIN_OR.Apply(0.5, 0.5); err != nil || !floatEqual(result, 0.8) {
+		t.Errorf("EINSTEIN_OR.Apply(0.5, 0.5) = %f, %v; expected 0.8", result, err)
+	}
+	if result, _ := EINSTEIN_AND.Apply(1, 0.4, 1); !floatEqual(result, 0.4) {
+		t.Errorf("Expected 1 to be the EINSTEIN_AND identity, got %f", result)
+	}
+	if result, _ := EINSTEIN_OR.Apply(0, 0.4, 0); !floatEqual(result, 0.4) {
+		t.Errorf("Expected 0 to be the EINSTEIN_OR identity, got %f", result)
+	}
+
+	// Einstein AND never exceeds MIN and Einstein OR never falls below MAX
+	points := [][]float64{{0.2, 0.9}, {0.5, 0.5}, {0.7, 0.8, 0.9}, {0.1, 0.3}}
+	for _, p := range points {
+		minV, _ := AND.Apply(p...)
+		maxV, _ := OR.Apply(p...)
+		and, _ := EINSTEIN_AND.Apply(p...)
+		or, _ := EINSTEIN_OR.Apply(p...)
+		if and > minV+epsilon {
+			t.Errorf("EINSTEIN_AND(%v) = %f exceeds MIN %f", p, and, minV)
+		}
+		if or < maxV-epsilon || or > 1 {
+			t.Errorf("EINSTEIN_OR(%v) = %f outside [MAX %f, 1]", p, or, maxV)
+		}
+	}
+
+	if _, err := EINSTEIN_AND.Apply(1.1, 0.5); !errors.Is(err, ErrInvalidMembership) {
+		t.Errorf("expected ErrInvalidMembership from EINSTEIN_AND, got %v", err)
+	}
+	if _, err := EINSTEIN_OR.Apply(0.5, -1); !errors.Is(err, ErrInvalidMembership) {
+		t.Errorf("expected ErrInvalidMembership from EINSTEIN_OR, got %v", err)
+	}
+}
+
+func TestHamacherOperators(t *testing.T) {
+	and, err := NewHamacherAnd(1)
+	if err != nil {
+		t.Fatalf("NewHamacherAnd(1) failed: %v", err)
+	}
+	or, err := NewHamacherOr(1)
+	if err != nil {
+		t.Fatalf("NewHamacherOr(1) failed: %v", err)
+	}
+
+	// Gamma 1 reduces to the algebraic product and probabilistic sum
+	points := [][]float64{{0.2, 0.9}, {0.5, 0.5}, {0.7, 0.8, 0.9}, {0, 0.3}, {1, 1}}
+	for _, p := range points {
+		wantAnd, _ := PROD.Apply(p...)
+		wantOr, _ := PROBOR.Apply(p...)
+		if got, err := and.Apply(p...); err != nil || !floatEqual(got, wantAnd) {
+			t.Errorf("HamacherAnd(1)(%v) = %f, %v; expected %f", p, got, err, wantAnd)
+		}
+		if got, err := or.Apply(p...); err != nil || !floatEqual(got, wantOr) {
+			t.Errorf("HamacherOr(1)(%v) = %f, %v; expected %f", p, got, err, wantOr)
+		}
+	}
+
+	// Gamma 0 is defined at its singular points
+	and0, _ := NewHamacherAnd(0)
+	if got, err := and0.Apply(0, 0); err != nil || got != 0 {
+		t.Errorf("HamacherAnd(0)(0, 0) = %f, %v; expected 0", got, err)
+	}
+	or0, _ := NewHamacherOr(0)
+	if got, err := or0.Apply(1, 1); err != nil || got != 1 {
+		t.Errorf("HamacherOr(0)(1, 1) = %f, %v; expected 1", got, err)
+	}
+
+	if _, err := NewHamacherAnd(-0.5); err == nil {
+		t.Error("Expected error for negative gamma in NewHamacherAnd, got nil")
+	}
+	if _, err := NewHamacherOr(-0.5); err == nil {
+		t.Error("Expected error for negative gamma in NewHamacherOr, got nil")
+	}
+	if _, err := and.Apply(0.5, 1.2); !errors.Is(err, ErrInvalidMembership) {
+		t.Errorf("expected ErrInvalidMembership from HamacherAnd, got %v", err)
+	}
+	if _, err := or.Apply(-0.1, 0.5); !errors.Is(err, ErrInvalidMembership) {
+		t.Errorf("expected ErrInvalidMembership from HamacherOr, got %v", err)
+	}
+}
+
+func TestAlgebraicOperators_InvalidInputs(t *testing.T) {
+	result, err := PROD.Apply(0.5, 1.5)
+	if !errors.Is(err, ErrInvalidMembership) {
+		t.Errorf("expected ErrInvalidMembership from PROD, got %v", err)
+	}
+	if !floatEqual(result, 0.5) {
+		t.Errorf("expected PROD to clamp 1.5 to 1 and return 0.5, got %f", result)
+	}
+	var invalid *InvalidMembershipError
+	if _, err := PROBOR.Apply(-0.1, 2); !errors.As(err, &invalid) || invalid.Value != -0.1 {
+		t.Errorf("expected PROBOR to report the first invalid value -0.1, got %v", err)
+	}
+}
+
+func TestByName(t *testing.T) {
+	expected := map[string]Operator{"min": AND, "max": OR, "prod": PROD, "probor": PROBOR, "lukand": LUKAND, "lukor": LUKOR,
+		"einstein_and": EINSTEIN_AND, "einstein_or": EINSTEIN_OR}
+	for name, want := range expected {
+		op, err := ByName(name)
+		if err != nil {
+			t.Fatalf("ByName(%q) returned error: %v", name, err)
+		}
+		if op != want {
+			t.Errorf("ByName(%q) returned %T, expected %T", name, op, want)
+		}
+	}
+	for name, op := range expected {
+		if got, ok := NameOf(op); !ok || got != name {
+			t.Errorf("NameOf(%T) = %q, %v; expected %q", op, got, ok, name)
+		}
+	}
+	if _, ok := NameOf(&MinOperator{}); ok {
+		t.Error("Expected NameOf to reject an unregistered operator instance")
+	}
+	if _, err := ByName("lukasiewicz"); !errors.Is(err, ErrUnknownOperator) {
+		t.Errorf("expected ErrUnknownOperator, got %v", err)
+	}
+}
+
+func TestByName_Parameterized(t *testing.T) {
+	op, err := ByName("hamacher_and:0.5")
+	if err != nil {
+		t.Fatalf("ByName failed: %v", err)
+	}
+	if h, ok := op.(*HamacherAndOperator); !ok || h.Gamma != 0.5 {
+		t.Errorf("Expected Hamacher AND with gamma 0.5, got %#v", op)
+	}
+	for _, op := range []Operator{&HamacherAndOperator{Gamma: 0.5}, &HamacherOrOperator{Gamma: 2},
+		&SugenoComplement{Lambda: 0.5}, &YagerComplement{W: 2}} {
+		name, ok := NameOf(op)
+		if !ok {
+			t.Fatalf("NameOf(%#v) failed", op)
+		}
+		back, err := ByName(name)
+		if err != nil {
+			t.Fatalf("ByName(%q) failed: %v", name, err)
+		}
+		if !reflect.DeepEqual(back, op) {
+			t.Errorf("ByName(%q) = %#v, expected %#v", name, back, op)
+		}
+	}
+
+	if _, err := ByName("hamacher_and:-1"); err == nil {
+		t.Error("Expected error for negative gamma")
+	}
+	if _, err := ByName("sugeno_not:-1"); err == nil {
+		t.Error("Expected error for sugeno lambda <= -1")
+	}
+	if _, err := ByName("yager_not:0"); err == nil {
+		t.Error("Expected error for yager w <= 0")
+	}
+	if name, _ := NameOf(&YagerComplement{W: 2}); name != "yager_not:2" {
+		t.Errorf("Expected name yager_not:2, got %q", name)
+	}
+	if _, err := ByName("hamacher_or:abc"); err == nil {
+		t.Error("Expected error for a non-numeric parameter")
+	}
+	if _, err := ByName("hamacher_and"); !errors.Is(err, ErrUnknownOperator) {
+		t.Errorf("Expected ErrUnknownOperator without a parameter, got %v", err)
+	}
+	if _, err := ByName("min:1"); !errors.Is(err, ErrUnknownOperator) {
+		t.Errorf("Expected ErrUnknownOperator for a parameter on a plain operator, got %v", err)
+	}
+	if err := Register("custom:1", &MinOperator{}); err == nil {
+		t.Error("Expected error registering a name containing ':'")
+	}
+}
+
+func TestRegister(t *testing.T) {
+	if err := Register("custom-min", &MinOperator{}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	defer delete(registry, "custom-min")
+	if _, err := ByName("custom-min"); err != nil {
+		t.Errorf("ByName after Register failed: %v", err)
+	}
+	if err := Register("custom-min", &MinOperator{}); err == nil {
+		t.Error("expected error registering duplicate name")
+	}
+	if err := Register("", &MinOperator{}); err == nil {
+		t.Error("expected error for empty name")
+	}
+	if err := Register("nil-op", nil); err == nil {
+		t.Error("expected error for nil operator")
+	}
+	if err := Register("slice-op", sliceOperator{0.5}); err == nil {
+		t.Error("expected error for non-pointer operator")
+	}
+	if _, ok := NameOf(sliceOperator{0.5}); ok {
+		t.Error("expected NameOf to reject a non-comparable operator")
+	}
+}
+
+// sliceOperator is an operator whose dynamic type is not comparable
+type sliceOperator []float64
+
+func (s sliceOperator) Apply(values ...float64) (float64, error) {
+	return AND.Apply(values...)
+}
+
+func TestRegister_Concurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		name := fmt.Sprintf("concurrent-%d", i)
+		defer delete(registry, name)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if err := Register(name, &MaxOperator{}); err != nil {
+				t.Errorf("Register(%q) failed: %v", name, err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			_, _ = ByName("min")
+			_, _ = NameOf(OR)
+		}()
+	}
+	wg.Wait()
+}
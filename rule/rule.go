@@ -3,23 +3,63 @@ package rule
 import (
 	"fmt"
 	"github.com/loian/fuzzylib/operators"
+	"math"
 )
 
 // RuleCondition represents a condition in a rule (e.g., "Temperature IS Cold").
 // It specifies that a particular variable should match a particular fuzzy set.
 // Used in both rule antecedents (IF conditions) and consequents (THEN outputs).
 type RuleCondition struct {
-	Variable string // Variable name (e.g., "Temperature")
-	Set      string // Fuzzy set name (e.g., "Cold")
-	Negated  bool   // If true, apply NOT operator to this condition
+	Variable string  // Variable name (e.g., "Temperature")
+	Set      string  // Fuzzy set name (e.g., "Cold")
+	Negated  bool    // If true, apply NOT operator to this condition
+	Weight   float64 // Condition weight in (0, 1]; 0 means unweighted (1.0). Ignored for outputs
 }
 
-// Rule represents an IF-THEN fuzzy rule
+// Condition weighting schemes, selecting how RuleCondition.Weight shapes a condition's degree
+const (
+	// ConditionWeightProduct scales the degree: weight * degree (default).
+	// A lower weight can only lower a condition's degree, so under AND (min)
+	// it can only lower the rule strength.
+	ConditionWeightProduct = "product"
+	// ConditionWeightImportance treats the weight as the condition's importance:
+	// max(degree, 1-weight) under min operators and min(degree, weight) under max
+	// operators, so a less important condition constrains the result less. Under
+	// AND, down-weighting the limiting condition raises the rule strength.
+	// Other operators fall back to the product scheme.
+	ConditionWeightImportance = "importance"
+)
+
+// ConditionGroup is a parenthesised group of conditions inside a rule antecedent,
+// e.g. "(Temperature IS Hot AND Humidity IS Wet)". Conditions within a group are
+// combined by the rule's GroupOperator.
+type ConditionGroup struct {
+	Conditions []RuleCondition
+}
+
+// Rule represents an IF-THEN fuzzy rule.
+//
+// A simple rule lists its antecedents in Conditions, combined by Operator.
+// For mixed connectives such as "(A AND B) OR C", use Groups instead: the
+// conditions of each group are combined by GroupOperator and the group results
+// are combined by Operator. A rule uses either Conditions or Groups, not both.
 type Rule struct {
-	Conditions []RuleCondition    // IF conditions (antecedents)
-	Output     RuleCondition      // THEN output (consequent)
-	Weight     float64            // Rule weight (0-1, default 1.0)
-	Operator   operators.Operator // AND/OR operator for combining conditions
+	ID                int                // Stable identifier assigned by the inference system (0 = unassigned)
+	Conditions        []RuleCondition    // IF conditions (antecedents)
+	Groups            []ConditionGroup   // Grouped IF conditions (alternative to Conditions)
+	Output            RuleCondition      // THEN output (consequent)
+	AdditionalOutputs []RuleCondition    // Further THEN outputs receiving the same firing strength
+	Weight            float64            // Rule weight (0-1, default 1.0)
+	Operator          operators.Operator // AND/OR operator for combining conditions (or groups); nil = AND
+	GroupOperator     operators.Operator // AND/OR operator for combining conditions within a group (default AND)
+	Complement        operators.Operator // NOT operator applied to negated conditions (default NOT; nil = 1 - degree)
+	// ConditionWeighting selects how condition weights are applied: ConditionWeightProduct
+	// (default, also used when empty) or ConditionWeightImportance
+	ConditionWeighting string
+	// Inhibitory makes the rule suppress its output sets: its firing strength is
+	// subtracted (floored at 0) from each set's strength after all excitatory
+	// rules have been aggregated
+	Inhibitory bool
 }
 
 // NewRule creates a new fuzzy rule with default weight of 1.0 and AND operator.
@@ -38,10 +78,12 @@ func NewRule(output RuleCondition, operator operators.Operator) (*Rule, error) {
 		operator = operators.AND
 	}
 	return &Rule{
-		Conditions: make([]RuleCondition, 0),
-		Output:     output,
-		Weight:     1.0,
-		Operator:   operator,
+		Conditions:    make([]RuleCondition, 0),
+		Output:        output,
+		Weight:        1.0,
+		Operator:      operator,
+		GroupOperator: operators.AND,
+		Complement:    operators.NOT,
 	}, nil
 }
 
@@ -69,6 +111,93 @@ func (r *Rule) AddConditionEx(variable, set string, negated bool) error {
 	return nil
 }
 
+// AddWeightedCondition adds a condition with a per-condition weight, applied to the
+// condition's degree according to ConditionWeighting before the operator combines it.
+// Returns error if variable or set name is empty, or if weight is not in range (0, 1].
+func (r *Rule) AddWeightedCondition(variable, set string, negated bool, weight float64) error {
+	if weight <= 0 || weight > 1 {
+		return fmt.Errorf("condition weight must be in range (0, 1], got %.2f", weight)
+	}
+	if err := r.AddConditionEx(variable, set, negated); err != nil {
+		return err
+	}
+	r.Conditions[len(r.Conditions)-1].Weight = weight
+	return nil
+}
+
+// AddGroup adds a group of conditions to the rule. The conditions of the group are
+// combined by GroupOperator, and all groups are combined by Operator.
+// Returns error if the group is empty or any condition has an empty variable or set name.
+//
+// Example, (Temperature IS Hot AND Humidity IS Wet) OR Temperature IS VeryHot:
+//
+//	r, _ := NewRule(RuleCondition{Variable: "Fan", Set: "High"}, operators.OR)
+//	r.AddGroup(RuleCondition{Variable: "Temperature", Set: "Hot"}, RuleCondition{Variable: "Humidity", Set: "Wet"})
+//	r.AddGroup(RuleCondition{Variable: "Temperature", Set: "VeryHot"})
+func (r *Rule) AddGroup(conditions ...RuleCondition) error {
+	if len(conditions) == 0 {
+		return fmt.Errorf("condition group cannot be empty")
+	}
+	for i, cond := range conditions {
+		if cond.Variable == "" {
+			return fmt.Errorf("group condition %d variable name cannot be empty", i+1)
+		}
+		if cond.Set == "" {
+			return fmt.Errorf("group condition %d set name cannot be empty", i+1)
+		}
+	}
+	group := ConditionGroup{Conditions: make([]RuleCondition, len(conditions))}
+	copy(group.Conditions, conditions)
+	r.Groups = append(r.Groups, group)
+	return nil
+}
+
+// AddOutput adds a further consequent to the rule, e.g. "... THEN Fan IS High AND Vent IS Open".
+// Every consequent receives the rule's firing strength.
+// Returns error if variable or set name is empty.
+func (r *Rule) AddOutput(variable, set string) error {
+	if variable == "" {
+		return fmt.Errorf("output variable name cannot be empty")
+	}
+	if set == "" {
+		return fmt.Errorf("output set name cannot be empty")
+	}
+	r.AdditionalOutputs = append(r.AdditionalOutputs, RuleCondition{Variable: variable, Set: set})
+	return nil
+}
+
+// Outputs returns every consequent of the rule: Output followed by AdditionalOutputs.
+func (r *Rule) Outputs() []RuleCondition {
+	outputs := make([]RuleCondition, 0, 1+len(r.AdditionalOutputs))
+	outputs = append(outputs, r.Output)
+	return append(outputs, r.AdditionalOutputs...)
+}
+
+// AllConditions returns every condition referenced by the rule, whether listed
+// directly in Conditions or inside Groups.
+func (r *Rule) AllConditions() []RuleCondition {
+	if len(r.Groups) == 0 {
+		return r.Conditions
+	}
+	all := make([]RuleCondition, 0, len(r.Conditions))
+	all = append(all, r.Conditions...)
+	for _, g := range r.Groups {
+		all = append(all, g.Conditions...)
+	}
+	return all
+}
+
+// SetComplement sets the NOT operator applied to negated conditions, e.g. a
+// Sugeno or Yager complement in place of the default 1 - degree.
+// Returns error if op is nil.
+func (r *Rule) SetComplement(op operators.Operator) error {
+	if op == nil {
+		return fmt.Errorf("complement operator cannot be nil")
+	}
+	r.Complement = op
+	return nil
+}
+
 // SetWeight sets the rule weight. Weight must be in range [0, 1].
 // Returns error if weight is out of bounds.
 func (r *Rule) SetWeight(weight float64) error {
@@ -81,29 +210,70 @@ func (r *Rule) SetWeight(weight float64) error {
 
 // Evaluate evaluates the rule given input membership values.
 // membershipMap: map[variableName][setName]membershipDegree
-// Returns error if the rule has no conditions.
+// Returns error if the rule has no conditions, or if it mixes Conditions and Groups.
 func (r *Rule) Evaluate(membershipMap map[string]map[string]float64) (float64, error) {
+	return r.EvaluateWith(membershipMap, nil)
+}
+
+// EvaluateWith is like Evaluate but collects condition and group degrees in scratch
+// when it has at least ScratchLen capacity, avoiding a per-call allocation.
+func (r *Rule) EvaluateWith(membershipMap map[string]map[string]float64, scratch []float64) (float64, error) {
+	return r.EvaluateWithConnectives(membershipMap, scratch, Connectives{})
+}
+
+// Connectives overrides the operators applied for a rule's AND and OR connectives
+// at evaluation time. A connective is AND when the rule (or group) operator is an
+// operators.MinOperator and OR when it is an operators.MaxOperator; other operators,
+// and connectives whose override is nil, are applied as set on the rule.
+type Connectives struct {
+	And operators.Operator
+	Or  operators.Operator
+}
+
+// Resolve returns the operator applied in place of op
+func (c Connectives) Resolve(op operators.Operator) operators.Operator {
+	switch op.(type) {
+	case *operators.MinOperator:
+		if c.And != nil {
+			return c.And
+		}
+	case *operators.MaxOperator:
+		if c.Or != nil {
+			return c.Or
+		}
+	}
+	return op
+}
+
+// EvaluateWithConnectives is like EvaluateWith but combines AND and OR connectives
+// with the operators in c, e.g. the system-wide AND/OR methods of a loaded .fis file.
+// Condition weights are applied according to the operator actually used, so an
+// AND connective overridden with a product scales its conditions by their weight.
+func (r *Rule) EvaluateWithConnectives(membershipMap map[string]map[string]float64, scratch []float64, c Connectives) (float64, error) {
+	if len(r.Groups) > 0 {
+		return r.evaluateGroups(membershipMap, scratch, c)
+	}
 	if len(r.Conditions) == 0 {
 		return 0, fmt.Errorf("cannot evaluate rule with no conditions")
 	}
 
 	// Get membership degrees for all conditions
-	values := make([]float64, len(r.Conditions))
+	var values []float64
+	if cap(scratch) >= len(r.Conditions) {
+		values = scratch[:len(r.Conditions)]
+	} else {
+		values = make([]float64, len(r.Conditions))
+	}
 	for i, cond := range r.Conditions {
-		if varMap, ok := membershipMap[cond.Variable]; ok {
-			if degree, ok := varMap[cond.Set]; ok {
-				if cond.Negated {
-					// Apply NOT operator: 1 - membership_degree
-					values[i] = 1.0 - degree
-				} else {
-					values[i] = degree
-				}
-			}
+		degree, err := r.ConditionDegree(cond, c.Resolve(r.operator()), membershipMap)
+		if err != nil {
+			return 0, fmt.Errorf("error applying complement for rule output '%s.%s': %w", r.Output.Variable, r.Output.Set, err)
 		}
+		values[i] = degree
 	}
 
 	// Apply operator to combine conditions
-	result, err := r.Operator.Apply(values...)
+	result, err := c.Resolve(r.operator()).Apply(values...)
 	if err != nil {
 		return 0, fmt.Errorf("error applying operator for rule output '%s.%s': %w", r.Output.Variable, r.Output.Set, err)
 	}
@@ -111,3 +281,121 @@ func (r *Rule) Evaluate(membershipMap map[string]map[string]float64) (float64, e
 	// Apply weight
 	return result * r.Weight, nil
 }
+
+// ScratchLen returns the scratch capacity EvaluateWith needs to evaluate r without
+// allocating: one slot per condition, or for grouped rules one per group plus the
+// conditions of the largest group.
+func (r *Rule) ScratchLen() int {
+	if len(r.Groups) == 0 {
+		return len(r.Conditions)
+	}
+	largest := 0
+	for _, g := range r.Groups {
+		largest = max(largest, len(g.Conditions))
+	}
+	return len(r.Groups) + largest
+}
+
+// operator returns Operator, or AND for rules built as struct literals without one
+func (r *Rule) operator() operators.Operator {
+	if r.Operator == nil {
+		return operators.AND
+	}
+	return r.Operator
+}
+
+// evaluateGroups evaluates a grouped rule: each group is combined with GroupOperator,
+// then the group results are combined with Operator. Group results fill the front
+// of scratch and each group's condition degrees the rest, when it is large enough.
+func (r *Rule) evaluateGroups(membershipMap map[string]map[string]float64, scratch []float64, c Connectives) (float64, error) {
+	if len(r.Conditions) > 0 {
+		return 0, fmt.Errorf("rule cannot mix plain conditions and condition groups")
+	}
+	groupOp := r.GroupOperator
+	if groupOp == nil {
+		groupOp = operators.AND
+	}
+
+	if cap(scratch) < r.ScratchLen() {
+		scratch = make([]float64, r.ScratchLen())
+	}
+	groupValues := scratch[:len(r.Groups)]
+	for g, group := range r.Groups {
+		if len(group.Conditions) == 0 {
+			return 0, fmt.Errorf("condition group %d has no conditions", g+1)
+		}
+		values := scratch[len(r.Groups) : len(r.Groups)+len(group.Conditions)]
+		for i, cond := range group.Conditions {
+			degree, err := r.ConditionDegree(cond, c.Resolve(groupOp), membershipMap)
+			if err != nil {
+				return 0, fmt.Errorf("error applying complement for rule output '%s.%s': %w", r.Output.Variable, r.Output.Set, err)
+			}
+			values[i] = degree
+		}
+		v, err := c.Resolve(groupOp).Apply(values...)
+		if err != nil {
+			return 0, fmt.Errorf("error applying group operator for rule output '%s.%s': %w", r.Output.Variable, r.Output.Set, err)
+		}
+		groupValues[g] = v
+	}
+
+	result, err := c.Resolve(r.operator()).Apply(groupValues...)
+	if err != nil {
+		return 0, fmt.Errorf("error applying operator for rule output '%s.%s': %w", r.Output.Variable, r.Output.Set, err)
+	}
+	return result * r.Weight, nil
+}
+
+// ConditionDegree returns the degree of cond with its weight applied according to
+// r.ConditionWeighting, for combination by op, which should be the operator after
+// any Connectives override. Negated conditions use r.Complement
+// when set. Unweighted conditions under the default complement return cond.Degree.
+// Returns error if the complement operator fails.
+func (r *Rule) ConditionDegree(cond RuleCondition, op operators.Operator, membershipMap map[string]map[string]float64) (float64, error) {
+	degree, err := r.degree(cond, membershipMap)
+	if err != nil {
+		return 0, err
+	}
+	if cond.Weight == 0 || cond.Weight == 1 {
+		return degree, nil
+	}
+	if r.ConditionWeighting == ConditionWeightImportance {
+		switch op.(type) {
+		case *operators.MinOperator:
+			return math.Max(degree, 1-cond.Weight), nil
+		case *operators.MaxOperator:
+			return math.Min(degree, cond.Weight), nil
+		}
+	}
+	return cond.Weight * degree, nil
+}
+
+// degree is like cond.Degree but negates with r.Complement when it is set.
+// Returns error if the complement operator fails, e.g. ErrInvalidMembership for
+// an out-of-range degree.
+func (r *Rule) degree(cond RuleCondition, membershipMap map[string]map[string]float64) (float64, error) {
+	if !cond.Negated || r.Complement == nil {
+		return cond.Degree(membershipMap), nil
+	}
+	plain := cond
+	plain.Negated = false
+	return r.Complement.Apply(plain.Degree(membershipMap))
+}
+
+// Degree looks up the membership degree for the condition, applying
+// negation if requested. Missing variables or sets yield 0.
+func (cond RuleCondition) Degree(membershipMap map[string]map[string]float64) float64 {
+	varMap, ok := membershipMap[cond.Variable]
+	if !ok {
+		return 0
+	}
+	degree, ok := varMap[cond.Set]
+	if !ok {
+		return 0
+	}
+	if cond.Negated {
+		// Apply NOT operator: 1 - membership_degree
+		return 1.0 - degree
+	}
+	return degree
+}
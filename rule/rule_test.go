@@ -1,6 +1,7 @@
 package rule
 
 import (
+	"errors"
 	"github.com/loian/fuzzylib/operators"
 	"math"
 	"testing"
@@ -132,6 +133,72 @@ func TestRule_Evaluate_OR(t *testing.T) {
 	}
 }
 
+func TestRule_Evaluate_NilOperator(t *testing.T) {
+	// A struct literal without an Operator combines its conditions with AND
+	rule := &Rule{
+		Conditions: []RuleCondition{{Variable: "Temperature", Set: "Hot"}, {Variable: "Humidity", Set: "High"}},
+		Output:     RuleCondition{Variable: "FanSpeed", Set: "High"},
+		Weight:     1.0,
+	}
+	membershipMap := map[string]map[string]float64{
+		"Temperature": {"Hot": 0.8},
+		"Humidity":    {"High": 0.6},
+	}
+
+	result, err := rule.Evaluate(membershipMap)
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	if result != 0.6 {
+		t.Errorf("Expected AND result 0.6, got %f", result)
+	}
+}
+
+func TestRule_EvaluateWithConnectives(t *testing.T) {
+	membershipMap := map[string]map[string]float64{
+		"Temperature": {"Hot": 0.8},
+		"Humidity":    {"High": 0.6},
+	}
+	connectives := Connectives{And: operators.PROD, Or: operators.PROBOR}
+
+	grouped, _ := NewRule(RuleCondition{Variable: "FanSpeed", Set: "High"}, operators.OR)
+	_ = grouped.AddGroup(RuleCondition{Variable: "Temperature", Set: "Hot"}, RuleCondition{Variable: "Humidity", Set: "High"})
+	_ = grouped.AddGroup(RuleCondition{Variable: "Humidity", Set: "High"})
+
+	tests := []struct {
+		name     string
+		op       operators.Operator
+		expected float64
+	}{
+		{"AND uses override", operators.AND, 0.48},
+		{"OR uses override", operators.OR, 0.92},
+		{"other operators unchanged", operators.LUKAND, 0.4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _ := NewRule(RuleCondition{Variable: "FanSpeed", Set: "High"}, tt.op)
+			_ = r.AddCondition("Temperature", "Hot")
+			_ = r.AddCondition("Humidity", "High")
+			result, err := r.EvaluateWithConnectives(membershipMap, nil, connectives)
+			if err != nil {
+				t.Fatalf("EvaluateWithConnectives failed: %v", err)
+			}
+			if math.Abs(result-tt.expected) > 1e-9 {
+				t.Errorf("Expected %f, got %f", tt.expected, result)
+			}
+		})
+	}
+
+	// Groups: PROD(0.8, 0.6) = 0.48, then PROBOR(0.48, 0.6) = 0.792
+	result, err := grouped.EvaluateWithConnectives(membershipMap, nil, connectives)
+	if err != nil {
+		t.Fatalf("EvaluateWithConnectives failed: %v", err)
+	}
+	if math.Abs(result-0.792) > 1e-9 {
+		t.Errorf("Expected grouped result 0.792, got %f", result)
+	}
+}
+
 func TestRule_Evaluate_WithWeight(t *testing.T) {
 	output := RuleCondition{Variable: "FanSpeed", Set: "High"}
 	rule, _ := NewRule(output, operators.AND)
@@ -216,6 +283,51 @@ func TestRule_Evaluate_Negated_Single(t *testing.T) {
 	}
 }
 
+func TestRule_Evaluate_Negated_Complement(t *testing.T) {
+	output := RuleCondition{Variable: "FanSpeed", Set: "High"}
+	rule, _ := NewRule(output, operators.AND)
+	if rule.Complement != operators.NOT {
+		t.Errorf("Expected NewRule to default Complement to NOT, got %v", rule.Complement)
+	}
+	rule.Conditions = append(rule.Conditions, RuleCondition{Variable: "Temperature", Set: "Cold", Negated: true})
+	rule.Conditions = append(rule.Conditions, RuleCondition{Variable: "Humidity", Set: "High"})
+
+	membershipMap := map[string]map[string]float64{
+		"Temperature": {"Cold": 0.8},
+		"Humidity":    {"High": 0.9},
+	}
+
+	result, err := rule.Evaluate(membershipMap)
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	if !almostEqual(result, 0.2) { // MIN(1 - 0.8, 0.9)
+		t.Errorf("Expected 0.2 with the default complement, got %f", result)
+	}
+
+	yager, _ := operators.NewYagerComplement(2)
+	if err := rule.SetComplement(yager); err != nil {
+		t.Fatalf("SetComplement failed: %v", err)
+	}
+	result, err = rule.Evaluate(membershipMap)
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	if !almostEqual(result, 0.6) { // MIN(sqrt(1 - 0.8^2), 0.9)
+		t.Errorf("Expected 0.6 with the Yager complement, got %f", result)
+	}
+
+	if err := rule.SetComplement(nil); err == nil {
+		t.Error("Expected error for nil complement, got nil")
+	}
+
+	// Complement errors propagate like those of the other operators
+	membershipMap["Temperature"]["Cold"] = 1.5
+	if _, err := rule.Evaluate(membershipMap); !errors.Is(err, operators.ErrInvalidMembership) {
+		t.Errorf("Expected ErrInvalidMembership from the complement, got %v", err)
+	}
+}
+
 func TestRule_Evaluate_Negated_AND(t *testing.T) {
 	output := RuleCondition{Variable: "FanSpeed", Set: "High"}
 	rule, _ := NewRule(output, operators.AND)
@@ -438,3 +550,187 @@ func findSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestRule_EvaluateGroups(t *testing.T) {
+	// (Temperature IS Hot AND Humidity IS Wet) OR Temperature IS VeryHot
+	rule, _ := NewRule(RuleCondition{Variable: "Fan", Set: "High"}, operators.OR)
+	if err := rule.AddGroup(
+		RuleCondition{Variable: "Temperature", Set: "Hot"},
+		RuleCondition{Variable: "Humidity", Set: "Wet"},
+	); err != nil {
+		t.Fatalf("AddGroup failed: %v", err)
+	}
+	if err := rule.AddGroup(RuleCondition{Variable: "Temperature", Set: "VeryHot"}); err != nil {
+		t.Fatalf("AddGroup failed: %v", err)
+	}
+
+	tests := []struct {
+		hot, wet, veryHot float64
+		expected          float64
+	}{
+		{0.8, 0.6, 0.2, 0.6}, // AND group dominates: max(min(0.8, 0.6), 0.2)
+		{0.8, 0.1, 0.5, 0.5}, // VeryHot dominates: max(min(0.8, 0.1), 0.5)
+		{0.0, 0.9, 0.0, 0.0}, // nothing fires
+	}
+	for _, tt := range tests {
+		membershipMap := map[string]map[string]float64{
+			"Temperature": {"Hot": tt.hot, "VeryHot": tt.veryHot},
+			"Humidity":    {"Wet": tt.wet},
+		}
+		got, err := rule.Evaluate(membershipMap)
+		if err != nil {
+			t.Fatalf("Evaluate failed: %v", err)
+		}
+		if !almostEqual(got, tt.expected) {
+			t.Errorf("hot=%.1f wet=%.1f veryHot=%.1f: expected %f, got %f", tt.hot, tt.wet, tt.veryHot, tt.expected, got)
+		}
+	}
+
+	if len(rule.AllConditions()) != 3 {
+		t.Errorf("Expected 3 conditions across groups, got %d", len(rule.AllConditions()))
+	}
+
+	// Two group results plus the two conditions of the larger group
+	if rule.ScratchLen() != 4 {
+		t.Errorf("Expected ScratchLen 4, got %d", rule.ScratchLen())
+	}
+	membershipMap := map[string]map[string]float64{
+		"Temperature": {"Hot": 0.8, "VeryHot": 0.2},
+		"Humidity":    {"Wet": 0.6},
+	}
+	scratch := make([]float64, rule.ScratchLen())
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = rule.EvaluateWith(membershipMap, scratch)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected grouped EvaluateWith with enough scratch to be allocation-free, got %v allocs per run", allocs)
+	}
+}
+
+func TestRule_AddGroupValidation(t *testing.T) {
+	rule, _ := NewRule(RuleCondition{Variable: "Fan", Set: "High"}, operators.OR)
+	if err := rule.AddGroup(); err == nil {
+		t.Error("Expected error for empty group, got nil")
+	}
+	if err := rule.AddGroup(RuleCondition{Variable: "", Set: "Hot"}); err == nil {
+		t.Error("Expected error for empty variable name, got nil")
+	}
+	if err := rule.AddGroup(RuleCondition{Variable: "Temperature", Set: ""}); err == nil {
+		t.Error("Expected error for empty set name, got nil")
+	}
+}
+
+func TestRule_EvaluateMixedConditionsAndGroups(t *testing.T) {
+	rule, _ := NewRule(RuleCondition{Variable: "Fan", Set: "High"}, operators.OR)
+	_ = rule.AddCondition("Temperature", "Hot")
+	_ = rule.AddGroup(RuleCondition{Variable: "Temperature", Set: "VeryHot"})
+
+	if _, err := rule.Evaluate(map[string]map[string]float64{}); err == nil {
+		t.Error("Expected error when mixing conditions and groups, got nil")
+	}
+}
+
+func TestRule_AddOutput(t *testing.T) {
+	rule, _ := NewRule(RuleCondition{Variable: "Fan", Set: "High"}, operators.AND)
+	if err := rule.AddOutput("Vent", "Open"); err != nil {
+		t.Fatalf("AddOutput failed: %v", err)
+	}
+	outputs := rule.Outputs()
+	if len(outputs) != 2 {
+		t.Fatalf("Expected 2 outputs, got %d", len(outputs))
+	}
+	if outputs[0].Variable != "Fan" || outputs[1].Variable != "Vent" {
+		t.Errorf("Unexpected output order: %v", outputs)
+	}
+
+	if err := rule.AddOutput("", "Open"); err == nil {
+		t.Error("Expected error for empty output variable, got nil")
+	}
+	if err := rule.AddOutput("Vent", ""); err == nil {
+		t.Error("Expected error for empty output set, got nil")
+	}
+}
+
+func TestRule_WeightedConditions(t *testing.T) {
+	membershipMap := map[string]map[string]float64{
+		"Temperature": {"Hot": 0.8},
+		"Humidity":    {"High": 0.4},
+	}
+	newRule := func(weighting string) *Rule {
+		r, _ := NewRule(RuleCondition{Variable: "FanSpeed", Set: "High"}, operators.AND)
+		r.ConditionWeighting = weighting
+		_ = r.AddCondition("Temperature", "Hot")
+		// Humidity limits the AND; it is given half importance
+		if err := r.AddWeightedCondition("Humidity", "High", false, 0.5); err != nil {
+			t.Fatalf("AddWeightedCondition failed: %v", err)
+		}
+		return r
+	}
+
+	// Product scheme: MIN(0.8, 0.5*0.4) = 0.2
+	result, err := newRule("").Evaluate(membershipMap)
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	if math.Abs(result-0.2) > 1e-9 {
+		t.Errorf("Expected product-weighted strength 0.2, got %f", result)
+	}
+
+	// Importance scheme: MIN(0.8, MAX(0.4, 1-0.5)) = 0.5, above the unweighted 0.4
+	result, err = newRule(ConditionWeightImportance).Evaluate(membershipMap)
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+	if math.Abs(result-0.5) > 1e-9 {
+		t.Errorf("Expected down-weighting the limiting condition to raise strength to 0.5, got %f", result)
+	}
+
+	// With AND overridden by product the weight scales the degree like the product
+	// scheme, rather than MAX(0.4, 1-0.5) being multiplied: 0.8 * 0.5*0.4 = 0.16
+	result, err = newRule(ConditionWeightImportance).EvaluateWithConnectives(membershipMap, nil, Connectives{And: operators.PROD})
+	if err != nil {
+		t.Fatalf("EvaluateWithConnectives failed: %v", err)
+	}
+	if math.Abs(result-0.16) > 1e-9 {
+		t.Errorf("Expected importance weighting to follow the product override to 0.16, got %f", result)
+	}
+
+	// Unweighted conditions are unaffected by the scheme
+	r := newRule(ConditionWeightImportance)
+	if got, err := r.ConditionDegree(r.Conditions[0], r.Operator, membershipMap); err != nil || got != 0.8 {
+		t.Errorf("Expected unweighted condition degree 0.8, got %f (%v)", got, err)
+	}
+}
+
+func TestRule_WeightedConditions_OR(t *testing.T) {
+	r, _ := NewRule(RuleCondition{Variable: "FanSpeed", Set: "High"}, operators.OR)
+	r.ConditionWeighting = ConditionWeightImportance
+	_ = r.AddCondition("Temperature", "Hot")
+	_ = r.AddWeightedCondition("Humidity", "High", false, 0.3)
+	membershipMap := map[string]map[string]float64{
+		"Temperature": {"Hot": 0.2},
+		"Humidity":    {"High": 0.9},
+	}
+
+	// MAX(0.2, MIN(0.9, 0.3)) = 0.3: a less important condition can dominate less
+	result, _ := r.Evaluate(membershipMap)
+	if math.Abs(result-0.3) > 1e-9 {
+		t.Errorf("Expected importance-weighted OR strength 0.3, got %f", result)
+	}
+}
+
+func TestRule_AddWeightedCondition_Validation(t *testing.T) {
+	r, _ := NewRule(RuleCondition{Variable: "FanSpeed", Set: "High"}, operators.AND)
+	if err := r.AddWeightedCondition("Temperature", "Hot", false, 0); err == nil {
+		t.Error("Expected error for zero weight, got nil")
+	}
+	if err := r.AddWeightedCondition("Temperature", "Hot", false, 1.5); err == nil {
+		t.Error("Expected error for weight above 1, got nil")
+	}
+	if err := r.AddWeightedCondition("", "Hot", false, 0.5); err == nil {
+		t.Error("Expected error for empty variable name, got nil")
+	}
+	if len(r.Conditions) != 0 {
+		t.Errorf("Expected no conditions after failed adds, got %d", len(r.Conditions))
+	}
+}
@@ -1,8 +1,15 @@
 package set
 
 import (
+	"encoding/csv"
+	"errors"
 	"fmt"
+	"github.com/loian/fuzzylib/internal/polyline"
 	"github.com/loian/fuzzylib/membership"
+	"io"
+	"math"
+	"strconv"
+	"strings"
 )
 
 // FuzzySet represents a fuzzy set with a membership function
@@ -30,3 +37,412 @@ func NewFuzzySet(name string, mf membership.MembershipFunction) (*FuzzySet, erro
 func (fs *FuzzySet) Evaluate(x float64) float64 {
 	return fs.MembershipFunc.Evaluate(x)
 }
+
+// Peak returns the representative location of the set's membership function
+// (see membership.Peaked). ok is false if the function does not expose one,
+// e.g. a Custom function, or a Scaled one wrapping it.
+func (fs *FuzzySet) Peak() (peak float64, ok bool) {
+	p, ok := fs.MembershipFunc.(membership.Peaked)
+	if !ok {
+		return 0, false
+	}
+	peak = p.Peak()
+	if math.IsNaN(peak) {
+		return 0, false
+	}
+	return peak, true
+}
+
+// LoadPiecewise creates a fuzzy set with a piecewise-linear membership function
+// from measured (x, mu) samples read from r as two-column CSV, one sample per line.
+// Lines starting with '#' are ignored, and a first line whose x column is not a
+// number is treated as a header.
+// Returns error if name is empty, the CSV is malformed, a value is not a number,
+// or the samples are rejected by membership.NewPiecewiseLinear (fewer than two
+// points, x not strictly increasing, mu outside [0, 1]).
+func LoadPiecewise(name string, r io.Reader) (*FuzzySet, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = 2
+	cr.TrimLeadingSpace = true
+
+	var points [][2]float64
+	for first := true; ; first = false {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading samples for set '%s': %w", name, err)
+		}
+		line, _ := cr.FieldPos(0)
+		x, err := strconv.ParseFloat(strings.TrimSpace(record[0]), 64)
+		if err != nil {
+			if first {
+				continue // header
+			}
+			return nil, fmt.Errorf("line %d: invalid x value %q", line, record[0])
+		}
+		mu, err := strconv.ParseFloat(strings.TrimSpace(record[1]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid membership value %q", line, record[1])
+		}
+		points = append(points, [2]float64{x, mu})
+	}
+
+	mf, err := membership.NewPiecewiseLinear(points)
+	if err != nil {
+		return nil, fmt.Errorf("invalid samples for set '%s': %w", name, err)
+	}
+	return NewFuzzySet(name, mf)
+}
+
+// normalTolerance is the slack allowed when deciding whether the estimated
+// peak counts as reaching full membership.
+const normalTolerance = 1e-6
+
+// refineIterations is the number of local zoom passes MaxMembership performs
+// around the best sample. Each pass shrinks the search window by a factor of 5.
+const refineIterations = 30
+
+// IsNormal reports whether the set reaches full membership (1.0) within the
+// domain [min, max], using MaxMembership to estimate the peak.
+// A set whose peak falls outside the domain, or whose definition is clipped,
+// is non-normal and weakens any rule that uses it.
+// Returns false if min > max or resolution <= 0.
+func (fs *FuzzySet) IsNormal(min, max float64, resolution int) bool {
+	if min > max || resolution <= 0 {
+		return false
+	}
+	return fs.MaxMembership(min, max, resolution) >= 1.0-normalTolerance
+}
+
+// MaxMembership estimates the highest membership degree (supremum) of the set
+// over [min, max]. The domain is sampled at resolution+1 evenly spaced points,
+// then the neighbourhood of the best sample is refined so that peaks falling
+// between samples, and shoulders whose boundary point evaluates to 0, are
+// still found.
+// Returns 0 if min > max or resolution <= 0.
+func (fs *FuzzySet) MaxMembership(min, max float64, resolution int) float64 {
+	if min > max || resolution <= 0 {
+		return 0
+	}
+	step := (max - min) / float64(resolution)
+	bestX := min
+	highest := fs.Evaluate(min)
+	for i := 1; i <= resolution; i++ {
+		x := min + float64(i)*step
+		if degree := fs.Evaluate(x); degree > highest {
+			highest = degree
+			bestX = x
+		}
+	}
+
+	// Zoom in around the best sample
+	for iter := 0; iter < refineIterations && step > 0; iter++ {
+		lo := math.Max(min, bestX-step)
+		hi := math.Min(max, bestX+step)
+		step = (hi - lo) / 10
+		for i := 0; i <= 10; i++ {
+			x := lo + float64(i)*step
+			if degree := fs.Evaluate(x); degree > highest {
+				highest = degree
+				bestX = x
+			}
+		}
+	}
+	return highest
+}
+
+// IsMonotonic reports whether s is monotonic over [min, max], and if so whether it
+// is non-decreasing (increasing) or non-increasing, by checking the sign of the
+// differences between successive samples. Plateaus are allowed; a set that is
+// constant over the domain is reported as increasing. Samples are taken at the
+// midpoints of resolution equal intervals, so a shoulder whose boundary point
+// evaluates to 0 is not mistaken for a non-monotonic set.
+// Tsukamoto consequents and S/Z-shaped sets are expected to be monotonic.
+// Returns false, false if min >= max or resolution <= 0.
+func IsMonotonic(s *FuzzySet, min, max float64, resolution int) (monotonic bool, increasing bool) {
+	if min >= max || resolution <= 0 {
+		return false, false
+	}
+	step := (max - min) / float64(resolution)
+	rising, falling := false, false
+	prev := s.Evaluate(min + step/2)
+	for i := 1; i < resolution; i++ {
+		cur := s.Evaluate(min + (float64(i)+0.5)*step)
+		if cur > prev {
+			rising = true
+		} else if cur < prev {
+			falling = true
+		}
+		if rising && falling {
+			return false, false
+		}
+		prev = cur
+	}
+	return true, !falling
+}
+
+// Normalize returns a copy of s whose membership is scaled so that its maximum
+// over [min, max] (as estimated by MaxMembership) is 1.0. Normal sets, and sets
+// with zero membership throughout the domain, are returned as an unscaled copy.
+// Use with care on input sets: normalization strengthens every rule using the set.
+func Normalize(s *FuzzySet, min, max float64, resolution int) *FuzzySet {
+	peak := s.MaxMembership(min, max, resolution)
+	if peak <= 0 || peak >= 1.0-normalTolerance {
+		return &FuzzySet{Name: s.Name, MembershipFunc: s.MembershipFunc}
+	}
+	return &FuzzySet{
+		Name:           s.Name,
+		MembershipFunc: &membership.Scaled{MF: s.MembershipFunc, Factor: 1 / peak},
+	}
+}
+
+// Area returns the area under the set's membership curve over [min, max].
+// Triangular, trapezoidal, rectangular and piecewise-linear sets are integrated
+// exactly; other membership functions use the trapezoidal rule over resolution intervals.
+// Returns 0 if min >= max or resolution <= 0.
+func (fs *FuzzySet) Area(min, max float64, resolution int) float64 {
+	if min >= max || resolution <= 0 {
+		return 0
+	}
+	switch mf := fs.MembershipFunc.(type) {
+	case *membership.Triangular:
+		_, area := piecewiseLinearCentroidArea([]float64{mf.A, mf.B, mf.C}, []float64{0, 1, 0}, min, max)
+		return area
+	case *membership.Trapezoidal:
+		_, area := piecewiseLinearCentroidArea([]float64{mf.A, mf.B, mf.C, mf.D}, []float64{0, 1, 1, 0}, min, max)
+		return area
+	case *membership.Rectangular:
+		return math.Max(0, math.Min(max, mf.Hi)-math.Max(min, mf.Lo))
+	case *membership.PiecewiseLinear:
+		_, area := piecewiseLinearCentroidArea(mf.X, mf.Y, min, max)
+		return area
+	}
+
+	step := (max - min) / float64(resolution)
+	area := 0.0
+	prev := fs.Evaluate(min)
+	for i := 1; i <= resolution; i++ {
+		cur := fs.Evaluate(min + float64(i)*step)
+		area += step * (prev + cur) / 2
+		prev = cur
+	}
+	return area
+}
+
+// Translate returns a copy of s with its membership function shifted along the
+// domain by delta, so that the new set's degree at x + delta equals s's degree at x.
+// Built-in parametric shapes are shifted analytically; Scaled wrappers are
+// translated through to the function they wrap.
+// Returns error if delta is NaN or infinite, or the membership function is a
+// custom type that cannot be translated.
+func Translate(s *FuzzySet, delta float64) (*FuzzySet, error) {
+	if math.IsNaN(delta) || math.IsInf(delta, 0) {
+		return nil, fmt.Errorf("translation delta must be a finite number, got %v", delta)
+	}
+	mf, err := translateMF(s.MembershipFunc, delta)
+	if err != nil {
+		return nil, fmt.Errorf("cannot translate set '%s': %w", s.Name, err)
+	}
+	return &FuzzySet{Name: s.Name, MembershipFunc: mf}, nil
+}
+
+// translateMF returns a copy of mf shifted by delta
+func translateMF(mf membership.MembershipFunction, delta float64) (membership.MembershipFunction, error) {
+	switch mf := mf.(type) {
+	case *membership.Triangular:
+		return &membership.Triangular{A: mf.A + delta, B: mf.B + delta, C: mf.C + delta}, nil
+	case *membership.Trapezoidal:
+		return &membership.Trapezoidal{A: mf.A + delta, B: mf.B + delta, C: mf.C + delta, D: mf.D + delta}, nil
+	case *membership.Gaussian:
+		g, err := membership.NewGaussian(mf.Center+delta, mf.Width)
+		if err != nil {
+			return nil, err
+		}
+		return g, nil
+	case *membership.GaussianHalf:
+		return &membership.GaussianHalf{Center: mf.Center + delta, Width: mf.Width, Rising: mf.Rising}, nil
+	case *membership.Rectangular:
+		return &membership.Rectangular{Lo: mf.Lo + delta, Hi: mf.Hi + delta}, nil
+	case *membership.PiecewiseLinear:
+		xs := make([]float64, len(mf.X))
+		for i, x := range mf.X {
+			xs[i] = x + delta
+		}
+		return &membership.PiecewiseLinear{X: xs, Y: append([]float64(nil), mf.Y...)}, nil
+	case *membership.Scaled:
+		inner, err := translateMF(mf.MF, delta)
+		if err != nil {
+			return nil, err
+		}
+		return &membership.Scaled{MF: inner, Factor: mf.Factor}, nil
+	default:
+		return nil, fmt.Errorf("membership function type %T does not support translation", mf)
+	}
+}
+
+// AlphaCut returns the alpha-cut of the set, the interval [lo, hi] where its
+// membership is at least alpha. Built-in shapes are cut analytically; for a
+// non-convex piecewise-linear set the interval spans from the first to the last
+// point reaching alpha. Gaussian halves are unbounded on their saturated side.
+// ok is false if the cut is empty, alpha is not in (0, 1], or the membership
+// function is a custom type whose shape is unknown.
+func (fs *FuzzySet) AlphaCut(alpha float64) (lo, hi float64, ok bool) {
+	if !(alpha > 0 && alpha <= 1) {
+		return 0, 0, false
+	}
+	return alphaCutMF(fs.MembershipFunc, alpha)
+}
+
+// AlphaCuts returns AlphaCut for each level, in order, for plotting several cuts
+// at once. Empty cuts are returned as [NaN, NaN].
+func (fs *FuzzySet) AlphaCuts(levels []float64) [][2]float64 {
+	cuts := make([][2]float64, len(levels))
+	for i, alpha := range levels {
+		if lo, hi, ok := fs.AlphaCut(alpha); ok {
+			cuts[i] = [2]float64{lo, hi}
+		} else {
+			cuts[i] = [2]float64{math.NaN(), math.NaN()}
+		}
+	}
+	return cuts
+}
+
+// alphaCutMF returns the alpha-cut of mf for alpha in (0, 1]
+func alphaCutMF(mf membership.MembershipFunction, alpha float64) (lo, hi float64, ok bool) {
+	switch mf := mf.(type) {
+	case *membership.Triangular:
+		return mf.A + alpha*(mf.B-mf.A), mf.C - alpha*(mf.C-mf.B), true
+	case *membership.Trapezoidal:
+		return mf.A + alpha*(mf.B-mf.A), mf.D - alpha*(mf.D-mf.C), true
+	case *membership.Gaussian:
+		offset := mf.Width * math.Sqrt(-2*math.Log(alpha))
+		return mf.Center - offset, mf.Center + offset, true
+	case *membership.GaussianHalf:
+		if mf.Rising {
+			return mf.Inverse(alpha), math.Inf(1), true
+		}
+		return math.Inf(-1), mf.Inverse(alpha), true
+	case *membership.Rectangular:
+		return mf.Lo, mf.Hi, true
+	case *membership.PiecewiseLinear:
+		return piecewiseLinearAlphaCut(mf.X, mf.Y, alpha)
+	case *membership.Scaled:
+		if mf.Factor <= 0 || alpha/mf.Factor > 1 {
+			return 0, 0, false
+		}
+		return alphaCutMF(mf.MF, alpha/mf.Factor)
+	}
+	return 0, 0, false
+}
+
+// piecewiseLinearAlphaCut returns the first and last x where the polyline
+// through (xs[i], ys[i]) reaches alpha
+func piecewiseLinearAlphaCut(xs, ys []float64, alpha float64) (lo, hi float64, ok bool) {
+	first, last := -1, -1
+	for i, y := range ys {
+		if y >= alpha {
+			if first < 0 {
+				first = i
+			}
+			last = i
+		}
+	}
+	if first < 0 {
+		return 0, 0, false
+	}
+	lo, hi = xs[first], xs[last]
+	// Move out to where the neighbouring segments cross alpha
+	if first > 0 {
+		i := first - 1
+		lo = xs[i] + (alpha-ys[i])/(ys[first]-ys[i])*(xs[first]-xs[i])
+	}
+	if last < len(ys)-1 {
+		i := last + 1
+		hi = xs[last] + (ys[last]-alpha)/(ys[last]-ys[i])*(xs[i]-xs[last])
+	}
+	return lo, hi, true
+}
+
+// gaussianTails is the number of widths either side of a Gaussian's center beyond
+// which truncation by the domain is ignored when computing its centroid.
+const gaussianTails = 6
+
+// Centroid returns the centroid (center of gravity) of the set's membership
+// curve over [min, max]. Triangular, trapezoidal, rectangular and piecewise-linear
+// sets are computed exactly, and a Gaussian that lies well inside the domain returns its
+// center; other membership functions use numeric integration over resolution
+// intervals.
+// Returns 0 if min >= max, resolution <= 0, or the set has zero area in the domain.
+func (fs *FuzzySet) Centroid(min, max float64, resolution int) float64 {
+	if min >= max || resolution <= 0 {
+		return 0
+	}
+	switch mf := fs.MembershipFunc.(type) {
+	case *membership.Triangular:
+		centroid, _ := piecewiseLinearCentroidArea([]float64{mf.A, mf.B, mf.C}, []float64{0, 1, 0}, min, max)
+		return centroid
+	case *membership.Trapezoidal:
+		centroid, _ := piecewiseLinearCentroidArea([]float64{mf.A, mf.B, mf.C, mf.D}, []float64{0, 1, 1, 0}, min, max)
+		return centroid
+	case *membership.Rectangular:
+		lo := math.Max(min, mf.Lo)
+		hi := math.Min(max, mf.Hi)
+		if hi <= lo {
+			return 0
+		}
+		return (lo + hi) / 2
+	case *membership.PiecewiseLinear:
+		centroid, _ := piecewiseLinearCentroidArea(mf.X, mf.Y, min, max)
+		return centroid
+	case *membership.Gaussian:
+		if mf.Center-gaussianTails*mf.Width >= min && mf.Center+gaussianTails*mf.Width <= max {
+			return mf.Center
+		}
+	}
+
+	step := (max - min) / float64(resolution)
+	area, moment := 0.0, 0.0
+	for i := 0; i <= resolution; i++ {
+		x := min + float64(i)*step
+		degree := fs.Evaluate(x)
+		// Trapezoidal rule weights: endpoints count half
+		if i == 0 || i == resolution {
+			degree /= 2
+		}
+		area += degree
+		moment += x * degree
+	}
+	if area == 0 {
+		return 0
+	}
+	return moment / area
+}
+
+// piecewiseLinearCentroidArea returns the centroid and area of the piecewise-linear
+// curve through the points (xs[i], ys[i]) restricted to [min, max]. The curve is 0
+// outside [xs[0], xs[len-1]] and xs must be non-decreasing; zero-width segments
+// contribute nothing.
+// Returns (0, 0) if the area is zero.
+func piecewiseLinearCentroidArea(xs, ys []float64, min, max float64) (centroid, area float64) {
+	clippedXs := make([]float64, 0, 2*len(xs))
+	clippedYs := make([]float64, 0, 2*len(ys))
+	for i := 1; i < len(xs); i++ {
+		x0, x1 := xs[i-1], xs[i]
+		width := x1 - x0
+		if width <= 0 {
+			continue
+		}
+		lo := math.Max(x0, min)
+		hi := math.Min(x1, max)
+		if hi <= lo {
+			continue
+		}
+		slope := (ys[i] - ys[i-1]) / width
+		clippedXs = append(clippedXs, lo, hi)
+		clippedYs = append(clippedYs, ys[i-1]+slope*(lo-x0), ys[i-1]+slope*(hi-x0))
+	}
+	return polyline.CentroidArea(clippedXs, clippedYs)
+}
@@ -2,9 +2,18 @@ package set
 
 import (
 	"github.com/loian/fuzzylib/membership"
+	"math"
+	"strings"
 	"testing"
 )
 
+const epsilon = 1e-9
+
+// Helper function to compare floats
+func floatEqual(a, b float64) bool {
+	return math.Abs(a-b) < epsilon
+}
+
 func TestNewFuzzySet(t *testing.T) {
 	memFunc, _ := membership.NewTriangular(0, 5, 10)
 	fuzzySet, err := NewFuzzySet("TestSet", memFunc)
@@ -93,3 +102,419 @@ func TestFuzzySet_WithDifferentMembershipFunctions(t *testing.T) {
 		})
 	}
 }
+
+func TestFuzzySet_IsNormal(t *testing.T) {
+	memFunc, _ := membership.NewTriangular(0, 5, 10)
+	fuzzySet, _ := NewFuzzySet("Normal", memFunc)
+	if !fuzzySet.IsNormal(0, 10, 1000) {
+		t.Error("Expected set peaking inside the domain to be normal")
+	}
+}
+
+func TestFuzzySet_IsNormal_PeakOutsideDomain(t *testing.T) {
+	// Peak at 10 lies outside [0, 8]; max membership within the domain is 0.8
+	memFunc, _ := membership.NewTriangular(0, 10, 20)
+	fuzzySet, _ := NewFuzzySet("Clipped", memFunc)
+
+	if got := fuzzySet.MaxMembership(0, 8, 1000); !floatEqual(got, 0.8) {
+		t.Errorf("Expected max membership 0.8, got %f", got)
+	}
+	if fuzzySet.IsNormal(0, 8, 1000) {
+		t.Error("Expected set with max membership 0.8 to be non-normal")
+	}
+}
+
+func TestFuzzySet_IsNormal_InvalidArgs(t *testing.T) {
+	memFunc, _ := membership.NewTriangular(0, 5, 10)
+	fuzzySet, _ := NewFuzzySet("Normal", memFunc)
+	if fuzzySet.IsNormal(10, 0, 1000) {
+		t.Error("Expected false when min > max")
+	}
+	if fuzzySet.IsNormal(0, 10, 0) {
+		t.Error("Expected false for non-positive resolution")
+	}
+}
+
+func TestFuzzySet_IsNormal_Shoulder(t *testing.T) {
+	// A left shoulder evaluates to 0 exactly at A=B but approaches 1 just inside
+	memFunc, _ := membership.NewTriangular(0, 0, 25)
+	fuzzySet, _ := NewFuzzySet("Cold", memFunc)
+	if !fuzzySet.IsNormal(0, 50, 1000) {
+		t.Errorf("Expected shoulder set to be normal, max membership %f", fuzzySet.MaxMembership(0, 50, 1000))
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	// Peak at 10 lies outside [0, 8]; max membership within the domain is 0.8
+	memFunc, _ := membership.NewTriangular(0, 10, 20)
+	clipped, _ := NewFuzzySet("Clipped", memFunc)
+
+	normalized := Normalize(clipped, 0, 8, 1000)
+	if normalized.Name != "Clipped" {
+		t.Errorf("Expected name to be preserved, got %s", normalized.Name)
+	}
+	if got := normalized.MaxMembership(0, 8, 1000); !floatEqual(got, 1.0) {
+		t.Errorf("Expected normalized peak 1.0, got %f", got)
+	}
+	if !normalized.IsNormal(0, 8, 1000) {
+		t.Error("Expected normalized set to be normal")
+	}
+	// Degrees are scaled by 1/0.8
+	if got := normalized.Evaluate(4); !floatEqual(got, 0.5) {
+		t.Errorf("Expected 0.4/0.8 = 0.5 at x=4, got %f", got)
+	}
+	// Original set is unchanged
+	if got := clipped.Evaluate(8); !floatEqual(got, 0.8) {
+		t.Errorf("Expected original set unchanged (0.8 at x=8), got %f", got)
+	}
+}
+
+func TestNormalize_AlreadyNormal(t *testing.T) {
+	memFunc, _ := membership.NewTriangular(0, 5, 10)
+	fuzzySet, _ := NewFuzzySet("Normal", memFunc)
+	normalized := Normalize(fuzzySet, 0, 10, 1000)
+	if got := normalized.Evaluate(2.5); !floatEqual(got, 0.5) {
+		t.Errorf("Expected normal set to be unchanged, got %f at x=2.5", got)
+	}
+}
+
+func TestFuzzySet_Area_Triangle(t *testing.T) {
+	memFunc, _ := membership.NewTriangular(2, 5, 10)
+	fuzzySet, _ := NewFuzzySet("Tri", memFunc)
+	// Area of a unit-height triangle is 0.5 * base
+	if got := fuzzySet.Area(0, 20, 1000); !floatEqual(got, 0.5*8) {
+		t.Errorf("Expected area 4, got %f", got)
+	}
+	// Clipped at the peak: only the left half lies inside the domain
+	if got := fuzzySet.Area(0, 5, 1000); !floatEqual(got, 1.5) {
+		t.Errorf("Expected clipped area 1.5, got %f", got)
+	}
+}
+
+func TestFuzzySet_Area_TrapezoidalAndRectangular(t *testing.T) {
+	trap, _ := membership.NewTrapezoidal(0, 2, 6, 8)
+	trapSet, _ := NewFuzzySet("Trap", trap)
+	if got := trapSet.Area(0, 10, 1000); !floatEqual(got, 6) {
+		t.Errorf("Expected trapezoid area 6, got %f", got)
+	}
+
+	rect, _ := membership.NewRectangular(3, 7)
+	rectSet, _ := NewFuzzySet("Rect", rect)
+	if got := rectSet.Area(0, 10, 1000); !floatEqual(got, 4) {
+		t.Errorf("Expected rectangle area 4, got %f", got)
+	}
+	if got := rectSet.Area(5, 10, 1000); !floatEqual(got, 2) {
+		t.Errorf("Expected clipped rectangle area 2, got %f", got)
+	}
+}
+
+func TestFuzzySet_Area_Numeric(t *testing.T) {
+	// Wrapping the triangle forces the numeric path; it should match the analytic result
+	memFunc, _ := membership.NewTriangular(2, 5, 10)
+	fuzzySet, _ := NewFuzzySet("Wrapped", &membership.Scaled{MF: memFunc, Factor: 1})
+	if got := fuzzySet.Area(0, 20, 1000); math.Abs(got-4) > 1e-3 {
+		t.Errorf("Expected numeric area close to 4, got %f", got)
+	}
+	if got := fuzzySet.Area(10, 0, 1000); got != 0 {
+		t.Errorf("Expected 0 for min > max, got %f", got)
+	}
+	if got := fuzzySet.Area(0, 20, 0); got != 0 {
+		t.Errorf("Expected 0 for non-positive resolution, got %f", got)
+	}
+}
+
+func TestFuzzySet_Centroid_Triangle(t *testing.T) {
+	cases := [][3]float64{{0, 5, 10}, {2, 3, 10}, {0, 0, 6}, {1, 9, 9}}
+	for _, c := range cases {
+		memFunc, _ := membership.NewTriangular(c[0], c[1], c[2])
+		fuzzySet, _ := NewFuzzySet("Tri", memFunc)
+		want := (c[0] + c[1] + c[2]) / 3
+		if got := fuzzySet.Centroid(-10, 20, 1000); !floatEqual(got, want) {
+			t.Errorf("Triangular(%v, %v, %v): expected centroid %f, got %f", c[0], c[1], c[2], want, got)
+		}
+	}
+}
+
+func TestFuzzySet_Centroid_Shapes(t *testing.T) {
+	trap, _ := membership.NewTrapezoidal(0, 2, 6, 8)
+	trapSet, _ := NewFuzzySet("Trap", trap)
+	if got := trapSet.Centroid(0, 10, 1000); !floatEqual(got, 4) {
+		t.Errorf("Expected symmetric trapezoid centroid 4, got %f", got)
+	}
+
+	rect, _ := membership.NewRectangular(3, 7)
+	rectSet, _ := NewFuzzySet("Rect", rect)
+	if got := rectSet.Centroid(0, 10, 1000); !floatEqual(got, 5) {
+		t.Errorf("Expected rectangle centroid 5, got %f", got)
+	}
+	if got := rectSet.Centroid(5, 10, 1000); !floatEqual(got, 6) {
+		t.Errorf("Expected clipped rectangle centroid 6, got %f", got)
+	}
+
+	w, _ := membership.NewPiecewiseLinear([][2]float64{{0, 1}, {2, 0}, {5, 0.8}, {8, 0}, {10, 1}})
+	wSet, _ := NewFuzzySet("W", w)
+	if got := wSet.Centroid(0, 10, 1000); !floatEqual(got, 5) {
+		t.Errorf("Expected symmetric piecewise-linear centroid 5, got %f", got)
+	}
+	if got := wSet.Area(0, 10, 1000); !floatEqual(got, 4.4) {
+		t.Errorf("Expected piecewise-linear area 4.4, got %f", got)
+	}
+
+	gauss, _ := membership.NewGaussian(50, 5)
+	gaussSet, _ := NewFuzzySet("Gauss", gauss)
+	if got := gaussSet.Centroid(0, 100, 1000); !floatEqual(got, 50) {
+		t.Errorf("Expected gaussian centroid 50, got %f", got)
+	}
+	// Truncated on the right, the centroid moves left of the center
+	if got := gaussSet.Centroid(0, 52, 1000); got >= 50 {
+		t.Errorf("Expected truncated gaussian centroid below 50, got %f", got)
+	}
+}
+
+func TestFuzzySet_Centroid_Numeric(t *testing.T) {
+	// Wrapping the triangle forces the numeric path; it should match the analytic result
+	memFunc, _ := membership.NewTriangular(2, 3, 10)
+	fuzzySet, _ := NewFuzzySet("Wrapped", &membership.Scaled{MF: memFunc, Factor: 1})
+	if got := fuzzySet.Centroid(0, 20, 1000); math.Abs(got-5) > 1e-3 {
+		t.Errorf("Expected numeric centroid close to 5, got %f", got)
+	}
+	if got := fuzzySet.Centroid(10, 0, 1000); got != 0 {
+		t.Errorf("Expected 0 for min > max, got %f", got)
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	memFunc, _ := membership.NewTriangular(0, 5, 10)
+	original, _ := NewFuzzySet("Mid", memFunc)
+
+	shifted, err := Translate(original, 10)
+	if err != nil {
+		t.Fatalf("Translate failed: %v", err)
+	}
+	if shifted.Name != "Mid" {
+		t.Errorf("Expected name to be preserved, got %s", shifted.Name)
+	}
+	if got := shifted.Evaluate(15); !floatEqual(got, 1) {
+		t.Errorf("Expected peak moved to 15, got %f there", got)
+	}
+	if got := shifted.Evaluate(5); got != 0 {
+		t.Errorf("Expected old peak at 5 to be outside the shifted set, got %f", got)
+	}
+	for x := -5.0; x <= 15; x += 0.5 {
+		if !floatEqual(shifted.Evaluate(x+10), original.Evaluate(x)) {
+			t.Fatalf("Expected shifted(%f) == original(%f)", x+10, x)
+		}
+	}
+	// Original set is unchanged
+	if got := original.Evaluate(5); !floatEqual(got, 1) {
+		t.Errorf("Expected original peak at 5 unchanged, got %f", got)
+	}
+}
+
+func TestTranslate_Shapes(t *testing.T) {
+	trap, _ := membership.NewTrapezoidal(0, 2, 6, 8)
+	gauss, _ := membership.NewGaussian(5, 2)
+	rect, _ := membership.NewRectangular(2, 8)
+	tri, _ := membership.NewTriangular(0, 5, 10)
+	pl, _ := membership.NewPiecewiseLinear([][2]float64{{0, 1}, {2, 0}, {5, 0.8}, {8, 0}, {10, 1}})
+	mfs := map[string]membership.MembershipFunction{
+		"piecewise":   pl,
+		"trapezoidal": trap,
+		"gaussian":    gauss,
+		"rising half": gauss.RisingHalf(),
+		"rectangular": rect,
+		"scaled":      &membership.Scaled{MF: tri, Factor: 0.5},
+	}
+	for name, mf := range mfs {
+		original, _ := NewFuzzySet(name, mf)
+		shifted, err := Translate(original, -3)
+		if err != nil {
+			t.Fatalf("%s: Translate failed: %v", name, err)
+		}
+		for x := -5.0; x <= 15; x += 0.25 {
+			if !floatEqual(shifted.Evaluate(x-3), original.Evaluate(x)) {
+				t.Fatalf("%s: expected shifted(%f) == original(%f)", name, x-3, x)
+			}
+		}
+	}
+}
+
+// customMF is a membership function type unknown to Translate
+type customMF struct{}
+
+func (customMF) Evaluate(x float64) float64 { return 0.5 }
+
+func (customMF) Support() (left, right float64, bounded bool) {
+	return math.Inf(-1), math.Inf(1), false
+}
+
+func TestTranslate_Errors(t *testing.T) {
+	custom, _ := NewFuzzySet("Custom", customMF{})
+	if _, err := Translate(custom, 1); err == nil {
+		t.Error("Expected error for custom membership function, got nil")
+	}
+	wrapped, _ := NewFuzzySet("Wrapped", &membership.Scaled{MF: customMF{}, Factor: 1})
+	if _, err := Translate(wrapped, 1); err == nil {
+		t.Error("Expected error for scaled custom membership function, got nil")
+	}
+	memFunc, _ := membership.NewTriangular(0, 5, 10)
+	fuzzySet, _ := NewFuzzySet("Tri", memFunc)
+	if _, err := Translate(fuzzySet, math.NaN()); err == nil {
+		t.Error("Expected error for NaN delta, got nil")
+	}
+}
+
+func TestLoadPiecewise(t *testing.T) {
+	data := `x,mu
+# measured comfort curve
+0, 0
+10, 0.5
+20, 1
+40, 0
+`
+	fs, err := LoadPiecewise("Comfort", strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("LoadPiecewise failed: %v", err)
+	}
+	if fs.Name != "Comfort" {
+		t.Errorf("Expected name Comfort, got %s", fs.Name)
+	}
+	tests := []struct {
+		x, expected float64
+	}{
+		{5, 0.25}, {10, 0.5}, {20, 1}, {30, 0.5}, {50, 0},
+	}
+	for _, tt := range tests {
+		if got := fs.Evaluate(tt.x); !floatEqual(got, tt.expected) {
+			t.Errorf("Evaluate(%v) = %f, expected %f", tt.x, got, tt.expected)
+		}
+	}
+}
+
+func TestLoadPiecewise_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"decreasing x":  "0,0\n10,1\n5,0\n",
+		"mu above 1":    "0,0\n10,1.5\n",
+		"bad number":    "0,0\n10,abc\n",
+		"bad x":         "0,0\nten,1\n",
+		"one point":     "0,1\n",
+		"three columns": "0,0,1\n10,1,1\n",
+	}
+	for name, data := range tests {
+		if _, err := LoadPiecewise("S", strings.NewReader(data)); err == nil {
+			t.Errorf("%s: expected error, got nil", name)
+		}
+	}
+	if _, err := LoadPiecewise("", strings.NewReader("0,0\n1,1\n")); err == nil {
+		t.Error("Expected error for empty name, got nil")
+	}
+}
+
+func TestFuzzySet_Peak(t *testing.T) {
+	trap, _ := membership.NewTrapezoidal(0, 2, 6, 10)
+	s, _ := NewFuzzySet("Trap", trap)
+	if peak, ok := s.Peak(); !ok || !floatEqual(peak, 4) {
+		t.Errorf("Expected peak 4, got %f (ok=%v)", peak, ok)
+	}
+
+	custom, _ := membership.NewCustom(math.Sin)
+	for _, mf := range []membership.MembershipFunction{custom, &membership.Scaled{MF: custom, Factor: 1}} {
+		s, _ := NewFuzzySet("Custom", mf)
+		if _, ok := s.Peak(); ok {
+			t.Errorf("Expected no peak for %T", mf)
+		}
+	}
+}
+
+func TestAlphaCuts_Triangle(t *testing.T) {
+	tri, _ := membership.NewTriangular(0, 4, 10)
+	s, _ := NewFuzzySet("Tri", tri)
+
+	levels := []float64{0.25, 0.5, 0.75, 1}
+	cuts := s.AlphaCuts(levels)
+	expected := [][2]float64{{1, 8.5}, {2, 7}, {3, 5.5}, {4, 4}}
+	for i, cut := range cuts {
+		if !floatEqual(cut[0], expected[i][0]) || !floatEqual(cut[1], expected[i][1]) {
+			t.Errorf("alpha %.2f: expected %v, got %v", levels[i], expected[i], cut)
+		}
+		// Higher levels give nested, shrinking intervals
+		if i > 0 && (cut[0] < cuts[i-1][0] || cut[1] > cuts[i-1][1]) {
+			t.Errorf("alpha %.2f: cut %v is not inside %v", levels[i], cut, cuts[i-1])
+		}
+	}
+
+	// Levels outside (0, 1] give the empty sentinel
+	for _, cut := range s.AlphaCuts([]float64{0, 1.5, math.NaN()}) {
+		if !math.IsNaN(cut[0]) || !math.IsNaN(cut[1]) {
+			t.Errorf("Expected empty cut [NaN, NaN], got %v", cut)
+		}
+	}
+}
+
+func TestAlphaCut_Shapes(t *testing.T) {
+	gauss, _ := membership.NewGaussian(5, 2)
+	halfWidth := 2 * math.Sqrt(-2*math.Log(0.5))
+	custom, _ := membership.NewCustom(math.Sin)
+	tests := []struct {
+		name   string
+		mf     membership.MembershipFunction
+		lo, hi float64
+		ok     bool
+	}{
+		{"trapezoidal", &membership.Trapezoidal{A: 0, B: 2, C: 6, D: 10}, 1, 8, true},
+		{"gaussian", gauss, 5 - halfWidth, 5 + halfWidth, true},
+		{"gaussian rising half", gauss.RisingHalf(), 5 - halfWidth, math.Inf(1), true},
+		{"rectangular", &membership.Rectangular{Lo: 2, Hi: 8}, 2, 8, true},
+		{"piecewise linear", &membership.PiecewiseLinear{X: []float64{0, 2, 4, 6, 8}, Y: []float64{0, 1, 0.2, 1, 0}}, 1, 7, true},
+		{"piecewise linear below alpha", &membership.PiecewiseLinear{X: []float64{0, 2, 4}, Y: []float64{0, 0.4, 0}}, 0, 0, false},
+		{"scaled", &membership.Scaled{MF: &membership.Triangular{A: 0, B: 4, C: 8}, Factor: 2}, 1, 7, true},
+		{"scaled below alpha", &membership.Scaled{MF: &membership.Triangular{A: 0, B: 4, C: 8}, Factor: 0.4}, 0, 0, false},
+		{"custom", custom, 0, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := NewFuzzySet("Set", tt.mf)
+			lo, hi, ok := s.AlphaCut(0.5)
+			if ok != tt.ok {
+				t.Fatalf("Expected ok=%v, got %v", tt.ok, ok)
+			}
+			if ok && (!floatEqual(lo, tt.lo) || (hi != tt.hi && !floatEqual(hi, tt.hi))) {
+				t.Errorf("Expected [%f, %f], got [%f, %f]", tt.lo, tt.hi, lo, hi)
+			}
+		})
+	}
+}
+
+func TestIsMonotonic(t *testing.T) {
+	zShape, _ := membership.NewTrapezoidal(0, 0, 20, 40)
+	sShape := (&membership.Gaussian{Center: 40, Width: 10}).RisingHalf()
+	triangle, _ := membership.NewTriangular(0, 20, 40)
+
+	tests := []struct {
+		name       string
+		mf         membership.MembershipFunction
+		monotonic  bool
+		increasing bool
+	}{
+		{"Z-shape", zShape, true, false},
+		{"S-shape", sShape, true, true},
+		{"Triangle", triangle, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs, _ := NewFuzzySet(tt.name, tt.mf)
+			monotonic, increasing := IsMonotonic(fs, 0, 40, 1000)
+			if monotonic != tt.monotonic || increasing != tt.increasing {
+				t.Errorf("Expected (%v, %v), got (%v, %v)", tt.monotonic, tt.increasing, monotonic, increasing)
+			}
+		})
+	}
+
+	fs, _ := NewFuzzySet("Triangle", triangle)
+	if monotonic, _ := IsMonotonic(fs, 0, 20, 1000); !monotonic {
+		t.Error("Expected rising half of triangle to be monotonic")
+	}
+	if monotonic, _ := IsMonotonic(fs, 40, 0, 1000); monotonic {
+		t.Error("Expected false when min >= max")
+	}
+}
@@ -3,6 +3,7 @@ package variable
 import (
 	"fmt"
 	"github.com/loian/fuzzylib/set"
+	"sort"
 )
 
 // SetRef is a type-safe reference to a fuzzy set within a variable.
@@ -19,6 +20,13 @@ type FuzzyVariable struct {
 	MinValue float64
 	MaxValue float64
 	Sets     map[string]*set.FuzzySet
+	// Unit and Description are display metadata (e.g. "°C", "Room temperature");
+	// inference ignores them
+	Unit        string
+	Description string
+
+	// order holds set names in the order AddSet added them; see SetNames
+	order []string
 }
 
 // NewFuzzyVariable creates a new fuzzy variable.
@@ -61,12 +69,78 @@ func (fv *FuzzyVariable) AddSet(fuzzySet *set.FuzzySet, err error) (*SetRef, err
 		return nil, fmt.Errorf("set '%s' already exists in variable '%s'", fuzzySet.Name, fv.Name)
 	}
 	fv.Sets[fuzzySet.Name] = fuzzySet
+	// Copies of the variable may share the backing array, so never append in place
+	fv.order = append(fv.order[:len(fv.order):len(fv.order)], fuzzySet.Name)
 	return &SetRef{
 		Variable: fv.Name,
 		Set:      fuzzySet.Name,
 	}, nil
 }
 
+// RemoveSet removes the named set from the variable. Callers are responsible for
+// ensuring that no rule references the set; an inference system holding the
+// variable needs InvalidateCaches afterwards.
+// Returns error if no set has the given name.
+func (fv *FuzzyVariable) RemoveSet(name string) error {
+	if _, exists := fv.Sets[name]; !exists {
+		return fmt.Errorf("set '%s' does not exist in variable '%s'", name, fv.Name)
+	}
+	delete(fv.Sets, name)
+	order := make([]string, 0, len(fv.order))
+	for _, n := range fv.order {
+		if n != name {
+			order = append(order, n)
+		}
+	}
+	fv.order = order
+	return nil
+}
+
+// SetNames returns the names of the variable's sets in the order they were added
+// with AddSet; ReplaceSet keeps a set's position. Sets put into Sets directly come
+// last, sorted by name.
+func (fv *FuzzyVariable) SetNames() []string {
+	names := make([]string, 0, len(fv.Sets))
+	seen := make(map[string]bool, len(fv.Sets))
+	for _, name := range fv.order {
+		if _, exists := fv.Sets[name]; exists && !seen[name] {
+			names = append(names, name)
+			seen[name] = true
+		}
+	}
+	var rest []string
+	for name := range fv.Sets {
+		if !seen[name] {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+	return append(names, rest...)
+}
+
+// ReplaceSet replaces the named set with fuzzySet, e.g. to change its membership
+// function, keeping the name so that rules referencing it stay valid, and returns
+// a fresh SetRef. As with AddSet, err is checked first so that both results of
+// set.NewFuzzySet can be passed on unchecked.
+// Returns error if err is non-nil, no set has the given name, or fuzzySet has a
+// different name.
+func (fv *FuzzyVariable) ReplaceSet(name string, fuzzySet *set.FuzzySet, err error) (*SetRef, error) {
+	if err != nil {
+		return nil, err
+	}
+	if _, exists := fv.Sets[name]; !exists {
+		return nil, fmt.Errorf("set '%s' does not exist in variable '%s'", name, fv.Name)
+	}
+	if fuzzySet.Name != name {
+		return nil, fmt.Errorf("replacement set is named '%s', expected '%s'", fuzzySet.Name, name)
+	}
+	fv.Sets[name] = fuzzySet
+	return &SetRef{
+		Variable: fv.Name,
+		Set:      name,
+	}, nil
+}
+
 // Fuzzify returns the membership degrees for all sets given a crisp value
 func (fv *FuzzyVariable) Fuzzify(value float64) map[string]float64 {
 	result := make(map[string]float64)
@@ -76,6 +150,57 @@ func (fv *FuzzyVariable) Fuzzify(value float64) map[string]float64 {
 	return result
 }
 
+// FuzzifyInto writes the membership degree of value for every set into dst,
+// keyed by set name, and returns dst. Existing keys are overwritten, so a map
+// reused across calls does not allocate once it holds every set name.
+// If dst is nil, a new map is allocated.
+func (fv *FuzzyVariable) FuzzifyInto(value float64, dst map[string]float64) map[string]float64 {
+	if dst == nil {
+		dst = make(map[string]float64, len(fv.Sets))
+	}
+	for name, fuzzySet := range fv.Sets {
+		dst[name] = fuzzySet.Evaluate(value)
+	}
+	return dst
+}
+
+// SetDomain changes the variable's domain to [minValue, maxValue] in place, keeping
+// its sets and any rules that reference them. Use SetsOutsideDomain afterwards to
+// check for sets that no longer overlap the new domain.
+// Returns error if minValue >= maxValue.
+func (fv *FuzzyVariable) SetDomain(minValue, maxValue float64) error {
+	if minValue >= maxValue {
+		return fmt.Errorf("minValue (%.2f) must be less than maxValue (%.2f)", minValue, maxValue)
+	}
+	fv.MinValue = minValue
+	fv.MaxValue = maxValue
+	return nil
+}
+
+// SetUnit sets the unit of measure shown alongside the variable's values
+func (fv *FuzzyVariable) SetUnit(unit string) {
+	fv.Unit = unit
+}
+
+// SetDescription sets a human-readable description of the variable
+func (fv *FuzzyVariable) SetDescription(description string) {
+	fv.Description = description
+}
+
+// SetsOutsideDomain returns the names (sorted) of sets whose membership is zero
+// everywhere within the domain, sampled at the given resolution. Such sets can
+// never fire. Returns nil if every set overlaps the domain.
+func (fv *FuzzyVariable) SetsOutsideDomain(resolution int) []string {
+	var names []string
+	for name, fuzzySet := range fv.Sets {
+		if fuzzySet.MaxMembership(fv.MinValue, fv.MaxValue, resolution) == 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
 // IsValid checks if a value is within the variable's domain
 func (fv *FuzzyVariable) IsValid(value float64) bool {
 	return value >= fv.MinValue && value <= fv.MaxValue
@@ -1,9 +1,11 @@
 package variable
 
 import (
+	"encoding/json"
 	"github.com/loian/fuzzylib/membership"
 	"github.com/loian/fuzzylib/set"
 	"math"
+	"reflect"
 	"testing"
 )
 
@@ -13,6 +15,14 @@ func floatEqual(a, b float64) bool {
 	return math.Abs(a-b) < epsilon
 }
 
+// Helper to unwrap membership functions in tests
+func mustMF(mf membership.MembershipFunction, err error) membership.MembershipFunction {
+	if err != nil {
+		panic(err)
+	}
+	return mf
+}
+
 // ===== FuzzySet Tests =====
 
 func TestFuzzySet_Creation(t *testing.T) {
@@ -213,6 +223,132 @@ func TestFuzzyVariable_ReplaceSet(t *testing.T) {
 	}
 }
 
+func TestFuzzyVariable_ReplaceSetMembership(t *testing.T) {
+	fv, _ := NewFuzzyVariable("Temperature", 0, 50)
+	fv.AddSet(set.NewFuzzySet("Cold", mustMF(membership.NewTriangular(0, 5, 15))))
+
+	cold, err := set.NewFuzzySet("Cold", mustMF(membership.NewTriangular(0, 0, 20)))
+	ref, err := fv.ReplaceSet("Cold", cold, err)
+	if err != nil {
+		t.Fatalf("ReplaceSet failed: %v", err)
+	}
+	if ref.Variable != "Temperature" || ref.Set != "Cold" {
+		t.Errorf("Expected reference to Temperature.Cold, got %+v", ref)
+	}
+	// Fuzzify follows the new membership function
+	if got := fv.Fuzzify(10)["Cold"]; !floatEqual(got, 0.5) {
+		t.Errorf("Expected replaced membership 0.5 at 10, got %f", got)
+	}
+
+	hot, err := set.NewFuzzySet("Hot", mustMF(membership.NewTriangular(25, 50, 50)))
+	if _, err := fv.ReplaceSet("Hot", hot, err); err == nil {
+		t.Error("Expected error replacing a missing set, got nil")
+	}
+	chilly, err := set.NewFuzzySet("Chilly", mustMF(membership.NewTriangular(0, 0, 20)))
+	if _, err := fv.ReplaceSet("Cold", chilly, err); err == nil {
+		t.Error("Expected error for a replacement with a different name, got nil")
+	}
+	invalid, err := set.NewFuzzySet("Cold", nil)
+	if _, err := fv.ReplaceSet("Cold", invalid, err); err == nil {
+		t.Error("Expected error for an invalid replacement set, got nil")
+	}
+}
+
+func TestFuzzyVariable_RemoveSet(t *testing.T) {
+	fv, _ := NewFuzzyVariable("Temperature", 0, 50)
+	fv.AddSet(set.NewFuzzySet("Cold", mustMF(membership.NewTriangular(0, 0, 20))))
+	fv.AddSet(set.NewFuzzySet("Hot", mustMF(membership.NewTriangular(25, 50, 50))))
+
+	if err := fv.RemoveSet("Cold"); err != nil {
+		t.Fatalf("RemoveSet failed: %v", err)
+	}
+	degrees := fv.Fuzzify(10)
+	if _, ok := degrees["Cold"]; ok || len(degrees) != 1 {
+		t.Errorf("Expected only Hot after removal, got %v", degrees)
+	}
+	if err := fv.RemoveSet("Cold"); err == nil {
+		t.Error("Expected error removing a missing set, got nil")
+	}
+}
+
+func TestFuzzyVariable_SetNames(t *testing.T) {
+	fv, _ := NewFuzzyVariable("Temperature", 0, 50)
+	fv.AddSet(set.NewFuzzySet("Warm", mustMF(membership.NewTriangular(10, 25, 40))))
+	fv.AddSet(set.NewFuzzySet("Cold", mustMF(membership.NewTriangular(0, 0, 20))))
+	fv.AddSet(set.NewFuzzySet("Hot", mustMF(membership.NewTriangular(30, 50, 50))))
+	cold, err := set.NewFuzzySet("Cold", mustMF(membership.NewTriangular(0, 0, 15)))
+	if _, err := fv.ReplaceSet("Cold", cold, err); err != nil {
+		t.Fatalf("ReplaceSet failed: %v", err)
+	}
+
+	// A copy made like FrozenSystem's keeps its own order
+	copied := *fv
+	copied.Sets = make(map[string]*set.FuzzySet, len(fv.Sets))
+	for name, s := range fv.Sets {
+		copied.Sets[name] = s
+	}
+	_ = fv.RemoveSet("Warm")
+	fv.Sets["Boiling"], _ = set.NewFuzzySet("Boiling", mustMF(membership.NewTriangular(45, 50, 50)))
+	fv.Sets["Arctic"], _ = set.NewFuzzySet("Arctic", mustMF(membership.NewTriangular(0, 0, 5)))
+
+	// Insertion order, then sets added to the map directly by name
+	if got, want := fv.SetNames(), []string{"Cold", "Hot", "Arctic", "Boiling"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected set names %v, got %v", want, got)
+	}
+	if got, want := copied.SetNames(), []string{"Warm", "Cold", "Hot"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected the copy to keep %v, got %v", want, got)
+	}
+}
+
+func TestFuzzyVariable_SetDomain(t *testing.T) {
+	fv, _ := NewFuzzyVariable("Temperature", 0, 50)
+	hot, _ := membership.NewTriangular(40, 55, 60)
+	fv.AddSet(set.NewFuzzySet("Hot", hot))
+
+	// 55 is outside [0, 50] until the domain is widened
+	if fv.IsValid(55) {
+		t.Fatal("Expected 55 to be outside the original domain")
+	}
+	if err := fv.SetDomain(0, 60); err != nil {
+		t.Fatalf("SetDomain failed: %v", err)
+	}
+	if fv.MinValue != 0 || fv.MaxValue != 60 {
+		t.Errorf("Expected domain [0, 60], got [%f, %f]", fv.MinValue, fv.MaxValue)
+	}
+	if !fv.IsValid(55) {
+		t.Error("Expected 55 to be inside the widened domain")
+	}
+	if got := fv.Fuzzify(55)["Hot"]; !floatEqual(got, 1.0) {
+		t.Errorf("Expected Hot membership 1.0 at 55, got %f", got)
+	}
+
+	if err := fv.SetDomain(10, 10); err == nil {
+		t.Error("Expected error for empty domain, got nil")
+	}
+	if fv.MaxValue != 60 {
+		t.Error("Invalid SetDomain should leave the domain unchanged")
+	}
+}
+
+func TestFuzzyVariable_SetsOutsideDomain(t *testing.T) {
+	fv, _ := NewFuzzyVariable("Temperature", 0, 60)
+	cold, _ := membership.NewTriangular(0, 0, 20)
+	hot, _ := membership.NewTriangular(40, 55, 60)
+	fv.AddSet(set.NewFuzzySet("Cold", cold))
+	fv.AddSet(set.NewFuzzySet("Hot", hot))
+
+	if names := fv.SetsOutsideDomain(1000); len(names) != 0 {
+		t.Errorf("Expected no sets outside domain, got %v", names)
+	}
+
+	// Narrowing to [0, 30] leaves Hot without any support
+	_ = fv.SetDomain(0, 30)
+	names := fv.SetsOutsideDomain(1000)
+	if len(names) != 1 || names[0] != "Hot" {
+		t.Errorf("Expected [Hot] outside domain, got %v", names)
+	}
+}
+
 // ===== Integration Tests =====
 
 func TestTemperatureControlExample(t *testing.T) {
@@ -390,3 +526,24 @@ func TestSetRef_TypeSafeRuleConstruction(t *testing.T) {
 		}
 	}
 }
+
+func TestFuzzyVariable_Metadata(t *testing.T) {
+	fv, _ := NewFuzzyVariable("Temperature", 0, 50)
+	fv.SetUnit("°C")
+	fv.SetDescription("Room temperature")
+
+	data, err := json.Marshal(fv)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	var decoded FuzzyVariable
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	if decoded.Unit != "°C" || decoded.Description != "Room temperature" {
+		t.Errorf("Expected metadata to round-trip, got unit %q description %q", decoded.Unit, decoded.Description)
+	}
+	if decoded.Name != "Temperature" || decoded.MinValue != 0 || decoded.MaxValue != 50 {
+		t.Errorf("Expected name and domain to round-trip, got %+v", decoded)
+	}
+}